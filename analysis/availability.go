@@ -0,0 +1,142 @@
+package analysis
+
+import (
+	"sort"
+
+	"microservice_overview/models"
+)
+
+// Availability liczy teoretyczną dostępność każdego liścia hierarchii przy
+// założeniu niezależnych awarii. Twarde zależności to relacje typów
+// synchronicznych (oraz relacje bez typu) bez fallbacku - awaria celu
+// wyłącza źródło. Relacje asynchroniczne i z fallbackiem są miękkie i nie
+// obniżają dostępności. Dostępność zapewniana przez zależności to iloczyn SLO
+// wszystkich wierzchołków osiągalnych twardymi zależnościami (każdy liczony
+// raz, również przy wspólnych zależnościach i cyklach); wierzchołek bez SLO
+// liczony jest jako 100%.
+func Availability(vertices []models.Vertex, edges []models.Edge, edgeTypes []models.EdgeType) *models.AvailabilityReport {
+	leaves := leafVertices(vertices)
+	byID := make(map[string]*models.Vertex, len(leaves))
+	for i := range leaves {
+		byID[leaves[i].ID] = &leaves[i]
+	}
+
+	types := make(map[string]*models.EdgeType, len(edgeTypes))
+	for i := range edgeTypes {
+		types[edgeTypes[i].Name] = &edgeTypes[i]
+	}
+
+	hard := make(map[string][]string)
+	soft := make(map[string][]string)
+	for _, edge := range edges {
+		edgeType := types[edge.Type]
+		isHard := !edge.Fallback && (edgeType == nil || edgeType.Synchronous)
+		for _, dep := range edgeType.Dependencies(edge) {
+			if byID[dep.Dependent] == nil || byID[dep.Dependency] == nil || dep.Dependent == dep.Dependency {
+				continue
+			}
+			if isHard {
+				hard[dep.Dependent] = append(hard[dep.Dependent], dep.Dependency)
+			} else {
+				soft[dep.Dependent] = append(soft[dep.Dependent], dep.Dependency)
+			}
+		}
+	}
+
+	report := &models.AvailabilityReport{
+		Vertices:     make([]models.VertexAvailability, 0, len(leaves)),
+		Unachievable: []string{},
+	}
+	for _, vertex := range leaves {
+		hardDeps := closure(vertex.ID, hard, nil)
+		// Miękkie: osiągalne dowolnymi relacjami, ale nie twardymi
+		softDeps := closure(vertex.ID, hard, soft)
+		softDeps = without(softDeps, hardDeps)
+
+		item := models.VertexAvailability{
+			ID:               vertex.ID,
+			Name:             vertex.Name,
+			HardDependencies: hardDeps,
+			SoftDependencies: softDeps,
+		}
+
+		achievable := 1.0
+		weakest := 101.0
+		for _, id := range hardDeps {
+			slo := byID[id].AvailabilitySLO
+			if slo == 0 {
+				item.UnknownSLO = append(item.UnknownSLO, id)
+				continue
+			}
+			achievable *= slo / 100
+			if slo < weakest {
+				weakest = slo
+				item.Weakest = id
+			}
+		}
+		composed := achievable
+		if vertex.AvailabilitySLO > 0 {
+			slo := vertex.AvailabilitySLO
+			item.SLO = &slo
+			composed *= slo / 100
+			item.Unachievable = slo/100 > achievable+1e-12
+		}
+		item.Achievable = round(achievable * 100)
+		item.Composed = round(composed * 100)
+
+		if item.Unachievable {
+			report.Unachievable = append(report.Unachievable, vertex.ID)
+		}
+		report.Vertices = append(report.Vertices, item)
+	}
+
+	sort.SliceStable(report.Vertices, func(i, j int) bool {
+		a, b := report.Vertices[i], report.Vertices[j]
+		if a.Unachievable != b.Unachievable {
+			return a.Unachievable
+		}
+		if a.Composed != b.Composed {
+			return a.Composed < b.Composed
+		}
+		return a.ID < b.ID
+	})
+	return report
+}
+
+// closure zwraca posortowane wierzchołki osiągalne ze start po relacjach z
+// obu map (druga może być nil), bez samego start
+func closure(start string, first, second map[string][]string) []string {
+	visited := map[string]bool{start: true}
+	queue := []string{start}
+	result := []string{}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		next := append(append([]string(nil), first[id]...), second[id]...)
+		for _, dep := range next {
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+			queue = append(queue, dep)
+			result = append(result, dep)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// without zwraca elementy ids, których nie ma w excluded (obie listy posortowane)
+func without(ids, excluded []string) []string {
+	skip := make(map[string]bool, len(excluded))
+	for _, id := range excluded {
+		skip[id] = true
+	}
+	result := []string{}
+	for _, id := range ids {
+		if !skip[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
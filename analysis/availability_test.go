@@ -0,0 +1,95 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"microservice_overview/models"
+)
+
+var availabilityEdgeTypes = []models.EdgeType{
+	{Name: "calls", Direction: models.DirectionForward, Synchronous: true},
+	{Name: "calls_async", Direction: models.DirectionForward},
+	{Name: "publishes", Direction: models.DirectionReverse},
+}
+
+func availabilityByID(report *models.AvailabilityReport) map[string]models.VertexAvailability {
+	byID := make(map[string]models.VertexAvailability)
+	for _, v := range report.Vertices {
+		byID[v.ID] = v
+	}
+	return byID
+}
+
+func TestAvailability_Composition(t *testing.T) {
+	// gateway -> orders -> db (twarde), orders -> mailer (asynchroniczne),
+	// gateway -> recommendations (z fallbackiem)
+	vertices := []models.Vertex{
+		{ID: "gateway", AvailabilitySLO: 99.99},
+		{ID: "orders", AvailabilitySLO: 99.9},
+		{ID: "db", AvailabilitySLO: 99.95},
+		{ID: "mailer", AvailabilitySLO: 95},
+		{ID: "recommendations", AvailabilitySLO: 90},
+	}
+	edges := []models.Edge{
+		{From: "gateway", To: "orders", Type: "calls"},
+		{From: "orders", To: "db", Type: "calls"},
+		{From: "orders", To: "mailer", Type: "calls_async"},
+		{From: "gateway", To: "recommendations", Type: "calls", Fallback: true},
+	}
+	report := Availability(vertices, edges, availabilityEdgeTypes)
+	byID := availabilityByID(report)
+
+	gateway := byID["gateway"]
+	if !reflect.DeepEqual(gateway.HardDependencies, []string{"db", "orders"}) {
+		t.Errorf("Unexpected hard dependencies: %v", gateway.HardDependencies)
+	}
+	if !reflect.DeepEqual(gateway.SoftDependencies, []string{"mailer", "recommendations"}) {
+		t.Errorf("Unexpected soft dependencies: %v", gateway.SoftDependencies)
+	}
+	// 99.9% * 99.95% = 99.85005%
+	if gateway.Achievable != 99.85005 || gateway.Weakest != "orders" || !gateway.Unachievable {
+		t.Errorf("Unexpected gateway availability: %+v", gateway)
+	}
+	if db := byID["db"]; db.Achievable != 100 || db.Composed != 99.95 || db.Unachievable {
+		t.Errorf("Unexpected db availability: %+v", db)
+	}
+	if !reflect.DeepEqual(report.Unachievable, []string{"gateway"}) || report.Vertices[0].ID != "gateway" {
+		t.Errorf("Expected only gateway to be flagged first, got %v", report.Unachievable)
+	}
+}
+
+func TestAvailability_SharedDependencyAndUnknownSLO(t *testing.T) {
+	// a -> b -> d, a -> c -> d: wspólna zależność d liczona raz; c bez SLO
+	vertices := []models.Vertex{
+		{ID: "a"},
+		{ID: "b", AvailabilitySLO: 99},
+		{ID: "c"},
+		{ID: "d", AvailabilitySLO: 99},
+	}
+	edges := []models.Edge{
+		{From: "a", To: "b", Type: "calls"},
+		{From: "a", To: "c", Type: "calls"},
+		{From: "b", To: "d", Type: "calls"},
+		{From: "c", To: "d", Type: "calls"},
+	}
+	a := availabilityByID(Availability(vertices, edges, availabilityEdgeTypes))["a"]
+
+	if a.Achievable != 98.01 || a.Composed != 98.01 || a.SLO != nil || a.Unachievable {
+		t.Errorf("Unexpected availability: %+v", a)
+	}
+	if !reflect.DeepEqual(a.UnknownSLO, []string{"c"}) {
+		t.Errorf("Expected c with unknown SLO, got %v", a.UnknownSLO)
+	}
+}
+
+func TestAvailability_ReverseDirectionIsSoft(t *testing.T) {
+	// publishes: konsument zależy od producenta, ale asynchronicznie
+	vertices := []models.Vertex{{ID: "producer", AvailabilitySLO: 90}, {ID: "consumer", AvailabilitySLO: 99.9}}
+	edges := []models.Edge{{From: "producer", To: "consumer", Type: "publishes"}}
+	consumer := availabilityByID(Availability(vertices, edges, availabilityEdgeTypes))["consumer"]
+
+	if len(consumer.HardDependencies) != 0 || !reflect.DeepEqual(consumer.SoftDependencies, []string{"producer"}) || consumer.Unachievable {
+		t.Errorf("Unexpected consumer availability: %+v", consumer)
+	}
+}
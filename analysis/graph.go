@@ -0,0 +1,120 @@
+// Package analysis liczy miary grafu zależności: centralność, stabilność,
+// głębokość łańcuchów oraz statystyki całego grafu.
+package analysis
+
+import (
+	"sort"
+
+	"microservice_overview/models"
+)
+
+// Graph skierowany graf zależności między liśćmi hierarchii. Krawędź u -> v
+// oznacza, że u zależy od v. Wierzchołki są indeksowane w kolejności ID.
+type Graph struct {
+	ids   []string
+	names []string
+	index map[string]int
+	out   [][]int             // zależności
+	in    [][]int             // zależni
+	edges map[[2]int][]string // ID relacji wyrażających zależność u -> v
+}
+
+// leafVertices zwraca wierzchołki bez dzieci posortowane po ID
+func leafVertices(vertices []models.Vertex) []models.Vertex {
+	parents := make(map[string]bool)
+	for _, v := range vertices {
+		if v.ParentID != nil && *v.ParentID != "" {
+			parents[*v.ParentID] = true
+		}
+	}
+
+	var leaves []models.Vertex
+	for _, v := range vertices {
+		if !parents[v.ID] {
+			leaves = append(leaves, v)
+		}
+	}
+	sort.Slice(leaves, func(i, j int) bool { return leaves[i].ID < leaves[j].ID })
+	return leaves
+}
+
+// NewGraph buduje graf zależności. Wierzchołki mające dzieci grupują inne
+// wierzchołki i są pomijane; wielokrotne relacje i pętle są scalane.
+func NewGraph(vertices []models.Vertex, edges []models.Edge, edgeTypes []models.EdgeType) *Graph {
+	leaves := leafVertices(vertices)
+
+	g := &Graph{
+		ids:   make([]string, len(leaves)),
+		names: make([]string, len(leaves)),
+		index: make(map[string]int, len(leaves)),
+		out:   make([][]int, len(leaves)),
+		in:    make([][]int, len(leaves)),
+		edges: make(map[[2]int][]string),
+	}
+	for i, v := range leaves {
+		g.ids[i] = v.ID
+		g.names[i] = v.Name
+		g.index[v.ID] = i
+	}
+
+	types := make(map[string]*models.EdgeType, len(edgeTypes))
+	for i := range edgeTypes {
+		types[edgeTypes[i].Name] = &edgeTypes[i]
+	}
+
+	for _, edge := range edges {
+		for _, dep := range types[edge.Type].Dependencies(edge) {
+			u, okU := g.index[dep.Dependent]
+			v, okV := g.index[dep.Dependency]
+			if !okU || !okV || u == v {
+				continue
+			}
+			key := [2]int{u, v}
+			if _, seen := g.edges[key]; !seen {
+				g.out[u] = append(g.out[u], v)
+				g.in[v] = append(g.in[v], u)
+			}
+			g.edges[key] = append(g.edges[key], edge.ID)
+		}
+	}
+	for i := range g.out {
+		sort.Ints(g.out[i])
+		sort.Ints(g.in[i])
+	}
+	return g
+}
+
+// Len zwraca liczbę wierzchołków grafu
+func (g *Graph) Len() int {
+	return len(g.ids)
+}
+
+// ID zwraca ID wierzchołka o danym indeksie
+func (g *Graph) ID(i int) string {
+	return g.ids[i]
+}
+
+// Index zwraca indeks wierzchołka o danym ID
+func (g *Graph) Index(id string) (int, bool) {
+	i, ok := g.index[id]
+	return i, ok
+}
+
+// Dependencies zwraca indeksy wierzchołków, od których zależy wierzchołek i
+func (g *Graph) Dependencies(i int) []int {
+	return g.out[i]
+}
+
+// Dependents zwraca indeksy wierzchołków zależnych od wierzchołka i
+func (g *Graph) Dependents(i int) []int {
+	return g.in[i]
+}
+
+// dependencyCount zwraca liczbę krawędzi grafu
+func (g *Graph) dependencyCount() int {
+	count := 0
+	for _, out := range g.out {
+		count += len(out)
+	}
+	return count
+}
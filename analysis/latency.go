@@ -0,0 +1,198 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"microservice_overview/models"
+)
+
+// ErrSynchronousCycle zwracany gdy z punktu wejścia osiągalny jest cykl wywołań
+// synchronicznych - najgorsze opóźnienie jest wtedy nieograniczone
+var ErrSynchronousCycle = errors.New("synchronous call cycle")
+
+// SynchronousCycleError błąd z wierzchołkami cyklu wywołań synchronicznych
+type SynchronousCycleError struct {
+	Cycle []string
+}
+
+func (e *SynchronousCycleError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrSynchronousCycle, strings.Join(e.Cycle, " -> "))
+}
+
+// Is pozwala porównywać błąd z ErrSynchronousCycle przez errors.Is
+func (e *SynchronousCycleError) Is(target error) bool {
+	return target == ErrSynchronousCycle
+}
+
+// call wywołanie synchroniczne; z kilku relacji między tą samą parą
+// zachowywana jest ta o największym opóźnieniu
+type call struct {
+	to        string
+	edgeID    string
+	latencyMs float64
+}
+
+// CriticalPath liczy najgorsze opóźnienie synchroniczne od punktu wejścia.
+// Uwzględniane są tylko relacje typów oznaczonych jako synchroniczne, w
+// kierunku zależności typu. Wywołania wierzchołka traktowane są jako
+// równoległe, więc czas odpowiedzi to czas obsługi plus najwolniejsze
+// wywołanie - ścieżka krytyczna prowadzi przez najwolniejsze wywołania.
+// Podany budżet (ms) dodaje do raportu zapas całkowity i dla każdego wierzchołka.
+func CriticalPath(vertices []models.Vertex, edges []models.Edge, edgeTypes []models.EdgeType, entrypoint string, budgetMs *float64) (*models.LatencyReport, error) {
+	byID := make(map[string]*models.Vertex, len(vertices))
+	for i := range vertices {
+		byID[vertices[i].ID] = &vertices[i]
+	}
+	if _, ok := byID[entrypoint]; !ok {
+		return nil, fmt.Errorf("entrypoint vertex %s not found", entrypoint)
+	}
+
+	types := make(map[string]*models.EdgeType, len(edgeTypes))
+	for i := range edgeTypes {
+		types[edgeTypes[i].Name] = &edgeTypes[i]
+	}
+
+	calls := make(map[string][]call)
+	for _, edge := range edges {
+		edgeType := types[edge.Type]
+		if edgeType == nil || !edgeType.Synchronous {
+			continue
+		}
+		for _, dep := range edgeType.Dependencies(edge) {
+			if byID[dep.Dependent] == nil || byID[dep.Dependency] == nil {
+				continue
+			}
+			calls[dep.Dependent] = addCall(calls[dep.Dependent], call{to: dep.Dependency, edgeID: edge.ID, latencyMs: edge.LatencyMs})
+		}
+	}
+	for id := range calls {
+		sort.Slice(calls[id], func(i, j int) bool { return calls[id][i].to < calls[id][j].to })
+	}
+
+	// Czas odpowiedzi każdego osiągalnego wierzchołka (DFS z wykrywaniem cykli)
+	latency := make(map[string]float64)
+	next := make(map[string]call)
+	state := make(map[string]int) // 0 - nieodwiedzony, 1 - na stosie, 2 - policzony
+	var order []string            // kolejność post-order
+	var stack []string
+	var visit func(id string) error
+	visit = func(id string) error {
+		state[id] = 1
+		stack = append(stack, id)
+		best := -1.0
+		for _, c := range calls[id] {
+			switch state[c.to] {
+			case 1:
+				start := len(stack) - 1
+				for stack[start] != c.to {
+					start--
+				}
+				return &SynchronousCycleError{Cycle: append(append([]string(nil), stack[start:]...), c.to)}
+			case 0:
+				if err := visit(c.to); err != nil {
+					return err
+				}
+			}
+			if total := c.latencyMs + latency[c.to]; total > best {
+				best = total
+				next[id] = c
+			}
+		}
+		latency[id] = byID[id].ProcessingMs
+		if best > 0 {
+			latency[id] += best
+		}
+		state[id] = 2
+		stack = stack[:len(stack)-1]
+		order = append(order, id)
+		return nil
+	}
+	if err := visit(entrypoint); err != nil {
+		return nil, err
+	}
+
+	// Najgorszy czas dotarcia - w odwrotnej kolejności post-order (topologicznej)
+	arrival := map[string]float64{entrypoint: 0}
+	for i := len(order) - 1; i >= 0; i-- {
+		id := order[i]
+		for _, c := range calls[id] {
+			if t := arrival[id] + byID[id].ProcessingMs + c.latencyMs; t > arrival[c.to] {
+				arrival[c.to] = t
+			}
+		}
+	}
+
+	report := &models.LatencyReport{
+		Entrypoint:    entrypoint,
+		TotalMs:       round(latency[entrypoint]),
+		BudgetMs:      budgetMs,
+		CriticalPath:  []models.PathStep{},
+		CriticalEdges: []string{},
+		Vertices:      make([]models.VertexLatency, 0, len(order)),
+	}
+
+	critical := make(map[string]bool)
+	step := models.PathStep{ID: entrypoint}
+	for {
+		vertex := byID[step.ID]
+		critical[step.ID] = true
+		step.Name = vertex.Name
+		step.ProcessingMs = vertex.ProcessingMs
+		step.ArrivalMs = round(arrival[step.ID])
+		report.CriticalPath = append(report.CriticalPath, step)
+		c, ok := next[step.ID]
+		if !ok {
+			break
+		}
+		report.CriticalEdges = append(report.CriticalEdges, c.edgeID)
+		step = models.PathStep{ID: c.to, EdgeID: c.edgeID, LatencyMs: c.latencyMs}
+	}
+
+	if budgetMs != nil {
+		remaining := round(*budgetMs - latency[entrypoint])
+		within := remaining >= 0
+		report.RemainingMs = &remaining
+		report.WithinBudget = &within
+	}
+
+	for _, id := range order {
+		vertex := byID[id]
+		item := models.VertexLatency{
+			ID:           id,
+			Name:         vertex.Name,
+			ProcessingMs: vertex.ProcessingMs,
+			ArrivalMs:    round(arrival[id]),
+			LatencyMs:    round(latency[id]),
+			Critical:     critical[id],
+		}
+		if budgetMs != nil {
+			slack := round(*budgetMs - arrival[id] - latency[id])
+			item.SlackMs = &slack
+		}
+		report.Vertices = append(report.Vertices, item)
+	}
+	sort.Slice(report.Vertices, func(i, j int) bool {
+		a, b := report.Vertices[i], report.Vertices[j]
+		if a.ArrivalMs != b.ArrivalMs {
+			return a.ArrivalMs < b.ArrivalMs
+		}
+		return a.ID < b.ID
+	})
+	return report, nil
+}
+
+// addCall dodaje wywołanie, zachowując tylko najwolniejszą relację do danego celu
+func addCall(calls []call, c call) []call {
+	for i := range calls {
+		if calls[i].to == c.to {
+			if c.latencyMs > calls[i].latencyMs {
+				calls[i] = c
+			}
+			return calls
+		}
+	}
+	return append(calls, c)
+}
@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"microservice_overview/models"
+)
+
+var latencyEdgeTypes = []models.EdgeType{
+	{Name: "calls", Direction: models.DirectionForward, Synchronous: true},
+	{Name: "calls_async", Direction: models.DirectionForward},
+}
+
+func TestCriticalPath_SlowestBranch(t *testing.T) {
+	// gateway -> orders -> payments jest wolniejsze niż gateway -> users
+	vertices := []models.Vertex{
+		{ID: "gateway", ProcessingMs: 5},
+		{ID: "orders", ProcessingMs: 20},
+		{ID: "payments", ProcessingMs: 50},
+		{ID: "users", ProcessingMs: 30},
+		{ID: "mailer", ProcessingMs: 500},
+	}
+	edges := []models.Edge{
+		{ID: "e1", From: "gateway", To: "orders", Type: "calls", LatencyMs: 2},
+		{ID: "e2", From: "orders", To: "payments", Type: "calls", LatencyMs: 3},
+		{ID: "e3", From: "gateway", To: "users", Type: "calls", LatencyMs: 2},
+		{ID: "e4", From: "orders", To: "mailer", Type: "calls_async", LatencyMs: 1},
+	}
+	budget := 70.0
+	report, err := CriticalPath(vertices, edges, latencyEdgeTypes, "gateway", &budget)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if report.TotalMs != 80 {
+		t.Errorf("Expected total 80ms, got %v", report.TotalMs)
+	}
+	if !reflect.DeepEqual(report.CriticalEdges, []string{"e1", "e2"}) {
+		t.Errorf("Expected critical edges e1, e2, got %v", report.CriticalEdges)
+	}
+	if last := report.CriticalPath[len(report.CriticalPath)-1]; last.ID != "payments" || last.ArrivalMs != 30 {
+		t.Errorf("Unexpected last step: %+v", last)
+	}
+	if *report.WithinBudget || *report.RemainingMs != -10 {
+		t.Errorf("Expected budget exceeded by 10ms, got %v %v", *report.WithinBudget, *report.RemainingMs)
+	}
+
+	byID := make(map[string]models.VertexLatency)
+	for _, v := range report.Vertices {
+		byID[v.ID] = v
+	}
+	if _, ok := byID["mailer"]; ok {
+		t.Errorf("Expected asynchronous calls to be skipped")
+	}
+	// users: dotarcie 7ms + odpowiedź 30ms -> zapas 33ms
+	if users := byID["users"]; users.Critical || *users.SlackMs != 33 {
+		t.Errorf("Unexpected users latency: %+v", users)
+	}
+}
+
+func TestCriticalPath_SlowestParallelEdge(t *testing.T) {
+	vertices := []models.Vertex{{ID: "a"}, {ID: "b", ProcessingMs: 1}}
+	edges := []models.Edge{
+		{ID: "fast", From: "a", To: "b", Type: "calls", LatencyMs: 1},
+		{ID: "slow", From: "a", To: "b", Type: "calls", LatencyMs: 9},
+	}
+	report, err := CriticalPath(vertices, edges, latencyEdgeTypes, "a", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.TotalMs != 10 || !reflect.DeepEqual(report.CriticalEdges, []string{"slow"}) || report.WithinBudget != nil {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+}
+
+func TestCriticalPath_Cycle(t *testing.T) {
+	vertices := []models.Vertex{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	edges := []models.Edge{
+		{ID: "e1", From: "a", To: "b", Type: "calls"},
+		{ID: "e2", From: "b", To: "c", Type: "calls"},
+		{ID: "e3", From: "c", To: "b", Type: "calls"},
+	}
+	_, err := CriticalPath(vertices, edges, latencyEdgeTypes, "a", nil)
+
+	var cycleErr *SynchronousCycleError
+	if !errors.Is(err, ErrSynchronousCycle) || !errors.As(err, &cycleErr) {
+		t.Fatalf("Expected synchronous cycle error, got %v", err)
+	}
+	if !reflect.DeepEqual(cycleErr.Cycle, []string{"b", "c", "b"}) {
+		t.Errorf("Unexpected cycle: %v", cycleErr.Cycle)
+	}
+}
@@ -0,0 +1,350 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"microservice_overview/models"
+)
+
+// Parametry PageRank
+const (
+	pageRankDamping   = 0.85
+	pageRankTolerance = 1e-9
+	pageRankMaxIter   = 200
+)
+
+// Metrics liczy miary wszystkich wierzchołków i statystyki grafu
+func Metrics(g *Graph) *models.GraphMetrics {
+	n := g.Len()
+	betweenness := Betweenness(g)
+	pageRank := PageRank(g)
+	components := StronglyConnected(g)
+	depth, chain := longestChains(g, components)
+
+	inCycle := make([]bool, n)
+	cycles := 0
+	for _, component := range components.Members {
+		if len(component) > 1 {
+			cycles++
+			for _, v := range component {
+				inCycle[v] = true
+			}
+		}
+	}
+
+	result := &models.GraphMetrics{Vertices: make([]models.VertexMetrics, n)}
+	for i := 0; i < n; i++ {
+		ca, ce := len(g.in[i]), len(g.out[i])
+		instability := 0.0
+		if ca+ce > 0 {
+			instability = float64(ce) / float64(ca+ce)
+		}
+		result.Vertices[i] = models.VertexMetrics{
+			ID:          g.ids[i],
+			Name:        g.names[i],
+			FanIn:       ca,
+			FanOut:      ce,
+			Betweenness: round(betweenness[i]),
+			PageRank:    round(pageRank[i]),
+			Instability: round(instability),
+			Depth:       depth[i],
+			InCycle:     inCycle[i],
+		}
+	}
+	sort.SliceStable(result.Vertices, func(i, j int) bool {
+		a, b := result.Vertices[i], result.Vertices[j]
+		if a.Betweenness != b.Betweenness {
+			return a.Betweenness > b.Betweenness
+		}
+		if a.FanIn != b.FanIn {
+			return a.FanIn > b.FanIn
+		}
+		return a.ID < b.ID
+	})
+
+	longestChain := make([]string, len(chain))
+	for i, v := range chain {
+		longestChain[i] = g.ids[v]
+	}
+	longestPath := 0
+	if len(chain) > 0 {
+		longestPath = len(chain) - 1
+	}
+
+	density := 0.0
+	if n > 1 {
+		density = float64(g.dependencyCount()) / float64(n*(n-1))
+	}
+
+	result.Stats = models.GraphStats{
+		Vertices:     n,
+		Dependencies: g.dependencyCount(),
+		Density:      round(density),
+		LongestPath:  longestPath,
+		LongestChain: longestChain,
+		Components:   WeaklyConnectedCount(g),
+		Cycles:       cycles,
+	}
+	return result
+}
+
+// Betweenness liczy znormalizowaną centralność pośrednictwa algorytmem Brandesa
+// (graf skierowany, bez wag). Wynik dzielony jest przez (n-1)(n-2).
+func Betweenness(g *Graph) []float64 {
+	n := g.Len()
+	centrality := make([]float64, n)
+
+	for s := 0; s < n; s++ {
+		stack := make([]int, 0, n)
+		predecessors := make([][]int, n)
+		sigma := make([]float64, n)
+		distance := make([]int, n)
+		for i := range distance {
+			distance[i] = -1
+		}
+		sigma[s] = 1
+		distance[s] = 0
+
+		queue := []int{s}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			stack = append(stack, v)
+			for _, w := range g.out[v] {
+				if distance[w] < 0 {
+					distance[w] = distance[v] + 1
+					queue = append(queue, w)
+				}
+				if distance[w] == distance[v]+1 {
+					sigma[w] += sigma[v]
+					predecessors[w] = append(predecessors[w], v)
+				}
+			}
+		}
+
+		delta := make([]float64, n)
+		for i := len(stack) - 1; i >= 0; i-- {
+			w := stack[i]
+			for _, v := range predecessors[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+			if w != s {
+				centrality[w] += delta[w]
+			}
+		}
+	}
+
+	if n > 2 {
+		scale := 1 / float64((n-1)*(n-2))
+		for i := range centrality {
+			centrality[i] *= scale
+		}
+	}
+	return centrality
+}
+
+// PageRank liczy PageRank na grafie zależności - ważność przepływa od
+// wierzchołka zależnego do jego zależności. Wierzchołki bez zależności
+// rozdzielają swoją wagę równo między wszystkie wierzchołki.
+func PageRank(g *Graph) []float64 {
+	n := g.Len()
+	if n == 0 {
+		return nil
+	}
+
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+
+	for iter := 0; iter < pageRankMaxIter; iter++ {
+		dangling := 0.0
+		for i := 0; i < n; i++ {
+			if len(g.out[i]) == 0 {
+				dangling += rank[i]
+			}
+		}
+
+		next := make([]float64, n)
+		base := (1-pageRankDamping)/float64(n) + pageRankDamping*dangling/float64(n)
+		for i := range next {
+			next[i] = base
+		}
+		for u := 0; u < n; u++ {
+			if len(g.out[u]) == 0 {
+				continue
+			}
+			share := pageRankDamping * rank[u] / float64(len(g.out[u]))
+			for _, v := range g.out[u] {
+				next[v] += share
+			}
+		}
+
+		diff := 0.0
+		for i := range next {
+			diff += math.Abs(next[i] - rank[i])
+		}
+		rank = next
+		if diff < pageRankTolerance {
+			break
+		}
+	}
+	return rank
+}
+
+// Components podział grafu na silnie spójne składowe
+type Components struct {
+	Of      []int   // indeks składowej każdego wierzchołka
+	Members [][]int // wierzchołki każdej składowej; składowe w odwrotnej kolejności topologicznej
+}
+
+// StronglyConnected wyznacza silnie spójne składowe algorytmem Tarjana
+// (iteracyjnie, żeby duże grafy nie przepełniały stosu)
+func StronglyConnected(g *Graph) *Components {
+	n := g.Len()
+	index := make([]int, n)
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	for i := range index {
+		index[i] = -1
+	}
+	result := &Components{Of: make([]int, n)}
+
+	counter := 0
+	var stack []int
+	type frame struct{ v, next int }
+
+	for root := 0; root < n; root++ {
+		if index[root] >= 0 {
+			continue
+		}
+		calls := []frame{{v: root}}
+		index[root], low[root] = counter, counter
+		counter++
+		stack = append(stack, root)
+		onStack[root] = true
+
+		for len(calls) > 0 {
+			top := &calls[len(calls)-1]
+			v := top.v
+			if top.next < len(g.out[v]) {
+				w := g.out[v][top.next]
+				top.next++
+				if index[w] < 0 {
+					index[w], low[w] = counter, counter
+					counter++
+					stack = append(stack, w)
+					onStack[w] = true
+					calls = append(calls, frame{v: w})
+				} else if onStack[w] && index[w] < low[v] {
+					low[v] = index[w]
+				}
+				continue
+			}
+
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				parent := calls[len(calls)-1].v
+				if low[v] < low[parent] {
+					low[parent] = low[v]
+				}
+			}
+			if low[v] == index[v] {
+				var members []int
+				for {
+					w := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[w] = false
+					result.Of[w] = len(result.Members)
+					members = append(members, w)
+					if w == v {
+						break
+					}
+				}
+				sort.Ints(members)
+				result.Members = append(result.Members, members)
+			}
+		}
+	}
+	return result
+}
+
+// longestChains liczy dla każdego wierzchołka długość najdłuższego łańcucha
+// zależnych, który do niego prowadzi, oraz najdłuższy łańcuch w grafie.
+// Cykle są scalane w jeden węzeł (graf składowych jest acykliczny).
+func longestChains(g *Graph, components *Components) ([]int, []int) {
+	count := len(components.Members)
+	depth := make([]int, count)
+	previous := make([]int, count)
+	for i := range previous {
+		previous[i] = -1
+	}
+
+	// Tarjan zwraca składowe w odwrotnej kolejności topologicznej, więc
+	// przechodząc od końca zawsze mamy już policzonych wszystkich zależnych
+	for c := count - 1; c >= 0; c-- {
+		for _, v := range components.Members[c] {
+			for _, w := range g.out[v] {
+				target := components.Of[w]
+				if target == c {
+					continue
+				}
+				if depth[c]+1 > depth[target] || (depth[c]+1 == depth[target] && previous[target] > c) {
+					depth[target] = depth[c] + 1
+					previous[target] = c
+				}
+			}
+		}
+	}
+
+	vertexDepth := make([]int, g.Len())
+	for v := range vertexDepth {
+		vertexDepth[v] = depth[components.Of[v]]
+	}
+
+	end := -1
+	for c := 0; c < count; c++ {
+		if end < 0 || depth[c] > depth[end] || (depth[c] == depth[end] && components.Members[c][0] < components.Members[end][0]) {
+			end = c
+		}
+	}
+	var chain []int
+	for c := end; c >= 0; c = previous[c] {
+		chain = append([]int{components.Members[c][0]}, chain...)
+	}
+	return vertexDepth, chain
+}
+
+// WeaklyConnectedCount zwraca liczbę słabo spójnych składowych grafu
+func WeaklyConnectedCount(g *Graph) int {
+	n := g.Len()
+	visited := make([]bool, n)
+	count := 0
+	for start := 0; start < n; start++ {
+		if visited[start] {
+			continue
+		}
+		count++
+		visited[start] = true
+		queue := []int{start}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, neighbours := range [][]int{g.out[v], g.in[v]} {
+				for _, w := range neighbours {
+					if !visited[w] {
+						visited[w] = true
+						queue = append(queue, w)
+					}
+				}
+			}
+		}
+	}
+	return count
+}
+
+// round zaokrągla miary do 6 miejsc po przecinku, żeby odpowiedź była stabilna
+func round(value float64) float64 {
+	return math.Round(value*1e6) / 1e6
+}
@@ -0,0 +1,129 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"microservice_overview/models"
+)
+
+// buildGraph tworzy graf z par "from->to" relacji typu calls
+func buildGraph(ids []string, edges [][2]string) *Graph {
+	vertices := make([]models.Vertex, len(ids))
+	for i, id := range ids {
+		vertices[i] = models.Vertex{ID: id, Name: id}
+	}
+	modelEdges := make([]models.Edge, len(edges))
+	for i, e := range edges {
+		modelEdges[i] = models.Edge{ID: e[0] + "-" + e[1], From: e[0], To: e[1], Type: "calls"}
+	}
+	return NewGraph(vertices, modelEdges, []models.EdgeType{{Name: "calls", Direction: models.DirectionForward}})
+}
+
+func metricsByID(m *models.GraphMetrics) map[string]models.VertexMetrics {
+	byID := make(map[string]models.VertexMetrics)
+	for _, v := range m.Vertices {
+		byID[v.ID] = v
+	}
+	return byID
+}
+
+func TestNewGraph_DirectionsAndLeaves(t *testing.T) {
+	parent := "group"
+	vertices := []models.Vertex{
+		{ID: "group"},
+		{ID: "a", ParentID: &parent},
+		{ID: "b", ParentID: &parent},
+	}
+	edges := []models.Edge{
+		{From: "a", To: "b", Type: "publishes"},
+		{From: "a", To: "b", Type: "publishes"}, // duplikat
+		{From: "a", To: "a"},                    // pętla
+		{From: "group", To: "a"},                // wierzchołek grupujący
+	}
+	g := NewGraph(vertices, edges, []models.EdgeType{{Name: "publishes", Direction: models.DirectionReverse}})
+
+	if g.Len() != 2 {
+		t.Fatalf("Expected only leaves in graph, got %d vertices", g.Len())
+	}
+	b, _ := g.Index("b")
+	if g.dependencyCount() != 1 || len(g.Dependencies(b)) != 1 || g.ID(g.Dependencies(b)[0]) != "a" {
+		t.Errorf("Expected single dependency b -> a for reverse edge type")
+	}
+}
+
+func TestBetweenness_Star(t *testing.T) {
+	// Wszystkie ścieżki z a, b do x, y prowadzą przez hub
+	g := buildGraph([]string{"a", "b", "hub", "x", "y"}, [][2]string{
+		{"a", "hub"}, {"b", "hub"}, {"hub", "x"}, {"hub", "y"},
+	})
+
+	centrality := Betweenness(g)
+	hub, _ := g.Index("hub")
+	// 4 pary (a,x), (a,y), (b,x), (b,y) / ((5-1)*(5-2))
+	if math.Abs(centrality[hub]-4.0/12.0) > 1e-9 {
+		t.Errorf("Expected hub betweenness %f, got %f", 4.0/12.0, centrality[hub])
+	}
+	a, _ := g.Index("a")
+	if centrality[a] != 0 {
+		t.Errorf("Expected leaf betweenness 0, got %f", centrality[a])
+	}
+}
+
+func TestPageRank_SumsToOne(t *testing.T) {
+	g := buildGraph([]string{"a", "b", "c", "d"}, [][2]string{
+		{"a", "c"}, {"b", "c"}, {"c", "d"}, {"d", "c"},
+	})
+
+	rank := PageRank(g)
+	sum := 0.0
+	for _, r := range rank {
+		sum += r
+	}
+	if math.Abs(sum-1) > 1e-6 {
+		t.Errorf("Expected PageRank sum 1, got %f", sum)
+	}
+	a, _ := g.Index("a")
+	c, _ := g.Index("c")
+	if rank[c] <= rank[a] {
+		t.Errorf("Expected heavily used vertex to rank higher: %v", rank)
+	}
+}
+
+func TestMetrics_CyclesAndChains(t *testing.T) {
+	// a -> b -> c -> b (cykl b-c) -> d, e osobno
+	g := buildGraph([]string{"a", "b", "c", "d", "e"}, [][2]string{
+		{"a", "b"}, {"b", "c"}, {"c", "b"}, {"c", "d"},
+	})
+
+	m := Metrics(g)
+	byID := metricsByID(m)
+
+	if m.Stats.Cycles != 1 || !byID["b"].InCycle || !byID["c"].InCycle || byID["a"].InCycle {
+		t.Errorf("Unexpected cycle detection: %+v", m)
+	}
+	if m.Stats.Components != 2 {
+		t.Errorf("Expected 2 components, got %d", m.Stats.Components)
+	}
+	// Łańcuch a -> {b,c} -> d
+	if m.Stats.LongestPath != 2 || len(m.Stats.LongestChain) != 3 || m.Stats.LongestChain[0] != "a" || m.Stats.LongestChain[2] != "d" {
+		t.Errorf("Unexpected longest chain: %d %v", m.Stats.LongestPath, m.Stats.LongestChain)
+	}
+	if byID["d"].Depth != 2 || byID["b"].Depth != 1 || byID["a"].Depth != 0 {
+		t.Errorf("Unexpected depths: %+v", byID)
+	}
+	if byID["c"].FanIn != 1 || byID["c"].FanOut != 2 || math.Abs(byID["c"].Instability-2.0/3.0) > 1e-6 {
+		t.Errorf("Unexpected fan metrics for c: %+v", byID["c"])
+	}
+	// 5 * 4 = 20 możliwych zależności, 4 istnieją
+	if math.Abs(m.Stats.Density-4.0/20.0) > 1e-6 {
+		t.Errorf("Expected density %f, got %f", 4.0/20.0, m.Stats.Density)
+	}
+}
+
+func TestMetrics_Empty(t *testing.T) {
+	m := Metrics(buildGraph(nil, nil))
+	if m.Stats.Vertices != 0 || len(m.Vertices) != 0 || m.Stats.LongestPath != 0 {
+		t.Errorf("Unexpected metrics for empty graph: %+v", m)
+	}
+}
@@ -0,0 +1,101 @@
+package analysis
+
+import "microservice_overview/models"
+
+// Kierunki przechodzenia relacji przy wyznaczaniu otoczenia wierzchołka
+const (
+	NeighborhoodOut  = "out"  // tylko relacje wychodzące (from -> to)
+	NeighborhoodIn   = "in"   // tylko relacje przychodzące
+	NeighborhoodBoth = "both" // obie
+)
+
+// Neighborhood zwraca podgraf w promieniu radius relacji od wierzchołka.
+// Dla wierzchołka grupującego punktem startu są wszystkie liście jego
+// poddrzewa. Wynik zawiera relacje między osiągniętymi wierzchołkami,
+// łańcuch przodków każdego z nich (aby dało się narysować hierarchię) oraz
+// naruszenia modelu warstwowego dotyczące zwróconych relacji.
+func Neighborhood(graph *models.Graph, vertexID string, radius int, direction string) *models.Graph {
+	byID := make(map[string]*models.Vertex, len(graph.Vertices))
+	children := make(map[string][]string)
+	for i := range graph.Vertices {
+		v := &graph.Vertices[i]
+		byID[v.ID] = v
+		if v.ParentID != nil && *v.ParentID != "" {
+			children[*v.ParentID] = append(children[*v.ParentID], v.ID)
+		}
+	}
+
+	adjacent := make(map[string][]string)
+	for _, edge := range graph.Edges {
+		if direction != NeighborhoodIn {
+			adjacent[edge.From] = append(adjacent[edge.From], edge.To)
+		}
+		if direction != NeighborhoodOut {
+			adjacent[edge.To] = append(adjacent[edge.To], edge.From)
+		}
+	}
+
+	// Start: sam wierzchołek i całe jego poddrzewo
+	reached := make(map[string]bool)
+	var frontier []string
+	var addSubtree func(id string)
+	addSubtree = func(id string) {
+		if reached[id] {
+			return
+		}
+		reached[id] = true
+		frontier = append(frontier, id)
+		for _, child := range children[id] {
+			addSubtree(child)
+		}
+	}
+	addSubtree(vertexID)
+
+	for hop := 0; hop < radius && len(frontier) > 0; hop++ {
+		var next []string
+		for _, id := range frontier {
+			for _, neighbour := range adjacent[id] {
+				if !reached[neighbour] {
+					reached[neighbour] = true
+					next = append(next, neighbour)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	included := make(map[string]bool, len(reached))
+	for id := range reached {
+		for current := byID[id]; current != nil && !included[current.ID]; {
+			included[current.ID] = true
+			if current.ParentID == nil {
+				break
+			}
+			current = byID[*current.ParentID]
+		}
+	}
+
+	result := &models.Graph{
+		Vertices:        []models.Vertex{},
+		Edges:           []models.Edge{},
+		LayerViolations: []models.LayerViolation{},
+	}
+	for _, v := range graph.Vertices {
+		if included[v.ID] {
+			result.Vertices = append(result.Vertices, v)
+		}
+	}
+	edgeIDs := make(map[string]bool)
+	for _, edge := range graph.Edges {
+		if reached[edge.From] && reached[edge.To] {
+			result.Edges = append(result.Edges, edge)
+			edgeIDs[edge.ID] = true
+		}
+	}
+	for _, violation := range graph.LayerViolations {
+		if edgeIDs[violation.EdgeID] {
+			result.LayerViolations = append(result.LayerViolations, violation)
+		}
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+package analysis
+
+import (
+	"sort"
+	"testing"
+
+	"microservice_overview/models"
+)
+
+func neighborhoodGraph() *models.Graph {
+	shop := "shop"
+	return &models.Graph{
+		Vertices: []models.Vertex{
+			{ID: "shop"},
+			{ID: "a", ParentID: &shop},
+			{ID: "b", ParentID: &shop},
+			{ID: "c"},
+			{ID: "d"},
+			{ID: "e"},
+		},
+		// a -> b -> c -> d, e -> a
+		Edges: []models.Edge{
+			{ID: "ab", From: "a", To: "b"},
+			{ID: "bc", From: "b", To: "c"},
+			{ID: "cd", From: "c", To: "d"},
+			{ID: "ea", From: "e", To: "a"},
+		},
+		LayerViolations: []models.LayerViolation{{EdgeID: "cd"}, {EdgeID: "bc"}},
+	}
+}
+
+func vertexIDs(g *models.Graph) []string {
+	ids := make([]string, len(g.Vertices))
+	for i, v := range g.Vertices {
+		ids[i] = v.ID
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestNeighborhood_Directions(t *testing.T) {
+	cases := []struct {
+		direction string
+		radius    int
+		vertices  []string
+		edges     int
+	}{
+		// Przodek "shop" jest zawsze dołączany
+		{NeighborhoodOut, 1, []string{"b", "c", "shop"}, 1},
+		{NeighborhoodIn, 1, []string{"a", "b", "shop"}, 1},
+		{NeighborhoodBoth, 2, []string{"a", "b", "c", "d", "e", "shop"}, 4},
+		{NeighborhoodOut, 0, []string{"b", "shop"}, 0},
+	}
+	for _, tc := range cases {
+		result := Neighborhood(neighborhoodGraph(), "b", tc.radius, tc.direction)
+		if got := vertexIDs(result); len(got) != len(tc.vertices) || len(result.Edges) != tc.edges {
+			t.Errorf("%s/%d: expected %v and %d edges, got %v and %d edges", tc.direction, tc.radius, tc.vertices, tc.edges, got, len(result.Edges))
+			continue
+		}
+		for i, id := range vertexIDs(result) {
+			if id != tc.vertices[i] {
+				t.Errorf("%s/%d: expected %v, got %v", tc.direction, tc.radius, tc.vertices, vertexIDs(result))
+				break
+			}
+		}
+	}
+}
+
+func TestNeighborhood_ContainerAndLayerViolations(t *testing.T) {
+	// Start z grupy obejmuje jej liście a i b
+	result := Neighborhood(neighborhoodGraph(), "shop", 1, NeighborhoodOut)
+
+	if got := vertexIDs(result); len(got) != 4 || got[2] != "c" {
+		t.Errorf("Expected shop, a, b, c, got %v", got)
+	}
+	if len(result.LayerViolations) != 1 || result.LayerViolations[0].EdgeID != "bc" {
+		t.Errorf("Expected only violations of returned edges, got %+v", result.LayerViolations)
+	}
+}
@@ -0,0 +1,100 @@
+package analysis
+
+import (
+	"sort"
+
+	"microservice_overview/models"
+)
+
+// Orphans wyszukuje wierzchołki, które nie biorą udziału w ruchu: liście bez
+// relacji, liście nieosiągalne z żadnego punktu wejścia oraz wierzchołki
+// rodzajów grupujących bez dzieci. Osiągalność liczona jest w kierunku
+// przepływu relacji (from -> to, dla typów dwukierunkowych w obie strony);
+// punktem wejścia jest wierzchołek z flagą entrypoint lub liść w jego
+// poddrzewie. Bez punktów wejścia lista nieosiągalnych jest pusta.
+func Orphans(vertices []models.Vertex, edges []models.Edge, edgeTypes []models.EdgeType, kinds []models.VertexKind) *models.OrphanReport {
+	sorted := append([]models.Vertex(nil), vertices...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	children := make(map[string][]string)
+	for _, v := range sorted {
+		if v.ParentID != nil && *v.ParentID != "" {
+			children[*v.ParentID] = append(children[*v.ParentID], v.ID)
+		}
+	}
+	containers := make(map[string]bool)
+	for _, kind := range kinds {
+		containers[kind.Name] = kind.Container
+	}
+	types := make(map[string]*models.EdgeType, len(edgeTypes))
+	for i := range edgeTypes {
+		types[edgeTypes[i].Name] = &edgeTypes[i]
+	}
+
+	flow := make(map[string][]string)
+	connected := make(map[string]bool)
+	for _, edge := range edges {
+		connected[edge.From] = true
+		connected[edge.To] = true
+		flow[edge.From] = append(flow[edge.From], edge.To)
+		if edgeType := types[edge.Type]; edgeType != nil && edgeType.Direction == models.DirectionBidirectional {
+			flow[edge.To] = append(flow[edge.To], edge.From)
+		}
+	}
+
+	// Punkty wejścia: oznaczone liście oraz liście w poddrzewach oznaczonych grup
+	reached := make(map[string]bool)
+	var queue []string
+	var markEntrypoint func(id string)
+	markEntrypoint = func(id string) {
+		if len(children[id]) == 0 {
+			if !reached[id] {
+				reached[id] = true
+				queue = append(queue, id)
+			}
+			return
+		}
+		for _, child := range children[id] {
+			markEntrypoint(child)
+		}
+	}
+	for _, v := range sorted {
+		if v.Entrypoint {
+			markEntrypoint(v.ID)
+		}
+	}
+
+	report := &models.OrphanReport{
+		Entrypoints:     append([]string{}, queue...),
+		Isolated:        []models.OrphanVertex{},
+		Unreachable:     []models.OrphanVertex{},
+		EmptyContainers: []models.OrphanVertex{},
+	}
+	sort.Strings(report.Entrypoints)
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range flow[id] {
+			if !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	for _, v := range sorted {
+		orphan := models.OrphanVertex{ID: v.ID, Name: v.Name, Kind: v.Kind, ParentID: v.ParentID}
+		switch {
+		case len(children[v.ID]) > 0:
+			continue
+		case containers[v.Kind]:
+			report.EmptyContainers = append(report.EmptyContainers, orphan)
+		case !connected[v.ID]:
+			report.Isolated = append(report.Isolated, orphan)
+		case len(report.Entrypoints) > 0 && !reached[v.ID]:
+			report.Unreachable = append(report.Unreachable, orphan)
+		}
+	}
+	return report
+}
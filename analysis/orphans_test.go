@@ -0,0 +1,65 @@
+package analysis
+
+import (
+	"testing"
+
+	"microservice_overview/models"
+)
+
+func orphanIDs(orphans []models.OrphanVertex) []string {
+	ids := make([]string, len(orphans))
+	for i, o := range orphans {
+		ids[i] = o.ID
+	}
+	return ids
+}
+
+func TestOrphans(t *testing.T) {
+	edgeGroup := "edge"
+	vertices := []models.Vertex{
+		{ID: "edge", Kind: "team", Entrypoint: true},
+		{ID: "gateway", Kind: models.KindService, ParentID: &edgeGroup},
+		{ID: "orders", Kind: models.KindService},
+		{ID: "consumer", Kind: models.KindService},
+		{ID: "zombie", Kind: models.KindService},
+		{ID: "legacy", Kind: models.KindService},
+		{ID: "legacy-db", Kind: "database"},
+		{ID: "empty-team", Kind: "team"},
+	}
+	edges := []models.Edge{
+		{From: "gateway", To: "orders", Type: "calls"},
+		{From: "orders", To: "consumer", Type: "publishes"},
+		{From: "legacy", To: "legacy-db", Type: "calls"},
+	}
+	edgeTypes := []models.EdgeType{
+		{Name: "calls", Direction: models.DirectionForward},
+		{Name: "publishes", Direction: models.DirectionReverse},
+	}
+	kinds := []models.VertexKind{{Name: models.KindService}, {Name: "database"}, {Name: "team", Container: true}}
+
+	report := Orphans(vertices, edges, edgeTypes, kinds)
+
+	if got := report.Entrypoints; len(got) != 1 || got[0] != "gateway" {
+		t.Errorf("Expected gateway inherited as entrypoint, got %v", got)
+	}
+	if got := orphanIDs(report.Isolated); len(got) != 1 || got[0] != "zombie" {
+		t.Errorf("Expected zombie isolated, got %v", got)
+	}
+	// consumer jest osiągalny w kierunku przepływu zdarzeń
+	if got := orphanIDs(report.Unreachable); len(got) != 2 || got[0] != "legacy" || got[1] != "legacy-db" {
+		t.Errorf("Expected legacy services unreachable, got %v", got)
+	}
+	if got := orphanIDs(report.EmptyContainers); len(got) != 1 || got[0] != "empty-team" {
+		t.Errorf("Expected empty-team as empty container, got %v", got)
+	}
+}
+
+func TestOrphans_WithoutEntrypoints(t *testing.T) {
+	vertices := []models.Vertex{{ID: "a"}, {ID: "b"}}
+	edges := []models.Edge{{From: "a", To: "b"}}
+
+	report := Orphans(vertices, edges, nil, nil)
+	if len(report.Unreachable) != 0 || len(report.Isolated) != 0 || len(report.Entrypoints) != 0 {
+		t.Errorf("Expected no unreachable vertices without entrypoints, got %+v", report)
+	}
+}
@@ -0,0 +1,231 @@
+package analysis
+
+import (
+	"sort"
+
+	"microservice_overview/models"
+)
+
+// Resilience wyszukuje punkty artykulacji i mosty w grafie zależności.
+// Łączność liczona jest bez względu na kierunek zależności - awaria
+// wierzchołka lub zerwanie zależności dzieli system na rozłączne części.
+// Za odcięte uznawane są wszystkie części poza największą.
+func Resilience(g *Graph) *models.ResilienceReport {
+	n := g.Len()
+	neighbours := g.undirected()
+
+	order := make([]int, n)
+	low := make([]int, n)
+	for i := range order {
+		order[i] = -1
+	}
+	articulation := make([]bool, n)
+	var bridges [][2]int
+	counter := 0
+
+	// DFS Tarjana (iteracyjnie, żeby duże grafy nie przepełniały stosu);
+	// parentArcs pozwala odróżnić krawędź do rodzica od równoległej krawędzi
+	// (zależność w obie strony nie jest mostem)
+	type frame struct{ v, parent, next, children, parentArcs int }
+	for root := 0; root < n; root++ {
+		if order[root] >= 0 {
+			continue
+		}
+		calls := []frame{{v: root, parent: -1}}
+		order[root], low[root] = counter, counter
+		counter++
+
+		for len(calls) > 0 {
+			top := &calls[len(calls)-1]
+			v := top.v
+			if top.next < len(neighbours[v]) {
+				w := neighbours[v][top.next]
+				top.next++
+				if w == top.parent {
+					top.parentArcs++
+					if top.parentArcs == 1 {
+						continue
+					}
+				}
+				if order[w] >= 0 {
+					if order[w] < low[v] {
+						low[v] = order[w]
+					}
+					continue
+				}
+				top.children++
+				order[w], low[w] = counter, counter
+				counter++
+				calls = append(calls, frame{v: w, parent: v})
+				continue
+			}
+
+			calls = calls[:len(calls)-1]
+			if top.parent < 0 {
+				if top.children > 1 {
+					articulation[v] = true
+				}
+				continue
+			}
+			parent := &calls[len(calls)-1]
+			u := parent.v
+			if low[v] < low[u] {
+				low[u] = low[v]
+			}
+			if parent.parent >= 0 && low[v] >= order[u] {
+				articulation[u] = true
+			}
+			if low[v] > order[u] {
+				bridges = append(bridges, [2]int{u, v})
+			}
+		}
+	}
+
+	report := &models.ResilienceReport{
+		ArticulationPoints: []models.ArticulationPoint{},
+		Bridges:            []models.Bridge{},
+	}
+
+	for v := 0; v < n; v++ {
+		if !articulation[v] {
+			continue
+		}
+		separated := g.separatedGroups(neighbours, v, -1, -1)
+		point := models.ArticulationPoint{ID: g.ids[v], Name: g.names[v], Separated: make([][]string, len(separated))}
+		for i, group := range separated {
+			point.Separated[i] = g.idsOf(group)
+			point.Unreachable += len(group)
+		}
+		report.ArticulationPoints = append(report.ArticulationPoints, point)
+	}
+
+	for _, b := range bridges {
+		u, v := b[0], b[1]
+		separated := g.separatedGroups(neighbours, -1, u, v)
+		var ids []int
+		for _, group := range separated {
+			ids = append(ids, group...)
+		}
+		sort.Ints(ids)
+
+		// Most może odpowiadać zależności u -> v lub v -> u
+		from, to := u, v
+		if _, ok := g.edges[[2]int{u, v}]; !ok {
+			from, to = v, u
+		}
+		edgeIDs := append([]string(nil), g.edges[[2]int{from, to}]...)
+		sort.Strings(edgeIDs)
+		report.Bridges = append(report.Bridges, models.Bridge{
+			From:      g.ids[from],
+			To:        g.ids[to],
+			EdgeIDs:   edgeIDs,
+			Separated: g.idsOf(ids),
+		})
+	}
+
+	sort.SliceStable(report.ArticulationPoints, func(i, j int) bool {
+		a, b := report.ArticulationPoints[i], report.ArticulationPoints[j]
+		if a.Unreachable != b.Unreachable {
+			return a.Unreachable > b.Unreachable
+		}
+		return a.ID < b.ID
+	})
+	sort.SliceStable(report.Bridges, func(i, j int) bool {
+		a, b := report.Bridges[i], report.Bridges[j]
+		if len(a.Separated) != len(b.Separated) {
+			return len(a.Separated) > len(b.Separated)
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+	return report
+}
+
+// undirected zwraca listy sąsiedztwa bez kierunku; zależność w obie strony
+// daje dwie równoległe krawędzie
+func (g *Graph) undirected() [][]int {
+	neighbours := make([][]int, g.Len())
+	for u := range g.out {
+		for _, v := range g.out[u] {
+			neighbours[u] = append(neighbours[u], v)
+			neighbours[v] = append(neighbours[v], u)
+		}
+	}
+	for i := range neighbours {
+		sort.Ints(neighbours[i])
+	}
+	return neighbours
+}
+
+// separatedGroups dzieli składową po usunięciu wierzchołka removed (lub
+// krawędzi cutU-cutV) i zwraca wszystkie części poza największą
+func (g *Graph) separatedGroups(neighbours [][]int, removed, cutU, cutV int) [][]int {
+	start := removed
+	if start < 0 {
+		start = cutU
+	}
+
+	// Składowa przed awarią
+	component := g.reachable(neighbours, start, -1, -1, -1)
+
+	visited := make(map[int]bool)
+	var groups [][]int
+	for _, v := range component {
+		if v == removed || visited[v] {
+			continue
+		}
+		group := g.reachable(neighbours, v, removed, cutU, cutV)
+		for _, w := range group {
+			visited[w] = true
+		}
+		groups = append(groups, group)
+	}
+
+	// Największa część (przy remisie - z najmniejszym ID) pozostaje "systemem"
+	main := 0
+	for i := range groups {
+		if len(groups[i]) > len(groups[main]) || (len(groups[i]) == len(groups[main]) && groups[i][0] < groups[main][0]) {
+			main = i
+		}
+	}
+	separated := make([][]int, 0, len(groups))
+	for i, group := range groups {
+		if i != main {
+			separated = append(separated, group)
+		}
+	}
+	return separated
+}
+
+// reachable zwraca posortowane wierzchołki osiągalne z start bez przechodzenia
+// przez removed i bez użycia krawędzi cutU-cutV
+func (g *Graph) reachable(neighbours [][]int, start, removed, cutU, cutV int) []int {
+	visited := map[int]bool{start: true}
+	queue := []int{start}
+	result := []int{}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		result = append(result, v)
+		for _, w := range neighbours[v] {
+			if w == removed || visited[w] || (v == cutU && w == cutV) || (v == cutV && w == cutU) {
+				continue
+			}
+			visited[w] = true
+			queue = append(queue, w)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+// idsOf zamienia indeksy na ID wierzchołków
+func (g *Graph) idsOf(indices []int) []string {
+	ids := make([]string, len(indices))
+	for i, v := range indices {
+		ids[i] = g.ids[v]
+	}
+	return ids
+}
@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestResilience_Chain(t *testing.T) {
+	// a -> b -> c -> d: b i c są punktami artykulacji, każda zależność mostem
+	g := buildGraph([]string{"a", "b", "c", "d"}, [][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}})
+	report := Resilience(g)
+
+	if len(report.ArticulationPoints) != 2 {
+		t.Fatalf("Expected 2 articulation points, got %+v", report.ArticulationPoints)
+	}
+	for _, p := range report.ArticulationPoints {
+		if p.Unreachable != 1 || len(p.Separated) != 1 {
+			t.Errorf("Expected single separated vertex for %s, got %+v", p.ID, p.Separated)
+		}
+	}
+	if b := report.ArticulationPoints[0]; b.ID != "b" || !reflect.DeepEqual(b.Separated, [][]string{{"a"}}) {
+		t.Errorf("Unexpected articulation point b: %+v", b)
+	}
+
+	if len(report.Bridges) != 3 {
+		t.Fatalf("Expected 3 bridges, got %+v", report.Bridges)
+	}
+	// Most środkowy dzieli graf na równe połowy - odcięta ta bez najmniejszego ID
+	for _, b := range report.Bridges {
+		if b.From == "b" && b.To == "c" {
+			if !reflect.DeepEqual(b.Separated, []string{"c", "d"}) || !reflect.DeepEqual(b.EdgeIDs, []string{"b-c"}) {
+				t.Errorf("Unexpected middle bridge: %+v", b)
+			}
+		}
+	}
+	if first := report.Bridges[0]; len(first.Separated) != 2 {
+		t.Errorf("Expected bridges sorted by separated count, got %+v", report.Bridges)
+	}
+}
+
+func TestResilience_Hub(t *testing.T) {
+	// Gateway łączy dwie niezależne części systemu
+	g := buildGraph([]string{"gateway", "orders", "payments", "users", "profiles"}, [][2]string{
+		{"gateway", "orders"}, {"orders", "payments"}, {"gateway", "users"}, {"users", "profiles"},
+	})
+	report := Resilience(g)
+
+	first := report.ArticulationPoints[0]
+	if first.ID != "gateway" || first.Unreachable != 2 {
+		t.Errorf("Expected gateway to be the worst single point of failure, got %+v", first)
+	}
+}
+
+func TestResilience_CycleAndMutualDependency(t *testing.T) {
+	// Cykl a -> b -> c -> a oraz wzajemna zależność c <-> d: brak pojedynczych punktów awarii
+	// poza c, którego awaria odcina d
+	g := buildGraph([]string{"a", "b", "c", "d"}, [][2]string{
+		{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "c"},
+	})
+	report := Resilience(g)
+
+	if len(report.Bridges) != 0 {
+		t.Errorf("Expected mutual dependency not to be a bridge, got %+v", report.Bridges)
+	}
+	if len(report.ArticulationPoints) != 1 || report.ArticulationPoints[0].ID != "c" {
+		t.Errorf("Expected only c as articulation point, got %+v", report.ArticulationPoints)
+	}
+}
+
+func TestResilience_Empty(t *testing.T) {
+	report := Resilience(buildGraph(nil, nil))
+	if report.ArticulationPoints == nil || report.Bridges == nil {
+		t.Errorf("Expected empty slices, not nil")
+	}
+}
+
+func TestResilience_LongCycle(t *testing.T) {
+	// Długi cykl - głęboki DFS bez punktów artykulacji i mostów
+	const n = 100000
+	ids := make([]string, n)
+	edges := make([][2]string, n)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+	for i := range edges {
+		edges[i] = [2]string{ids[i], ids[(i+1)%n]}
+	}
+	report := Resilience(buildGraph(ids, edges))
+
+	if len(report.ArticulationPoints) != 0 || len(report.Bridges) != 0 {
+		t.Errorf("Expected no single points of failure in a cycle, got %d points and %d bridges", len(report.ArticulationPoints), len(report.Bridges))
+	}
+}
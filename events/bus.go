@@ -0,0 +1,195 @@
+// Package events rozgłasza zmiany wierzchołków i relacji do subskrybentów
+// (strumienie gRPC, SSE, webhooki). Szyna działa w obrębie procesu; repliki
+// serwisu wymieniają zdarzenia przez wspólną tabelę w bazie (zob. Relay).
+package events
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"microservice_overview/models"
+)
+
+// Typy zdarzeń
+const (
+	VertexCreated = "vertex.created"
+	VertexUpdated = "vertex.updated"
+	VertexDeleted = "vertex.deleted"
+	EdgeCreated   = "edge.created"
+	EdgeUpdated   = "edge.updated"
+	EdgeDeleted   = "edge.deleted"
+)
+
+// Types wszystkie typy zdarzeń
+var Types = []string{VertexCreated, VertexUpdated, VertexDeleted, EdgeCreated, EdgeUpdated, EdgeDeleted}
+
+// IsType sprawdza czy t jest znanym typem zdarzenia
+func IsType(t string) bool {
+	for _, known := range Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrSubscriberTooSlow zwracany gdy subskrybent nie odbierał zdarzeń i jego
+// bufor się zapełnił - subskrypcja jest wtedy zamykana, a klient powinien
+// wczytać graf od nowa
+var ErrSubscriberTooSlow = errors.New("subscriber fell behind and was disconnected")
+
+// Event zmiana wierzchołka lub relacji; przy usunięciu zawiera ostatni
+// znany stan obiektu
+type Event struct {
+	ID     int64          `json:"id"` // Rosnący numer zdarzenia; z Relay wspólny dla replik
+	Type   string         `json:"type"`
+	Time   time.Time      `json:"time"`
+	Vertex *models.Vertex `json:"vertex,omitempty"`
+	Edge   *models.Edge   `json:"edge,omitempty"`
+	Remote bool           `json:"-"` // Zapis wykonała inna replika serwisu
+}
+
+// ResourceID zwraca ID zmienionego wierzchołka lub relacji
+func (e Event) ResourceID() string {
+	if e.Vertex != nil {
+		return e.Vertex.ID
+	}
+	if e.Edge != nil {
+		return e.Edge.ID
+	}
+	return ""
+}
+
+// SubscriberBuffer bufor subskrybenta mieszczący wszystkie zdarzenia
+// największej paczki zmian, publikowane naraz po zatwierdzeniu transakcji
+const SubscriberBuffer = 1024
+
+// historySize minimalna liczba ostatnich zdarzeń przechowywanych do wznawiania
+// subskrypcji
+const historySize = 1024
+
+// Bus rozsyła zdarzenia do wszystkich subskrybentów; publikowanie nigdy nie
+// blokuje zapisu
+type Bus struct {
+	stream      string // Zob. Stream
+	mu          sync.Mutex
+	lastID      int64
+	since       int64   // Numer ostatniego zdarzenia sprzed history
+	history     []Event // Ostatnie zdarzenia, od najstarszego
+	subscribers map[*Subscription]struct{}
+}
+
+// NewBus tworzy nową szynę zdarzeń
+func NewBus() *Bus {
+	return &Bus{
+		stream:      strconv.FormatInt(time.Now().UnixNano(), 36),
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Stream identyfikuje numerację zdarzeń: numery z różnych strumieni (np.
+// sprzed restartu procesu) są nieporównywalne. Szyny zasilane przez Relay
+// mają wspólny strumień numerowany przez tabelę zdarzeń
+func (b *Bus) Stream() string {
+	return b.stream
+}
+
+// Subscription subskrypcja zdarzeń; C jest zamykany po Close lub gdy
+// subskrybent nie nadąża (Err zwraca wtedy ErrSubscriberTooSlow)
+type Subscription struct {
+	C <-chan Event
+
+	bus *Bus
+	ch  chan Event
+	err error
+}
+
+// Subscribe rejestruje subskrybenta z buforem na buffer zdarzeń
+func (b *Bus) Subscribe(buffer int) *Subscription {
+	ch := make(chan Event, buffer)
+	sub := &Subscription{C: ch, bus: b, ch: ch}
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+	return sub
+}
+
+// SubscribeFrom rejestruje subskrybenta, który otrzymał już zdarzenia do
+// lastID włącznie, i zwraca zdarzenia opublikowane później. complete = false
+// oznacza, że części pominiętych zdarzeń nie ma już w historii (albo lastID
+// pochodzi spoza tej szyny) - klient musi wtedy wczytać graf od nowa
+func (b *Bus) SubscribeFrom(lastID int64, buffer int) (sub *Subscription, missed []Event, complete bool) {
+	ch := make(chan Event, buffer)
+	sub = &Subscription{C: ch, bus: b, ch: ch}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[sub] = struct{}{}
+
+	if lastID > b.lastID || lastID < b.since {
+		return sub, nil, false
+	}
+	for _, event := range b.history {
+		if event.ID > lastID {
+			missed = append(missed, event)
+		}
+	}
+	return sub, missed, true
+}
+
+// Publish nadaje zdarzeniu numer i czas, po czym rozsyła je subskrybentom.
+// Zdarzenie z numerem (z tabeli zdarzeń, zob. Relay) zachowuje go - numery
+// muszą rosnąć, ale mogą mieć luki
+func (b *Bus) Publish(event Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if event.ID == 0 {
+		event.ID = b.lastID + 1
+	}
+	if b.lastID == 0 {
+		// Zdarzeń sprzed pierwszego szyna nie zna
+		b.since = event.ID - 1
+	}
+	b.lastID = event.ID
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	b.history = append(b.history, event)
+	if len(b.history) >= 2*historySize {
+		trimmed := len(b.history) - historySize
+		b.since = b.history[trimmed-1].ID
+		b.history = append([]Event(nil), b.history[trimmed:]...)
+	}
+	for sub := range b.subscribers {
+		select {
+		case sub.ch <- event:
+		default:
+			sub.err = ErrSubscriberTooSlow
+			b.remove(sub)
+		}
+	}
+	return event
+}
+
+// Close kończy subskrypcję
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.remove(s)
+}
+
+// Err zwraca powód zamknięcia subskrypcji przez szynę
+func (s *Subscription) Err() error {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	return s.err
+}
+
+// remove wyrejestrowuje subskrybenta (wywoływane pod blokadą)
+func (b *Bus) remove(sub *Subscription) {
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
@@ -0,0 +1,290 @@
+package events
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"microservice_overview/models"
+	"microservice_overview/storage"
+)
+
+func TestBus_PublishToSubscribers(t *testing.T) {
+	bus := NewBus()
+	first := bus.Subscribe(4)
+	second := bus.Subscribe(4)
+	defer first.Close()
+
+	published := bus.Publish(Event{Type: VertexCreated, Vertex: &models.Vertex{ID: "a"}})
+	if published.ID != 1 || published.Time.IsZero() {
+		t.Errorf("expected the bus to assign ID and time, got %+v", published)
+	}
+
+	for _, sub := range []*Subscription{first, second} {
+		event := <-sub.C
+		if event.ID != 1 || event.ResourceID() != "a" {
+			t.Errorf("unexpected event %+v", event)
+		}
+	}
+
+	second.Close()
+	bus.Publish(Event{Type: VertexDeleted, Vertex: &models.Vertex{ID: "a"}})
+	if _, ok := <-second.C; ok {
+		t.Error("expected a closed subscription to receive nothing")
+	}
+	if event := <-first.C; event.ID != 2 {
+		t.Errorf("expected the second event, got %+v", event)
+	}
+}
+
+func TestBus_DropsSlowSubscriber(t *testing.T) {
+	bus := NewBus()
+	sub := bus.Subscribe(1)
+
+	bus.Publish(Event{Type: EdgeCreated, Edge: &models.Edge{ID: "e1"}})
+	bus.Publish(Event{Type: EdgeCreated, Edge: &models.Edge{ID: "e2"}})
+
+	if event := <-sub.C; event.ResourceID() != "e1" {
+		t.Errorf("expected the buffered event, got %+v", event)
+	}
+	if _, ok := <-sub.C; ok {
+		t.Error("expected the subscription to be closed after overflow")
+	}
+	if !errors.Is(sub.Err(), ErrSubscriberTooSlow) {
+		t.Errorf("expected ErrSubscriberTooSlow, got %v", sub.Err())
+	}
+	sub.Close() // ponowne zamknięcie jest bezpieczne
+}
+
+func TestBus_SubscribeFrom(t *testing.T) {
+	bus := NewBus()
+	for i := 0; i < 3; i++ {
+		bus.Publish(Event{Type: VertexCreated, Vertex: &models.Vertex{ID: "a"}})
+	}
+
+	sub, missed, complete := bus.SubscribeFrom(1, 4)
+	defer sub.Close()
+	if !complete || len(missed) != 2 || missed[0].ID != 2 {
+		t.Errorf("expected events 2 and 3 to be replayed, got %+v (complete=%v)", missed, complete)
+	}
+
+	for _, lastID := range []int64{7, -5} {
+		other, missed, complete := bus.SubscribeFrom(lastID, 4)
+		if complete || missed != nil {
+			t.Errorf("expected lastID %d to require a reload, got %+v", lastID, missed)
+		}
+		other.Close()
+	}
+
+	// Zdarzenia spoza historii nie mogą być dosłane
+	for i := 0; i < 2*historySize; i++ {
+		bus.Publish(Event{Type: VertexUpdated, Vertex: &models.Vertex{ID: "a"}})
+	}
+	sub.Close()
+	if other, _, complete := bus.SubscribeFrom(1, 4); complete {
+		t.Error("expected events older than the history to require a reload")
+	} else {
+		other.Close()
+	}
+}
+
+func TestPublishingStorage(t *testing.T) {
+	os.Setenv("DEV_MODE", "true")
+	defer os.Unsetenv("DEV_MODE")
+	db, err := storage.NewStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	bus := NewBus()
+	s := NewPublishingStorage(db, bus)
+	sub := bus.Subscribe(16)
+	defer sub.Close()
+
+	s.CreateVertex(&models.Vertex{ID: "a", Name: "A"})
+	s.CreateVertex(&models.Vertex{ID: "b", Name: "B"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "a", To: "b", Type: "calls"})
+	s.UpdateVertex(&models.Vertex{ID: "a", Name: "A2"})
+	s.DeleteEdge("e1", 0)
+
+	// Nieudany zapis nie publikuje zdarzenia
+	if err := s.CreateEdge(&models.Edge{From: "a", To: "missing"}); err == nil {
+		t.Fatal("expected an error for a missing vertex")
+	}
+
+	expected := []string{VertexCreated, VertexCreated, EdgeCreated, VertexUpdated, EdgeDeleted}
+	for _, eventType := range expected {
+		event := <-sub.C
+		if event.Type != eventType {
+			t.Fatalf("expected %s, got %s", eventType, event.Type)
+		}
+		if event.Type == VertexUpdated && (event.Vertex.Name != "A2" || event.Vertex.Slug == "") {
+			t.Errorf("expected the full updated vertex, got %+v", event.Vertex)
+		}
+		if event.Type == EdgeDeleted && event.Edge.From != "a" {
+			t.Errorf("expected the last known state of the deleted edge, got %+v", event.Edge)
+		}
+	}
+	select {
+	case event := <-sub.C:
+		t.Errorf("unexpected event %+v", event)
+	default:
+	}
+}
+
+func TestPublishingStorage_Transaction(t *testing.T) {
+	os.Setenv("DEV_MODE", "true")
+	defer os.Unsetenv("DEV_MODE")
+	db, err := storage.NewStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	bus := NewBus()
+	s := NewPublishingStorage(db, bus)
+	sub := bus.Subscribe(16)
+	defer sub.Close()
+
+	// Wycofana transakcja nie publikuje zdarzeń
+	s.Transaction(func(tx storage.Storage) error {
+		tx.CreateVertex(&models.Vertex{ID: "a", Name: "A"})
+		return errors.New("rollback")
+	})
+	select {
+	case event := <-sub.C:
+		t.Fatalf("unexpected event %+v", event)
+	default:
+	}
+
+	err = s.Transaction(func(tx storage.Storage) error {
+		if err := tx.CreateVertex(&models.Vertex{ID: "a", Name: "A"}); err != nil {
+			return err
+		}
+		if err := tx.CreateVertex(&models.Vertex{ID: "b", Name: "B"}); err != nil {
+			return err
+		}
+		// Zdarzenia trafiają na szynę dopiero po zatwierdzeniu
+		select {
+		case event := <-sub.C:
+			t.Errorf("event published before commit: %+v", event)
+		default:
+		}
+		return tx.CreateEdge(&models.Edge{ID: "e1", From: "a", To: "b", Type: "calls"})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, eventType := range []string{VertexCreated, VertexCreated, EdgeCreated} {
+		if event := <-sub.C; event.Type != eventType {
+			t.Fatalf("expected %s, got %s", eventType, event.Type)
+		}
+	}
+}
+
+func TestRelay_FansOutAcrossReplicas(t *testing.T) {
+	os.Setenv("DEV_MODE", "true")
+	defer os.Unsetenv("DEV_MODE")
+	db, err := storage.NewStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	// Dwie repliki współdzielące bazę
+	busA, busB := NewBus(), NewBus()
+	relayA, relayB := NewRelay(db, busA), NewRelay(db, busB)
+	for _, relay := range []*Relay{relayA, relayB} {
+		if err := relay.Poll(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	s := NewOutboxStorage(db, relayA)
+	subA, subB := busA.Subscribe(16), busB.Subscribe(16)
+	defer subA.Close()
+	defer subB.Close()
+
+	s.CreateVertex(&models.Vertex{ID: "a", Name: "A"})
+	s.Transaction(func(tx storage.Storage) error {
+		tx.CreateVertex(&models.Vertex{ID: "b", Name: "B"})
+		return errors.New("rollback")
+	})
+	s.CreateVertex(&models.Vertex{ID: "b", Name: "B"})
+	if err := s.CreateEdge(&models.Edge{From: "a", To: "missing"}); err == nil {
+		t.Fatal("expected an error for a missing vertex")
+	}
+	for _, relay := range []*Relay{relayA, relayB} {
+		if err := relay.Poll(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var ids []int64
+	for _, eventID := range []string{"a", "b"} {
+		a, b := <-subA.C, <-subB.C
+		if a.ResourceID() != eventID || b.ResourceID() != eventID || a.Type != VertexCreated {
+			t.Fatalf("expected vertex.created for %s on both replicas, got %+v and %+v", eventID, a, b)
+		}
+		if a.ID != b.ID || a.Vertex.Name != b.Vertex.Name {
+			t.Errorf("expected the same event on both replicas, got %+v and %+v", a, b)
+		}
+		if a.Remote || !b.Remote {
+			t.Errorf("expected only the other replica to see a remote event, got %v and %v", a.Remote, b.Remote)
+		}
+		ids = append(ids, a.ID)
+	}
+	select {
+	case event := <-subB.C:
+		t.Errorf("unexpected event %+v", event)
+	default:
+	}
+
+	// Numery zdarzeń są wspólne - klient może wznowić strumień na innej replice
+	other, missed, complete := busB.SubscribeFrom(ids[0], 4)
+	defer other.Close()
+	if !complete || len(missed) != 1 || missed[0].ID != ids[1] {
+		t.Errorf("expected event %d to be replayed, got %+v (complete=%v)", ids[1], missed, complete)
+	}
+}
+
+func TestRelay_WaitsForGap(t *testing.T) {
+	os.Setenv("DEV_MODE", "true")
+	defer os.Unsetenv("DEV_MODE")
+	db, err := storage.NewStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	bus := NewBus()
+	relay := NewRelay(db, bus)
+	relay.Poll()
+	sub := bus.Subscribe(16)
+	defer sub.Close()
+
+	// Zdarzenie ze środka jest jeszcze w niezatwierdzonej transakcji
+	record := func(id int64, vertexID string) models.EventRecord {
+		return models.EventRecord{ID: id, Origin: "other", Payload: []byte(`{"type": "vertex.created", "vertex": {"id": "` + vertexID + `"}}`)}
+	}
+	first := []models.EventRecord{record(0, "a")}
+	if err := db.AppendEvents(first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.AppendEvents([]models.EventRecord{record(first[0].ID+2, "c")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	relay.Poll()
+	if event := <-sub.C; event.ResourceID() != "a" {
+		t.Fatalf("expected the event before the gap, got %+v", event)
+	}
+	select {
+	case event := <-sub.C:
+		t.Fatalf("expected the relay to wait for the gap, got %+v", event)
+	default:
+	}
+
+	// Brakujące zdarzenie zostaje zatwierdzone
+	if err := db.AppendEvents([]models.EventRecord{record(first[0].ID+1, "b")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	relay.Poll()
+	for _, id := range []string{"b", "c"} {
+		if event := <-sub.C; event.ResourceID() != id || !event.Remote {
+			t.Fatalf("expected the remote event for %s, got %+v", id, event)
+		}
+	}
+}
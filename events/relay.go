@@ -0,0 +1,179 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"microservice_overview/models"
+	"microservice_overview/storage"
+)
+
+// Domyślne ustawienia przekaźnika
+const (
+	defaultPollInterval = 500 * time.Millisecond // Co ile sprawdzane są zapisy innych replik
+	defaultRetention    = time.Hour              // Jak długo tabela przechowuje zdarzenia
+	gapTimeout          = 5 * time.Second        // Jak długo czekać na zdarzenie z luki w numeracji
+	relayBatch          = 500                    // Zdarzenia wczytywane jednym zapytaniem
+	relayPurgeInterval  = 10 * time.Minute       // Co ile usuwane są stare zdarzenia
+	relayStream         = "db"                   // Strumień szyn zasilanych z tabeli zdarzeń (zob. Bus.Stream)
+)
+
+// Relay przekazuje na szynę zdarzenia z tabeli zdarzeń, do której trafiają
+// zapisy wszystkich replik (zob. NewOutboxStorage). Dzięki temu SSE, gRPC
+// Watch i webhooki widzą zmiany niezależnie od tego, która replika obsłużyła
+// żądanie, a numery zdarzeń (Last-Event-ID) są wspólne dla replik
+type Relay struct {
+	RelayConfig
+
+	storage storage.Storage
+	bus     *Bus
+	origin  string        // Identyfikator tej repliki
+	wakeup  chan struct{} // Sygnał zapisu w tej replice
+
+	cursor   int64     // Numer ostatniego przekazanego zdarzenia; -1 - do ustalenia
+	gapSince time.Time // Od kiedy przekazywanie czeka na zdarzenie z luki
+}
+
+// NewRelay tworzy przekaźnik z domyślnymi ustawieniami i przełącza szynę na
+// wspólną numerację zdarzeń. s nie może publikować zdarzeń - przekaźnik
+// tylko odczytuje i czyści tabelę
+func NewRelay(s storage.Storage, bus *Bus) *Relay {
+	origin := make([]byte, 8)
+	rand.Read(origin)
+	bus.stream = relayStream
+	return &Relay{
+		RelayConfig: RelayConfig{PollInterval: defaultPollInterval, Retention: defaultRetention},
+		storage:     s,
+		bus:         bus,
+		origin:      hex.EncodeToString(origin),
+		wakeup:      make(chan struct{}, 1),
+		cursor:      -1,
+	}
+}
+
+// RelayConfig ustawienia przekaźnika
+type RelayConfig struct {
+	PollInterval time.Duration // Co ile sprawdzane są zapisy innych replik; własne są przekazywane od razu
+	Retention    time.Duration // Jak długo tabela przechowuje zdarzenia
+}
+
+// LoadRelayConfig wczytuje ustawienia przekaźnika ze zmiennych
+// EVENTS_POLL_INTERVAL i EVENTS_RETENTION (czas w formacie Go, np. 500ms, 1h);
+// brakujące ustawienia są domyślne
+func LoadRelayConfig() (RelayConfig, error) {
+	config := RelayConfig{PollInterval: defaultPollInterval, Retention: defaultRetention}
+	if value := os.Getenv("EVENTS_POLL_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			return config, fmt.Errorf("EVENTS_POLL_INTERVAL must be a positive duration (e.g. 500ms), got %q", value)
+		}
+		config.PollInterval = interval
+	}
+	if value := os.Getenv("EVENTS_RETENTION"); value != "" {
+		retention, err := time.ParseDuration(value)
+		if err != nil || retention <= 0 {
+			return config, fmt.Errorf("EVENTS_RETENTION must be a positive duration (e.g. 1h), got %q", value)
+		}
+		config.Retention = retention
+	}
+	return config, nil
+}
+
+// save zapisuje zdarzenia transakcji tx tuż przed jej zatwierdzeniem
+func (r *Relay) save(tx storage.Storage, pending []Event) error {
+	records := make([]models.EventRecord, 0, len(pending))
+	for _, event := range pending {
+		if event.Time.IsZero() {
+			event.Time = time.Now().UTC()
+		}
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		records = append(records, models.EventRecord{Origin: r.origin, Payload: payload, CreatedAt: event.Time})
+	}
+	return tx.AppendEvents(records)
+}
+
+// wake przyspiesza przekazanie zdarzeń zapisanych przez tę replikę
+func (r *Relay) wake() {
+	select {
+	case r.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// Run przekazuje zdarzenia na szynę i usuwa z tabeli zdarzenia starsze niż
+// Retention, do zamknięcia ctx. Przekazywane są zdarzenia zapisane po starcie
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.PollInterval)
+	defer ticker.Stop()
+	purge := time.NewTicker(relayPurgeInterval)
+	defer purge.Stop()
+	for {
+		if err := r.Poll(); err != nil {
+			log.Printf("events: failed to read the event table: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wakeup:
+		case <-purge.C:
+			if _, err := r.storage.DeleteEventsBefore(time.Now().Add(-r.Retention)); err != nil {
+				log.Printf("events: failed to purge the event table: %v", err)
+			}
+		}
+	}
+}
+
+// Poll przekazuje na szynę zdarzenia zapisane od poprzedniego wywołania.
+// Numery nadaje baza przy zapisie, więc zdarzenie z wyższym numerem może
+// zostać zatwierdzone wcześniej - luka w numeracji wstrzymuje przekazywanie,
+// aż brakujące zdarzenie się pojawi albo minie gapTimeout (transakcja
+// wycofana przy zatwierdzaniu)
+func (r *Relay) Poll() error {
+	if r.cursor < 0 {
+		last, err := r.storage.GetLastEventID()
+		if err != nil {
+			return err
+		}
+		r.cursor = last
+	}
+	for {
+		records, err := r.storage.GetEventsAfter(r.cursor, relayBatch)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			if record.ID != r.cursor+1 {
+				if r.gapSince.IsZero() {
+					r.gapSince = time.Now()
+				}
+				if time.Since(r.gapSince) < gapTimeout {
+					return nil
+				}
+			}
+			r.gapSince = time.Time{}
+			r.cursor = record.ID
+
+			var event Event
+			if err := json.Unmarshal(record.Payload, &event); err != nil {
+				log.Printf("events: skipping malformed event %d: %v", record.ID, err)
+				continue
+			}
+			event.ID = record.ID
+			event.Remote = record.Origin != r.origin
+			r.bus.Publish(event)
+		}
+		if len(records) < relayBatch {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package events
+
+import (
+	"microservice_overview/models"
+	"microservice_overview/storage"
+)
+
+// publishingStorage nakładka na storage.Storage publikująca zdarzenia po
+// każdym udanym zapisie wierzchołka lub relacji - niezależnie od tego, czy
+// zmiana przyszła przez REST, GraphQL czy gRPC
+type publishingStorage struct {
+	storage.Storage
+	bus     *Bus     // Szyna, na którą trafiają zdarzenia (NewPublishingStorage)
+	relay   *Relay   // Tabela zdarzeń wspólna dla replik (NewOutboxStorage)
+	pending *[]Event // Zdarzenia trwającej transakcji; nil poza transakcją
+}
+
+// NewPublishingStorage opakowuje storage tak, by zmiany trafiały na szynę
+// w obrębie procesu
+func NewPublishingStorage(s storage.Storage, bus *Bus) storage.Storage {
+	return &publishingStorage{Storage: s, bus: bus}
+}
+
+// NewOutboxStorage opakowuje storage tak, by zmiany trafiały do tabeli
+// zdarzeń w tej samej transakcji co zapis; na szyny replik przekazuje je Relay
+func NewOutboxStorage(s storage.Storage, relay *Relay) storage.Storage {
+	return &publishingStorage{Storage: s, relay: relay}
+}
+
+// Transaction zbiera zdarzenia zapisów wykonanych w transakcji i publikuje je
+// dopiero po jej zatwierdzeniu; wycofana transakcja nie publikuje niczego
+func (s *publishingStorage) Transaction(fn func(tx storage.Storage) error) error {
+	if s.pending != nil {
+		// Transakcja zagnieżdżona - zdarzenia dołączają do zewnętrznej, o ile
+		// jej zmiany nie zostaną wycofane
+		n := len(*s.pending)
+		err := s.Storage.Transaction(func(tx storage.Storage) error {
+			return fn(&publishingStorage{Storage: tx, bus: s.bus, relay: s.relay, pending: s.pending})
+		})
+		if err != nil {
+			*s.pending = (*s.pending)[:n]
+		}
+		return err
+	}
+
+	var pending []Event
+	err := s.Storage.Transaction(func(tx storage.Storage) error {
+		if err := fn(&publishingStorage{Storage: tx, bus: s.bus, relay: s.relay, pending: &pending}); err != nil {
+			return err
+		}
+		if s.relay != nil {
+			return s.relay.save(tx, pending)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.committed(pending)
+	return nil
+}
+
+// write wykonuje zapis fn i publikuje jego zdarzenia. Z tabelą zdarzeń zapis
+// poza transakcją dostaje własną, by zdarzenia nie rozminęły się ze zmianą
+func (s *publishingStorage) write(fn func(s *publishingStorage) error) error {
+	if s.pending != nil {
+		return fn(s)
+	}
+	if s.relay != nil {
+		return s.Transaction(func(tx storage.Storage) error {
+			return fn(tx.(*publishingStorage))
+		})
+	}
+	var pending []Event
+	if err := fn(&publishingStorage{Storage: s.Storage, bus: s.bus, pending: &pending}); err != nil {
+		return err
+	}
+	s.committed(pending)
+	return nil
+}
+
+// publish dołącza zdarzenie do bieżącego zapisu (zob. write)
+func (s *publishingStorage) publish(event Event) {
+	*s.pending = append(*s.pending, event)
+}
+
+// committed przekazuje dalej zdarzenia zatwierdzonego zapisu
+func (s *publishingStorage) committed(pending []Event) {
+	if s.relay != nil {
+		if len(pending) > 0 {
+			s.relay.wake()
+		}
+		return
+	}
+	for _, event := range pending {
+		s.bus.Publish(event)
+	}
+}
+
+func (s *publishingStorage) CreateVertex(vertex *models.Vertex) error {
+	return s.write(func(s *publishingStorage) error {
+		if err := s.Storage.CreateVertex(vertex); err != nil {
+			return err
+		}
+		v := *vertex
+		s.publish(Event{Type: VertexCreated, Vertex: &v})
+		return nil
+	})
+}
+
+func (s *publishingStorage) UpdateVertex(vertex *models.Vertex) error {
+	return s.write(func(s *publishingStorage) error {
+		if err := s.Storage.UpdateVertex(vertex); err != nil {
+			return err
+		}
+		s.publishVertex(VertexUpdated, vertex.ID)
+		return nil
+	})
+}
+
+func (s *publishingStorage) MoveVertex(id string, parentID *string, version int64) (*models.Vertex, error) {
+	var vertex *models.Vertex
+	err := s.write(func(s *publishingStorage) error {
+		var err error
+		if vertex, err = s.Storage.MoveVertex(id, parentID, version); err != nil {
+			return err
+		}
+		v := *vertex
+		s.publish(Event{Type: VertexUpdated, Vertex: &v})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vertex, nil
+}
+
+func (s *publishingStorage) DeleteVertex(id string, version int64) error {
+	return s.write(func(s *publishingStorage) error {
+		last, _ := s.Storage.GetVertexByID(id)
+		if err := s.Storage.DeleteVertex(id, version); err != nil {
+			return err
+		}
+		if last == nil {
+			last = &models.Vertex{ID: id}
+		}
+		s.publish(Event{Type: VertexDeleted, Vertex: last})
+		return nil
+	})
+}
+
+func (s *publishingStorage) CreateEdge(edge *models.Edge) error {
+	return s.write(func(s *publishingStorage) error {
+		if err := s.Storage.CreateEdge(edge); err != nil {
+			return err
+		}
+		e := *edge
+		s.publish(Event{Type: EdgeCreated, Edge: &e})
+		return nil
+	})
+}
+
+func (s *publishingStorage) UpdateEdge(edge *models.Edge) error {
+	return s.write(func(s *publishingStorage) error {
+		if err := s.Storage.UpdateEdge(edge); err != nil {
+			return err
+		}
+		if current, err := s.Storage.GetEdgeByID(edge.ID); err == nil {
+			s.publish(Event{Type: EdgeUpdated, Edge: current})
+		}
+		return nil
+	})
+}
+
+func (s *publishingStorage) DeleteEdge(id string, version int64) error {
+	return s.write(func(s *publishingStorage) error {
+		last, _ := s.Storage.GetEdgeByID(id)
+		if err := s.Storage.DeleteEdge(id, version); err != nil {
+			return err
+		}
+		if last == nil {
+			last = &models.Edge{ID: id}
+		}
+		s.publish(Event{Type: EdgeDeleted, Edge: last})
+		return nil
+	})
+}
+
+// RepairGraph publikuje zmiany wykonane podczas naprawy; usunięte obiekty
+// są już niedostępne, więc zdarzenie zawiera tylko ich ID
+func (s *publishingStorage) RepairGraph(strategy string, dryRun bool) (*models.RepairReport, error) {
+	var report *models.RepairReport
+	err := s.write(func(s *publishingStorage) error {
+		var err error
+		if report, err = s.Storage.RepairGraph(strategy, dryRun); err != nil || dryRun {
+			return err
+		}
+		for _, action := range report.Actions {
+			switch action.Action {
+			case models.RepairActionDetachVertex:
+				s.publishVertex(VertexUpdated, action.VertexID)
+			case models.RepairActionDeleteVertex:
+				s.publish(Event{Type: VertexDeleted, Vertex: &models.Vertex{ID: action.VertexID}})
+			case models.RepairActionDeleteEdge:
+				s.publish(Event{Type: EdgeDeleted, Edge: &models.Edge{ID: action.EdgeID}})
+			}
+		}
+		return nil
+	})
+	return report, err
+}
+
+// publishVertex publikuje aktualny stan wierzchołka (z datami i slugiem
+// uzupełnionymi przez bazę)
+func (s *publishingStorage) publishVertex(eventType, id string) {
+	if current, err := s.Storage.GetVertexByID(id); err == nil {
+		s.publish(Event{Type: eventType, Vertex: current})
+	}
+}
@@ -0,0 +1,212 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"microservice_overview/models"
+	"microservice_overview/problem"
+	"microservice_overview/storage"
+)
+
+// countingStorage liczy wczytania grafu
+type countingStorage struct {
+	storage.Storage
+	graphLoads int
+}
+
+func (s *countingStorage) GetGraph() (*models.Graph, error) {
+	s.graphLoads++
+	return s.Storage.GetGraph()
+}
+
+func newTestServer(t *testing.T) (*Server, *countingStorage) {
+	t.Helper()
+	os.Setenv("DEV_MODE", "true")
+	defer os.Unsetenv("DEV_MODE")
+	s, err := storage.NewStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	counting := &countingStorage{Storage: s}
+	server, err := NewServer(counting)
+	if err != nil {
+		t.Fatalf("failed to build schema: %v", err)
+	}
+	return server, counting
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestNestedResolvers_LoadGraphOnce(t *testing.T) {
+	server, s := newTestServer(t)
+	s.CreateVertex(&models.Vertex{ID: "shop", Name: "Shop", Kind: "team"})
+	s.CreateVertex(&models.Vertex{ID: "gateway", Name: "Gateway", ParentID: stringPtr("shop")})
+	s.CreateVertex(&models.Vertex{ID: "orders", Name: "Orders", ParentID: stringPtr("shop")})
+	s.CreateVertex(&models.Vertex{ID: "orders-db", Name: "Orders DB", Kind: "database", Metadata: models.JSONMap{"engine": "postgres"}})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "gateway", To: "orders", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "e2", From: "orders", To: "orders-db", Type: "calls"})
+
+	result := server.Do(context.Background(), `{
+		vertex(id: "shop") {
+			children {
+				id
+				parent { id }
+				outgoing { target { name } edge_type { synchronous } }
+				dependencies { id }
+			}
+		}
+	}`, nil, "")
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if s.graphLoads != 1 {
+		t.Errorf("expected the graph to be loaded once per request, got %d loads", s.graphLoads)
+	}
+
+	children := result.Data.(map[string]interface{})["vertex"].(map[string]interface{})["children"].([]interface{})
+	if len(children) != 2 {
+		t.Fatalf("expected 2 children, got %v", children)
+	}
+	gateway := children[0].(map[string]interface{})
+	if gateway["id"] != "gateway" {
+		t.Fatalf("expected gateway first, got %v", gateway)
+	}
+	deps := gateway["dependencies"].([]interface{})
+	if len(deps) != 2 {
+		t.Errorf("expected gateway to depend transitively on orders and orders-db, got %v", deps)
+	}
+}
+
+func TestDependencies_DepthAndDirection(t *testing.T) {
+	server, s := newTestServer(t)
+	s.CreateVertex(&models.Vertex{ID: "a", Name: "A"})
+	s.CreateVertex(&models.Vertex{ID: "b", Name: "B"})
+	s.CreateVertex(&models.Vertex{ID: "c", Name: "C"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "a", To: "b", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "e2", From: "b", To: "c", Type: "calls"})
+
+	result := server.Do(context.Background(), `{
+		a: vertex(id: "a") { dependencies(depth: 1) { id } }
+		c: vertex(id: "c") { dependents { id } }
+	}`, nil, "")
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	data := result.Data.(map[string]interface{})
+	if deps := data["a"].(map[string]interface{})["dependencies"].([]interface{}); len(deps) != 1 {
+		t.Errorf("expected depth 1 to stop at b, got %v", deps)
+	}
+	if deps := data["c"].(map[string]interface{})["dependents"].([]interface{}); len(deps) != 2 {
+		t.Errorf("expected a and b to depend on c, got %v", deps)
+	}
+}
+
+func TestMutations_ReuseStorageValidation(t *testing.T) {
+	server, s := newTestServer(t)
+	s.CreateVertex(&models.Vertex{ID: "a", Name: "A"})
+
+	result := server.Do(context.Background(), `mutation {
+		create_vertex(input: {name: "Orders", kind: "no-such-kind"}) { id }
+	}`, nil, "")
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != codeBadUserInput {
+		t.Errorf("expected BAD_USER_INPUT for unknown kind, got %v", result.Errors)
+	}
+
+	result = server.Do(context.Background(), `mutation {
+		update_vertex(id: "a", input: {name: "A2"}, version: 7) { id }
+	}`, nil, "")
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != codeVersionConflict {
+		t.Errorf("expected VERSION_CONFLICT for a stale version, got %v", result.Errors)
+	}
+
+	result = server.Do(context.Background(), `mutation {
+		move_vertex(id: "a", parent_id: "a") { id }
+	}`, nil, "")
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != problem.CodeHierarchyCycle {
+		t.Errorf("expected HIERARCHY_CYCLE for a vertex moved under itself, got %v", result.Errors)
+	}
+
+	// Mutacja unieważnia wczytany graf - kolejne pole widzi nowy wierzchołek
+	result = server.Do(context.Background(), `mutation {
+		create_vertex(input: {id: "b", name: "B"}) { id children { id } }
+		create_edge(input: {from: "b", to: "a", type: "calls"}) { source { name } target { incoming { from } } }
+	}`, nil, "")
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	edge := result.Data.(map[string]interface{})["create_edge"].(map[string]interface{})
+	incoming := edge["target"].(map[string]interface{})["incoming"].([]interface{})
+	if edge["source"].(map[string]interface{})["name"] != "B" || len(incoming) != 1 {
+		t.Errorf("expected the new vertex to be visible after the mutation, got %v", edge)
+	}
+}
+
+// failingStorage zwraca błąd bazy przy zapisie relacji
+type failingStorage struct {
+	storage.Storage
+}
+
+func (s *failingStorage) CreateEdge(edge *models.Edge) error {
+	return errors.New("pq: connection refused")
+}
+
+func TestMutations_HideInternalErrors(t *testing.T) {
+	server, s := newTestServer(t)
+	s.CreateVertex(&models.Vertex{ID: "a", Name: "A"})
+	s.CreateVertex(&models.Vertex{ID: "b", Name: "B"})
+
+	result := server.Do(context.Background(), `mutation {
+		create_edge(input: {from: "a", to: "b", type: "no_such_type"}) { id }
+	}`, nil, "")
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != codeBadUserInput {
+		t.Errorf("expected BAD_USER_INPUT for an unknown edge type, got %v", result.Errors)
+	}
+
+	failing, err := NewServer(&failingStorage{Storage: s})
+	if err != nil {
+		t.Fatalf("failed to build schema: %v", err)
+	}
+	result = failing.Do(context.Background(), `mutation {
+		create_edge(input: {from: "a", to: "b"}) { id }
+	}`, nil, "")
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != codeInternal || result.Errors[0].Message != problem.GenericDetail {
+		t.Errorf("expected a generic INTERNAL_ERROR, got %v", result.Errors)
+	}
+}
+
+func TestCheck_Limits(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	nested := func(field string, levels int) string {
+		return "{ vertex(id: \"a\") { " + strings.Repeat(field+" { ", levels) + "id" + strings.Repeat(" }", levels) + " } }"
+	}
+	cases := []struct {
+		name  string
+		query string
+		ok    bool
+	}{
+		{"shallow", `{ vertex(id: "a") { name children { name outgoing { target { name } } } } }`, true},
+		{"deepest allowed", nested("parent", 8), true},
+		{"too deep", nested("parent", 9), false},
+		{"nested lists", nested("children", 4), false},
+		{"too deep through fragments", `{ vertex(id: "a") { ...up } } fragment up on Vertex { ` + strings.Repeat("parent { ", 9) + "id" + strings.Repeat(" }", 9) + ` }`, false},
+		{"self-referencing fragment", `{ vertex(id: "a") { ...loop } } fragment loop on Vertex { parent { ...loop } }`, true},
+		{"introspection", `{ __schema { types { name fields { name type { name ofType { name ofType { name ofType { name ofType { name } } } } } } } } }`, true},
+		{"syntax error", `{ vertex(id: "a") {`, true},
+	}
+	for _, tc := range cases {
+		err := server.Check(tc.query)
+		if tc.ok && err != nil {
+			t.Errorf("%s: expected the query to pass, got %v", tc.name, err)
+		}
+		if !tc.ok && !errors.Is(err, ErrQueryTooComplex) {
+			t.Errorf("%s: expected ErrQueryTooComplex, got %v", tc.name, err)
+		}
+	}
+}
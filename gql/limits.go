@@ -0,0 +1,119 @@
+package gql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// Domyślne limity zapytań (zob. Server.Check)
+const (
+	DefaultMaxDepth      = 10    // Najgłębsze zagnieżdżenie pól
+	DefaultMaxComplexity = 10000 // Szacowana liczba rozwiązywanych pól
+	listFactor           = 10    // Szacowana liczba elementów listy
+)
+
+// ErrQueryTooComplex zapytanie przekracza limit głębokości lub złożoności
+var ErrQueryTooComplex = errors.New("query too complex")
+
+// Check odrzuca, przed wykonaniem, zapytania głębsze niż MaxDepth lub
+// złożoniejsze niż MaxComplexity. Złożoność to liczba pól, w której pola
+// zwracające listy (children, dependencies...) mnożą koszt zagnieżdżonych pól
+// przez listFactor. Pola introspekcji (__schema, __typename) są pomijane.
+// Błędy składni zgłasza dopiero Do, w polu errors odpowiedzi
+func (srv *Server) Check(query string) error {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil
+	}
+	c := &costCounter{schema: srv.schema, fragments: map[string]*ast.FragmentDefinition{}}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			c.fragments[fragment.Name.Value] = fragment
+		}
+	}
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		root := srv.schema.QueryType()
+		if op.Operation == ast.OperationTypeMutation {
+			root = srv.schema.MutationType()
+		}
+		depth, complexity := c.selectionSet(root, op.SelectionSet, map[string]bool{})
+		if depth > srv.MaxDepth {
+			return fmt.Errorf("%w: depth %d exceeds the limit of %d", ErrQueryTooComplex, depth, srv.MaxDepth)
+		}
+		if complexity > srv.MaxComplexity {
+			return fmt.Errorf("%w: complexity %d exceeds the limit of %d - request fewer nested lists", ErrQueryTooComplex, complexity, srv.MaxComplexity)
+		}
+	}
+	return nil
+}
+
+// costCounter liczy głębokość i złożoność zbiorów pól
+type costCounter struct {
+	schema    graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+}
+
+// selectionSet zwraca głębokość i złożoność pól set typu parent; visiting
+// chroni przed fragmentami zawierającymi same siebie
+func (c *costCounter) selectionSet(parent graphql.Type, set *ast.SelectionSet, visiting map[string]bool) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+	for _, selection := range set.Selections {
+		var d, n int
+		switch s := selection.(type) {
+		case *ast.Field:
+			d, n = c.field(parent, s, visiting)
+		case *ast.InlineFragment:
+			d, n = c.selectionSet(parent, s.SelectionSet, visiting)
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			fragment := c.fragments[name]
+			if fragment == nil || visiting[name] {
+				continue
+			}
+			visiting[name] = true
+			d, n = c.selectionSet(parent, fragment.SelectionSet, visiting)
+			delete(visiting, name)
+		}
+		if d > depth {
+			depth = d
+		}
+		complexity += n
+	}
+	return depth, complexity
+}
+
+func (c *costCounter) field(parent graphql.Type, f *ast.Field, visiting map[string]bool) (depth, complexity int) {
+	if strings.HasPrefix(f.Name.Value, "__") {
+		return 0, 0
+	}
+	var fieldType graphql.Type
+	if object, ok := parent.(*graphql.Object); ok {
+		if def := object.Fields()[f.Name.Value]; def != nil {
+			fieldType = def.Type
+		}
+	}
+	factor := 1
+	if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+		fieldType = nonNull.OfType
+	}
+	if list, ok := fieldType.(*graphql.List); ok {
+		factor = listFactor
+		fieldType = list.OfType
+		if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+			fieldType = nonNull.OfType
+		}
+	}
+	depth, complexity = c.selectionSet(fieldType, f.SelectionSet, visiting)
+	return depth + 1, 1 + factor*complexity
+}
@@ -0,0 +1,151 @@
+package gql
+
+import (
+	"context"
+	"sync"
+
+	"microservice_overview/models"
+	"microservice_overview/storage"
+)
+
+// snapshot zindeksowany stan grafu, z którego korzystają zagnieżdżone resolvery
+type snapshot struct {
+	vertices  []models.Vertex
+	byID      map[string]*models.Vertex
+	bySlug    map[string]*models.Vertex
+	children  map[string][]*models.Vertex
+	edges     []models.Edge
+	edgeByID  map[string]*models.Edge
+	out, in   map[string][]*models.Edge
+	edgeTypes []models.EdgeType
+	typeByID  map[string]*models.EdgeType
+}
+
+// loader wczytuje graf raz na żądanie, zamiast odpytywać bazę w każdym
+// zagnieżdżonym resolverze; mutacje unieważniają wczytany stan
+type loader struct {
+	storage storage.Storage
+	mu      sync.Mutex
+	snap    *snapshot
+}
+
+type loaderKey struct{}
+
+func withLoader(ctx context.Context, s storage.Storage) context.Context {
+	return context.WithValue(ctx, loaderKey{}, &loader{storage: s})
+}
+
+func loaderFrom(ctx context.Context) *loader {
+	return ctx.Value(loaderKey{}).(*loader)
+}
+
+// get zwraca aktualny stan grafu, wczytując go przy pierwszym użyciu
+func (l *loader) get() (*snapshot, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.snap != nil {
+		return l.snap, nil
+	}
+
+	graph, err := l.storage.GetGraph()
+	if err != nil {
+		return nil, internalError(err)
+	}
+	edgeTypes, err := l.storage.GetAllEdgeTypes()
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	snap := &snapshot{
+		vertices:  graph.Vertices,
+		byID:      make(map[string]*models.Vertex, len(graph.Vertices)),
+		bySlug:    make(map[string]*models.Vertex, len(graph.Vertices)),
+		children:  make(map[string][]*models.Vertex),
+		edges:     graph.Edges,
+		edgeByID:  make(map[string]*models.Edge, len(graph.Edges)),
+		out:       make(map[string][]*models.Edge),
+		in:        make(map[string][]*models.Edge),
+		edgeTypes: edgeTypes,
+		typeByID:  make(map[string]*models.EdgeType, len(edgeTypes)),
+	}
+	for i := range snap.vertices {
+		v := &snap.vertices[i]
+		snap.byID[v.ID] = v
+		if v.Slug != "" {
+			snap.bySlug[v.Slug] = v
+		}
+		if v.ParentID != nil && *v.ParentID != "" {
+			snap.children[*v.ParentID] = append(snap.children[*v.ParentID], v)
+		}
+	}
+	for i := range snap.edges {
+		e := &snap.edges[i]
+		snap.edgeByID[e.ID] = e
+		snap.out[e.From] = append(snap.out[e.From], e)
+		snap.in[e.To] = append(snap.in[e.To], e)
+	}
+	for i := range snap.edgeTypes {
+		snap.typeByID[snap.edgeTypes[i].Name] = &snap.edgeTypes[i]
+	}
+	l.snap = snap
+	return snap, nil
+}
+
+// invalidate wymusza ponowne wczytanie grafu po mutacji
+func (l *loader) invalidate() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.snap = nil
+}
+
+// vertex zwraca wierzchołek po ID lub slugu
+func (s *snapshot) vertex(ref string) *models.Vertex {
+	if v, ok := s.byID[ref]; ok {
+		return v
+	}
+	return s.bySlug[ref]
+}
+
+// dependencies zwraca wierzchołki osiągalne z id po zależnościach (zgodnie
+// z kierunkiem typów relacji) do głębokości depth (0 = bez limitu);
+// reverse = true odwraca kierunek (wierzchołki zależne)
+func (s *snapshot) dependencies(id string, depth int, types []string, reverse bool) []*models.Vertex {
+	allowed := make(map[string]bool, len(types))
+	for _, t := range types {
+		allowed[t] = true
+	}
+	next := make(map[string][]string)
+	for _, e := range s.edges {
+		if len(allowed) > 0 && !allowed[e.Type] {
+			continue
+		}
+		for _, dep := range s.typeByID[e.Type].Dependencies(e) {
+			if reverse {
+				next[dep.Dependency] = append(next[dep.Dependency], dep.Dependent)
+			} else {
+				next[dep.Dependent] = append(next[dep.Dependent], dep.Dependency)
+			}
+		}
+	}
+
+	visited := map[string]bool{id: true}
+	frontier := []string{id}
+	var result []*models.Vertex
+	for level := 0; len(frontier) > 0 && (depth == 0 || level < depth); level++ {
+		var following []string
+		for _, current := range frontier {
+			for _, target := range next[current] {
+				if visited[target] {
+					continue
+				}
+				visited[target] = true
+				following = append(following, target)
+				if v := s.byID[target]; v != nil {
+					result = append(result, v)
+				}
+			}
+		}
+		frontier = following
+	}
+	return result
+}
@@ -0,0 +1,212 @@
+package gql
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"microservice_overview/models"
+	"microservice_overview/problem"
+	"microservice_overview/storage"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Kody błędów zwracane w extensions.code - te same co w REST API (tabela
+// problem.For), z wyjątkiem INVALID_REQUEST zgłaszanego jako BAD_USER_INPUT
+const (
+	codeBadUserInput    = "BAD_USER_INPUT"
+	codeNotFound        = problem.CodeNotFound
+	codeVersionConflict = problem.CodeVersionConflict
+	codeInternal        = problem.CodeInternal
+)
+
+// extendedError błąd GraphQL z kodem i dodatkowymi danymi w extensions
+type extendedError struct {
+	err        error
+	extensions map[string]interface{}
+}
+
+func (e *extendedError) Error() string { return e.err.Error() }
+
+func (e *extendedError) Unwrap() error { return e.err }
+
+// Extensions implementuje gqlerrors.ExtendedError
+func (e *extendedError) Extensions() map[string]interface{} { return e.extensions }
+
+func withCode(err error, code string) error {
+	return &extendedError{err: err, extensions: map[string]interface{}{"code": code}}
+}
+
+// internalError zapisuje przyczynę w logu, a klientowi zwraca ogólny opis -
+// jak odpowiedzi 5xx REST API
+func internalError(err error) error {
+	log.Printf("internal error (%s): %v", codeInternal, err)
+	return withCode(errors.New(problem.GenericDetail), codeInternal)
+}
+
+// writeError nadaje błędom zapisu z warstwy storage kody GraphQL wyprowadzone
+// z kodów REST API; pozostałe błędy to błędy serwera (zob. internalError)
+func writeError(err error) error {
+	_, code, ok := problem.For(err)
+	if !ok {
+		return internalError(err)
+	}
+	if code == problem.CodeInvalidRequest {
+		code = codeBadUserInput
+	}
+	extensions := map[string]interface{}{"code": code}
+
+	var ruleErr *storage.RuleViolationError
+	var layerErr *storage.LayerViolationError
+	switch {
+	case errors.As(err, &ruleErr):
+		extensions["violations"] = ruleErr.Violations
+	case errors.As(err, &layerErr):
+		extensions["violations"] = layerErr.Violations
+	}
+	return &extendedError{err: err, extensions: extensions}
+}
+
+// mutations resolvery mutacji; zapis zawsze przechodzi przez storage.Storage
+type mutations struct {
+	storage storage.Storage
+}
+
+func (m *mutations) createVertex(p graphql.ResolveParams) (interface{}, error) {
+	var vertex models.Vertex
+	if err := decodeInput(p.Args["input"], &vertex); err != nil {
+		return nil, withCode(err, codeBadUserInput)
+	}
+	if vertex.Name == "" {
+		return nil, withCode(errors.New("name is required"), codeBadUserInput)
+	}
+	if err := m.storage.CreateVertex(&vertex); err != nil {
+		return nil, writeError(err)
+	}
+	loaderFrom(p.Context).invalidate()
+	return &vertex, nil
+}
+
+func (m *mutations) updateVertex(p graphql.ResolveParams) (interface{}, error) {
+	current, err := m.findVertex(p.Args["id"].(string))
+	if err != nil {
+		return nil, err
+	}
+	var vertex models.Vertex
+	if err := decodeInput(p.Args["input"], &vertex); err != nil {
+		return nil, withCode(err, codeBadUserInput)
+	}
+	if vertex.Name == "" {
+		return nil, withCode(errors.New("name is required"), codeBadUserInput)
+	}
+	vertex.ID = current.ID
+	vertex.Version = expectedVersion(p.Args, current.Version)
+	if err := m.storage.UpdateVertex(&vertex); err != nil {
+		return nil, writeError(err)
+	}
+	loaderFrom(p.Context).invalidate()
+	return &vertex, nil
+}
+
+func (m *mutations) moveVertex(p graphql.ResolveParams) (interface{}, error) {
+	current, err := m.findVertex(p.Args["id"].(string))
+	if err != nil {
+		return nil, err
+	}
+	var parentID *string
+	if ref, ok := p.Args["parent_id"].(string); ok && ref != "" {
+		parentID = &ref
+	}
+	vertex, err := m.storage.MoveVertex(current.ID, parentID, expectedVersion(p.Args, current.Version))
+	if err != nil {
+		return nil, writeError(err)
+	}
+	loaderFrom(p.Context).invalidate()
+	return vertex, nil
+}
+
+func (m *mutations) deleteVertex(p graphql.ResolveParams) (interface{}, error) {
+	current, err := m.findVertex(p.Args["id"].(string))
+	if err != nil {
+		return nil, err
+	}
+	if err := m.storage.DeleteVertex(current.ID, expectedVersion(p.Args, current.Version)); err != nil {
+		return nil, writeError(err)
+	}
+	loaderFrom(p.Context).invalidate()
+	return true, nil
+}
+
+func (m *mutations) createEdge(p graphql.ResolveParams) (interface{}, error) {
+	var edge models.Edge
+	if err := decodeInput(p.Args["input"], &edge); err != nil {
+		return nil, withCode(err, codeBadUserInput)
+	}
+	if err := m.storage.CreateEdge(&edge); err != nil {
+		return nil, writeError(err)
+	}
+	loaderFrom(p.Context).invalidate()
+	return &edge, nil
+}
+
+func (m *mutations) updateEdge(p graphql.ResolveParams) (interface{}, error) {
+	current, err := m.findEdge(p.Args["id"].(string))
+	if err != nil {
+		return nil, err
+	}
+	var edge models.Edge
+	if err := decodeInput(p.Args["input"], &edge); err != nil {
+		return nil, withCode(err, codeBadUserInput)
+	}
+	edge.ID = current.ID
+	edge.Version = expectedVersion(p.Args, current.Version)
+	if err := m.storage.UpdateEdge(&edge); err != nil {
+		return nil, writeError(err)
+	}
+	loaderFrom(p.Context).invalidate()
+	return &edge, nil
+}
+
+func (m *mutations) deleteEdge(p graphql.ResolveParams) (interface{}, error) {
+	current, err := m.findEdge(p.Args["id"].(string))
+	if err != nil {
+		return nil, err
+	}
+	if err := m.storage.DeleteEdge(current.ID, expectedVersion(p.Args, current.Version)); err != nil {
+		return nil, writeError(err)
+	}
+	loaderFrom(p.Context).invalidate()
+	return true, nil
+}
+
+func (m *mutations) findVertex(ref string) (*models.Vertex, error) {
+	vertex, err := m.storage.GetVertexByIDOrSlug(ref)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, withCode(fmt.Errorf("vertex %s not found", ref), codeNotFound)
+	}
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return vertex, nil
+}
+
+func (m *mutations) findEdge(id string) (*models.Edge, error) {
+	edge, err := m.storage.GetEdgeByID(id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, withCode(fmt.Errorf("edge %s not found", id), codeNotFound)
+	}
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return edge, nil
+}
+
+// expectedVersion zwraca wersję z argumentu version, a gdy go nie podano -
+// bieżącą wersję rekordu (zapis bez kontroli współbieżności)
+func expectedVersion(args map[string]interface{}, current int64) int64 {
+	if version, ok := args["version"].(int); ok {
+		return int64(version)
+	}
+	return current
+}
@@ -0,0 +1,480 @@
+// Package gql udostępnia model wierzchołków i relacji przez GraphQL.
+// Zapytania korzystają z grafu wczytanego raz na żądanie, a mutacje
+// przechodzą przez storage.Storage - z tą samą walidacją co REST API.
+package gql
+
+import (
+	"context"
+	"encoding/json"
+
+	"microservice_overview/models"
+	"microservice_overview/storage"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Server schemat GraphQL powiązany z magazynem danych
+type Server struct {
+	MaxDepth      int // Limit głębokości zapytań (zob. Check)
+	MaxComplexity int // Limit złożoności zapytań (zob. Check)
+
+	schema  graphql.Schema
+	storage storage.Storage
+}
+
+// NewServer buduje schemat GraphQL
+func NewServer(s storage.Storage) (*Server, error) {
+	schema, err := newSchema(s)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{MaxDepth: DefaultMaxDepth, MaxComplexity: DefaultMaxComplexity, schema: schema, storage: s}, nil
+}
+
+// Do wykonuje zapytanie lub mutację; limity zapytań sprawdza wcześniej Check
+func (srv *Server) Do(ctx context.Context, query string, variables map[string]interface{}, operationName string) *graphql.Result {
+	return graphql.Do(graphql.Params{
+		Schema:         srv.schema,
+		RequestString:  query,
+		VariableValues: variables,
+		OperationName:  operationName,
+		Context:        withLoader(ctx, srv.storage),
+	})
+}
+
+// jsonScalar dowolna wartość JSON (metadane wierzchołka)
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:         "JSON",
+	Description:  "Dowolna wartość JSON",
+	Serialize:    func(value interface{}) interface{} { return value },
+	ParseValue:   func(value interface{}) interface{} { return value },
+	ParseLiteral: parseLiteral,
+})
+
+func parseLiteral(value ast.Value) interface{} {
+	switch v := value.(type) {
+	case *ast.ObjectValue:
+		result := make(map[string]interface{}, len(v.Fields))
+		for _, field := range v.Fields {
+			result[field.Name.Value] = parseLiteral(field.Value)
+		}
+		return result
+	case *ast.ListValue:
+		result := make([]interface{}, len(v.Values))
+		for i, item := range v.Values {
+			result[i] = parseLiteral(item)
+		}
+		return result
+	case *ast.IntValue:
+		return graphql.Float.ParseLiteral(v)
+	case *ast.FloatValue:
+		return graphql.Float.ParseLiteral(v)
+	case *ast.BooleanValue:
+		return v.Value
+	case *ast.StringValue:
+		return v.Value
+	case *ast.EnumValue:
+		return v.Value
+	}
+	return nil
+}
+
+func newSchema(s storage.Storage) (graphql.Schema, error) {
+	edgeTypeType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "EdgeType",
+		Description: "Typ relacji z katalogu",
+		Fields: graphql.Fields{
+			"name":                 &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"description":          &graphql.Field{Type: graphql.String},
+			"direction":            &graphql.Field{Type: graphql.String},
+			"synchronous":          &graphql.Field{Type: graphql.Boolean},
+			"color":                &graphql.Field{Type: graphql.String},
+			"style":                &graphql.Field{Type: graphql.String},
+			"allowed_source_kinds": &graphql.Field{Type: graphql.NewList(graphql.String)},
+			"allowed_target_kinds": &graphql.Field{Type: graphql.NewList(graphql.String)},
+		},
+	})
+
+	var vertexType, edgeType *graphql.Object
+
+	vertexType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Vertex",
+		Description: "Wierzchołek grafu (serwis, baza danych, kolejka, zespół...)",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			dependencyArgs := graphql.FieldConfigArgument{
+				"depth": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0, Description: "Maksymalna głębokość (0 = bez limitu)"},
+				"types": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Tylko relacje podanych typów"},
+			}
+			edgeArgs := graphql.FieldConfigArgument{
+				"type": &graphql.ArgumentConfig{Type: graphql.String, Description: "Tylko relacje podanego typu"},
+			}
+			return graphql.Fields{
+				"id":               &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":             &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"slug":             &graphql.Field{Type: graphql.String},
+				"description":      &graphql.Field{Type: graphql.String},
+				"kind":             &graphql.Field{Type: graphql.String},
+				"metadata":         &graphql.Field{Type: jsonScalar},
+				"layer":            &graphql.Field{Type: graphql.String},
+				"parent_id":        &graphql.Field{Type: graphql.ID, Resolve: resolveParentID},
+				"entrypoint":       &graphql.Field{Type: graphql.Boolean},
+				"availability_slo": &graphql.Field{Type: graphql.Float},
+				"processing_ms":    &graphql.Field{Type: graphql.Float},
+				"version":          &graphql.Field{Type: graphql.Int},
+				"created_at":       &graphql.Field{Type: graphql.DateTime},
+				"updated_at":       &graphql.Field{Type: graphql.DateTime},
+				"parent":           &graphql.Field{Type: vertexType, Resolve: resolveParent},
+				"children":         &graphql.Field{Type: nonNullList(vertexType), Resolve: resolveChildren},
+				"outgoing":         &graphql.Field{Type: nonNullList(edgeType), Args: edgeArgs, Resolve: resolveEdges(false)},
+				"incoming":         &graphql.Field{Type: nonNullList(edgeType), Args: edgeArgs, Resolve: resolveEdges(true)},
+				"dependencies": &graphql.Field{
+					Type:        nonNullList(vertexType),
+					Description: "Wierzchołki, od których ten zależy (przechodnio, wg kierunku typów relacji)",
+					Args:        dependencyArgs,
+					Resolve:     resolveDependencies(false),
+				},
+				"dependents": &graphql.Field{
+					Type:        nonNullList(vertexType),
+					Description: "Wierzchołki zależne od tego (przechodnio)",
+					Args:        dependencyArgs,
+					Resolve:     resolveDependencies(true),
+				},
+			}
+		}),
+	})
+
+	edgeType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Edge",
+		Description: "Relacja między wierzchołkami",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"from":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"to":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"type":       &graphql.Field{Type: graphql.String},
+				"latency_ms": &graphql.Field{Type: graphql.Float},
+				"fallback":   &graphql.Field{Type: graphql.Boolean},
+				"version":    &graphql.Field{Type: graphql.Int},
+				"created_at": &graphql.Field{Type: graphql.DateTime},
+				"updated_at": &graphql.Field{Type: graphql.DateTime},
+				"source":     &graphql.Field{Type: vertexType, Resolve: resolveEndpoint(false)},
+				"target":     &graphql.Field{Type: vertexType, Resolve: resolveEndpoint(true)},
+				"edge_type":  &graphql.Field{Type: edgeTypeType, Resolve: resolveEdgeType},
+			}
+		}),
+	})
+
+	vertexInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "VertexInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":               &graphql.InputObjectFieldConfig{Type: graphql.ID, Description: "Tylko przy tworzeniu; puste = UUIDv7"},
+			"name":             &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"slug":             &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"description":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"kind":             &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"metadata":         &graphql.InputObjectFieldConfig{Type: jsonScalar},
+			"layer":            &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"parent_id":        &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"entrypoint":       &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			"availability_slo": &graphql.InputObjectFieldConfig{Type: graphql.Float},
+			"processing_ms":    &graphql.InputObjectFieldConfig{Type: graphql.Float},
+		},
+	})
+	edgeInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "EdgeInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":         &graphql.InputObjectFieldConfig{Type: graphql.ID, Description: "Tylko przy tworzeniu; puste = UUIDv7"},
+			"from":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"to":         &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"type":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"latency_ms": &graphql.InputObjectFieldConfig{Type: graphql.Float},
+			"fallback":   &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"vertex": &graphql.Field{
+				Type:    vertexType,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID), Description: "ID lub slug"}},
+				Resolve: resolveVertex,
+			},
+			"vertices": &graphql.Field{
+				Type: nonNullList(vertexType),
+				Args: graphql.FieldConfigArgument{
+					"kind":  &graphql.ArgumentConfig{Type: graphql.String},
+					"roots": &graphql.ArgumentConfig{Type: graphql.Boolean, Description: "Tylko wierzchołki najwyższego poziomu"},
+				},
+				Resolve: resolveVertices,
+			},
+			"edge": &graphql.Field{
+				Type:    edgeType,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: resolveEdge,
+			},
+			"edges": &graphql.Field{
+				Type:    nonNullList(edgeType),
+				Args:    graphql.FieldConfigArgument{"type": &graphql.ArgumentConfig{Type: graphql.String}},
+				Resolve: resolveAllEdges,
+			},
+			"edge_types": &graphql.Field{
+				Type: nonNullList(edgeTypeType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					snap, err := loaderFrom(p.Context).get()
+					if err != nil {
+						return nil, err
+					}
+					return snap.edgeTypes, nil
+				},
+			},
+		},
+	})
+
+	m := &mutations{storage: s}
+	versionArg := &graphql.ArgumentConfig{Type: graphql.Int, Description: "Oczekiwana wersja (optymistyczna kontrola współbieżności)"}
+	mutationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"create_vertex": &graphql.Field{
+				Type:    vertexType,
+				Args:    graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(vertexInput)}},
+				Resolve: m.createVertex,
+			},
+			"update_vertex": &graphql.Field{
+				Type: vertexType,
+				Args: graphql.FieldConfigArgument{
+					"id":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(vertexInput)},
+					"version": versionArg,
+				},
+				Resolve: m.updateVertex,
+			},
+			"move_vertex": &graphql.Field{
+				Type: vertexType,
+				Args: graphql.FieldConfigArgument{
+					"id":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"parent_id": &graphql.ArgumentConfig{Type: graphql.ID, Description: "null = najwyższy poziom"},
+					"version":   versionArg,
+				},
+				Resolve: m.moveVertex,
+			},
+			"delete_vertex": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: graphql.FieldConfigArgument{
+					"id":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"version": versionArg,
+				},
+				Resolve: m.deleteVertex,
+			},
+			"create_edge": &graphql.Field{
+				Type:    edgeType,
+				Args:    graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(edgeInput)}},
+				Resolve: m.createEdge,
+			},
+			"update_edge": &graphql.Field{
+				Type: edgeType,
+				Args: graphql.FieldConfigArgument{
+					"id":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(edgeInput)},
+					"version": versionArg,
+				},
+				Resolve: m.updateEdge,
+			},
+			"delete_edge": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: graphql.FieldConfigArgument{
+					"id":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"version": versionArg,
+				},
+				Resolve: m.deleteEdge,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType, Mutation: mutationType})
+}
+
+func nonNullList(t graphql.Type) graphql.Type {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+}
+
+// Resolvery zapytań
+
+func resolveVertex(p graphql.ResolveParams) (interface{}, error) {
+	snap, err := loaderFrom(p.Context).get()
+	if err != nil {
+		return nil, err
+	}
+	if v := snap.vertex(p.Args["id"].(string)); v != nil {
+		return v, nil
+	}
+	return nil, nil
+}
+
+func resolveVertices(p graphql.ResolveParams) (interface{}, error) {
+	snap, err := loaderFrom(p.Context).get()
+	if err != nil {
+		return nil, err
+	}
+	kind, _ := p.Args["kind"].(string)
+	roots, _ := p.Args["roots"].(bool)
+	result := []*models.Vertex{}
+	for i := range snap.vertices {
+		v := &snap.vertices[i]
+		if kind != "" && v.Kind != kind {
+			continue
+		}
+		if roots && v.ParentID != nil && *v.ParentID != "" {
+			continue
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+func resolveEdge(p graphql.ResolveParams) (interface{}, error) {
+	snap, err := loaderFrom(p.Context).get()
+	if err != nil {
+		return nil, err
+	}
+	if e := snap.edgeByID[p.Args["id"].(string)]; e != nil {
+		return e, nil
+	}
+	return nil, nil
+}
+
+func resolveAllEdges(p graphql.ResolveParams) (interface{}, error) {
+	snap, err := loaderFrom(p.Context).get()
+	if err != nil {
+		return nil, err
+	}
+	edgeTypeName, _ := p.Args["type"].(string)
+	result := []*models.Edge{}
+	for i := range snap.edges {
+		if edgeTypeName == "" || snap.edges[i].Type == edgeTypeName {
+			result = append(result, &snap.edges[i])
+		}
+	}
+	return result, nil
+}
+
+// Resolvery zagnieżdżone
+
+func resolveParentID(p graphql.ResolveParams) (interface{}, error) {
+	v := p.Source.(*models.Vertex)
+	if v.ParentID == nil || *v.ParentID == "" {
+		return nil, nil
+	}
+	return *v.ParentID, nil
+}
+
+func resolveParent(p graphql.ResolveParams) (interface{}, error) {
+	v := p.Source.(*models.Vertex)
+	if v.ParentID == nil || *v.ParentID == "" {
+		return nil, nil
+	}
+	snap, err := loaderFrom(p.Context).get()
+	if err != nil {
+		return nil, err
+	}
+	if parent := snap.byID[*v.ParentID]; parent != nil {
+		return parent, nil
+	}
+	return nil, nil
+}
+
+func resolveChildren(p graphql.ResolveParams) (interface{}, error) {
+	snap, err := loaderFrom(p.Context).get()
+	if err != nil {
+		return nil, err
+	}
+	children := snap.children[p.Source.(*models.Vertex).ID]
+	if children == nil {
+		children = []*models.Vertex{}
+	}
+	return children, nil
+}
+
+func resolveEdges(incoming bool) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		snap, err := loaderFrom(p.Context).get()
+		if err != nil {
+			return nil, err
+		}
+		id := p.Source.(*models.Vertex).ID
+		edges := snap.out[id]
+		if incoming {
+			edges = snap.in[id]
+		}
+		edgeTypeName, _ := p.Args["type"].(string)
+		result := []*models.Edge{}
+		for _, e := range edges {
+			if edgeTypeName == "" || e.Type == edgeTypeName {
+				result = append(result, e)
+			}
+		}
+		return result, nil
+	}
+}
+
+func resolveDependencies(reverse bool) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		snap, err := loaderFrom(p.Context).get()
+		if err != nil {
+			return nil, err
+		}
+		depth, _ := p.Args["depth"].(int)
+		var types []string
+		if list, ok := p.Args["types"].([]interface{}); ok {
+			for _, t := range list {
+				types = append(types, t.(string))
+			}
+		}
+		result := snap.dependencies(p.Source.(*models.Vertex).ID, depth, types, reverse)
+		if result == nil {
+			result = []*models.Vertex{}
+		}
+		return result, nil
+	}
+}
+
+func resolveEndpoint(target bool) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		snap, err := loaderFrom(p.Context).get()
+		if err != nil {
+			return nil, err
+		}
+		e := p.Source.(*models.Edge)
+		id := e.From
+		if target {
+			id = e.To
+		}
+		if v := snap.byID[id]; v != nil {
+			return v, nil
+		}
+		return nil, nil
+	}
+}
+
+func resolveEdgeType(p graphql.ResolveParams) (interface{}, error) {
+	snap, err := loaderFrom(p.Context).get()
+	if err != nil {
+		return nil, err
+	}
+	if t := snap.typeByID[p.Source.(*models.Edge).Type]; t != nil {
+		return t, nil
+	}
+	return nil, nil
+}
+
+// decodeInput przepisuje argument wejściowy na model przez JSON (nazwy pól
+// wejścia odpowiadają tagom json modeli)
+func decodeInput(input interface{}, target interface{}) error {
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, target)
+}
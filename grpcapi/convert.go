@@ -0,0 +1,123 @@
+package grpcapi
+
+import (
+	"microservice_overview/events"
+	"microservice_overview/models"
+	"microservice_overview/proto/overviewpb"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func vertexToProto(v *models.Vertex) *overviewpb.Vertex {
+	pb := &overviewpb.Vertex{
+		Id:              v.ID,
+		Name:            v.Name,
+		Slug:            v.Slug,
+		Description:     v.Description,
+		Kind:            v.Kind,
+		Layer:           v.Layer,
+		Entrypoint:      v.Entrypoint,
+		AvailabilitySlo: v.AvailabilitySLO,
+		ProcessingMs:    v.ProcessingMs,
+		Version:         v.Version,
+		CreatedAt:       timestamppb.New(v.CreatedAt),
+		UpdatedAt:       timestamppb.New(v.UpdatedAt),
+	}
+	if v.ParentID != nil && *v.ParentID != "" {
+		parentID := *v.ParentID
+		pb.ParentId = &parentID
+	}
+	if len(v.Metadata) > 0 {
+		// Metadane pochodzą z JSON, więc zawsze dają się zapisać jako Struct
+		pb.Metadata, _ = structpb.NewStruct(v.Metadata)
+	}
+	return pb
+}
+
+func vertexFromProto(pb *overviewpb.Vertex) *models.Vertex {
+	if pb == nil {
+		return &models.Vertex{}
+	}
+	v := &models.Vertex{
+		ID:              pb.GetId(),
+		Name:            pb.GetName(),
+		Slug:            pb.GetSlug(),
+		Description:     pb.GetDescription(),
+		Kind:            pb.GetKind(),
+		Layer:           pb.GetLayer(),
+		ParentID:        pb.ParentId,
+		Entrypoint:      pb.GetEntrypoint(),
+		AvailabilitySLO: pb.GetAvailabilitySlo(),
+		ProcessingMs:    pb.GetProcessingMs(),
+	}
+	if pb.Metadata != nil {
+		v.Metadata = models.JSONMap(pb.Metadata.AsMap())
+	}
+	return v
+}
+
+func edgeToProto(e *models.Edge) *overviewpb.Edge {
+	return &overviewpb.Edge{
+		Id:        e.ID,
+		From:      e.From,
+		To:        e.To,
+		Type:      e.Type,
+		LatencyMs: e.LatencyMs,
+		Fallback:  e.Fallback,
+		Version:   e.Version,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+		UpdatedAt: timestamppb.New(e.UpdatedAt),
+	}
+}
+
+func edgeFromProto(pb *overviewpb.Edge) *models.Edge {
+	if pb == nil {
+		return &models.Edge{}
+	}
+	return &models.Edge{
+		ID:        pb.GetId(),
+		From:      pb.GetFrom(),
+		To:        pb.GetTo(),
+		Type:      pb.GetType(),
+		LatencyMs: pb.GetLatencyMs(),
+		Fallback:  pb.GetFallback(),
+	}
+}
+
+func graphToProto(g *models.Graph) *overviewpb.Graph {
+	pb := &overviewpb.Graph{
+		Vertices:        make([]*overviewpb.Vertex, len(g.Vertices)),
+		Edges:           make([]*overviewpb.Edge, len(g.Edges)),
+		LayerViolations: make([]*overviewpb.LayerViolation, len(g.LayerViolations)),
+	}
+	for i := range g.Vertices {
+		pb.Vertices[i] = vertexToProto(&g.Vertices[i])
+	}
+	for i := range g.Edges {
+		pb.Edges[i] = edgeToProto(&g.Edges[i])
+	}
+	for i, v := range g.LayerViolations {
+		pb.LayerViolations[i] = &overviewpb.LayerViolation{
+			Type:       v.Type,
+			EdgeId:     v.EdgeID,
+			Dependent:  v.Dependent,
+			Dependency: v.Dependency,
+			FromLayer:  v.FromLayer,
+			ToLayer:    v.ToLayer,
+			Message:    v.Message,
+		}
+	}
+	return pb
+}
+
+func eventToProto(e events.Event) *overviewpb.Event {
+	pb := &overviewpb.Event{Id: e.ID, Type: e.Type, Time: timestamppb.New(e.Time)}
+	switch {
+	case e.Vertex != nil:
+		pb.Object = &overviewpb.Event_Vertex{Vertex: vertexToProto(e.Vertex)}
+	case e.Edge != nil:
+		pb.Object = &overviewpb.Event_Edge{Edge: edgeToProto(e.Edge)}
+	}
+	return pb
+}
@@ -0,0 +1,66 @@
+package grpcapi
+
+import (
+	"log"
+	"net/http"
+
+	"microservice_overview/problem"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain domena kodów błędów w szczegółach ErrorInfo
+const errorDomain = "microservice-overview"
+
+// statusCodes kody gRPC odpowiadające statusom HTTP z tabeli problem.For
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.FailedPrecondition,
+	http.StatusPreconditionFailed:  codes.Aborted,
+	http.StatusUnprocessableEntity: codes.FailedPrecondition,
+}
+
+// codeOverrides kody błędów, dla których gRPC ma dokładniejszy odpowiednik niż status HTTP
+var codeOverrides = map[string]codes.Code{
+	problem.CodeDuplicateID:       codes.AlreadyExists,
+	problem.CodeDuplicateEdge:     codes.AlreadyExists,
+	problem.CodeParentNotFound:    codes.InvalidArgument,
+	problem.CodeEndpointNotFound:  codes.InvalidArgument,
+	problem.CodeQueryTooExpensive: codes.ResourceExhausted,
+}
+
+// writeError mapuje błędy storage (odczytów i zapisów) na kody gRPC
+// wyprowadzone z tabeli błędów REST API (problem.For). Kod REST trafia do
+// szczegółów jako ErrorInfo.Reason, więc klient odróżni np. HIERARCHY_CYCLE od
+// RULE_VIOLATION; nieznany błąd (np. bazy) to INTERNAL bez szczegółów
+func writeError(err error) error {
+	httpStatus, code, ok := problem.For(err)
+	if !ok {
+		return internalError(err)
+	}
+	grpcCode, ok := codeOverrides[code]
+	if !ok {
+		if grpcCode, ok = statusCodes[httpStatus]; !ok {
+			grpcCode = codes.Unknown
+		}
+	}
+	return withReason(grpcCode, code, err)
+}
+
+// internalError zgłasza nieoczekiwany błąd bez szczegółów; przyczyna trafia do logu
+func internalError(err error) error {
+	log.Printf("grpc: internal error: %v", err)
+	return status.Error(codes.Internal, problem.GenericDetail)
+}
+
+// withReason tworzy status gRPC z kodem błędu REST w szczegółach ErrorInfo
+func withReason(grpcCode codes.Code, code string, err error) error {
+	st, detailErr := status.New(grpcCode, err.Error()).WithDetails(&errdetails.ErrorInfo{Reason: code, Domain: errorDomain})
+	if detailErr != nil {
+		return status.Error(grpcCode, err.Error())
+	}
+	return st.Err()
+}
@@ -0,0 +1,240 @@
+// Package grpcapi implementuje usługę gRPC GraphService (proto/overview.proto)
+// na tym samym storage.Storage co REST API.
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+
+	"microservice_overview/events"
+	"microservice_overview/proto/overviewpb"
+	"microservice_overview/storage"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// watchBuffer liczba zdarzeń buforowanych dla jednego strumienia Watch
+const watchBuffer = events.SubscriberBuffer
+
+// Server implementacja overviewpb.GraphServiceServer
+type Server struct {
+	overviewpb.UnimplementedGraphServiceServer
+	storage storage.Storage
+	bus     *events.Bus
+}
+
+// NewServer tworzy nowy Server
+func NewServer(s storage.Storage, bus *events.Bus) *Server {
+	return &Server{storage: s, bus: bus}
+}
+
+// NewGRPCServer tworzy serwer gRPC z zarejestrowaną usługą GraphService
+// i refleksją (dla narzędzi takich jak grpcurl)
+func NewGRPCServer(s storage.Storage, bus *events.Bus) *grpc.Server {
+	server := grpc.NewServer()
+	overviewpb.RegisterGraphServiceServer(server, NewServer(s, bus))
+	reflection.Register(server)
+	return server
+}
+
+// ListVertices zwraca wszystkie wierzchołki
+func (s *Server) ListVertices(ctx context.Context, req *overviewpb.ListVerticesRequest) (*overviewpb.ListVerticesResponse, error) {
+	vertices, err := s.storage.GetAllVertices()
+	if err != nil {
+		return nil, internalError(err)
+	}
+	resp := &overviewpb.ListVerticesResponse{Vertices: make([]*overviewpb.Vertex, len(vertices))}
+	for i := range vertices {
+		resp.Vertices[i] = vertexToProto(&vertices[i])
+	}
+	return resp, nil
+}
+
+// GetVertex zwraca wierzchołek po ID lub slugu
+func (s *Server) GetVertex(ctx context.Context, req *overviewpb.GetVertexRequest) (*overviewpb.Vertex, error) {
+	vertex, err := s.storage.GetVertexByIDOrSlug(req.GetId())
+	if err != nil {
+		return nil, writeError(err)
+	}
+	return vertexToProto(vertex), nil
+}
+
+// CreateVertex tworzy nowy wierzchołek
+func (s *Server) CreateVertex(ctx context.Context, req *overviewpb.CreateVertexRequest) (*overviewpb.Vertex, error) {
+	vertex := vertexFromProto(req.GetVertex())
+	if vertex.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if err := s.storage.CreateVertex(vertex); err != nil {
+		return nil, writeError(err)
+	}
+	return vertexToProto(vertex), nil
+}
+
+// UpdateVertex zastępuje pola wierzchołka
+func (s *Server) UpdateVertex(ctx context.Context, req *overviewpb.UpdateVertexRequest) (*overviewpb.Vertex, error) {
+	current, err := s.storage.GetVertexByIDOrSlug(req.GetId())
+	if err != nil {
+		return nil, writeError(err)
+	}
+	vertex := vertexFromProto(req.GetVertex())
+	if vertex.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	vertex.ID = current.ID
+	vertex.Version = expectedVersion(req.GetVersion(), current.Version)
+	if err := s.storage.UpdateVertex(vertex); err != nil {
+		return nil, writeError(err)
+	}
+	updated, err := s.storage.GetVertexByID(vertex.ID)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return vertexToProto(updated), nil
+}
+
+// MoveVertex przenosi wierzchołek (z poddrzewem) pod nowego rodzica
+func (s *Server) MoveVertex(ctx context.Context, req *overviewpb.MoveVertexRequest) (*overviewpb.Vertex, error) {
+	current, err := s.storage.GetVertexByIDOrSlug(req.GetId())
+	if err != nil {
+		return nil, writeError(err)
+	}
+	vertex, err := s.storage.MoveVertex(current.ID, req.ParentId, expectedVersion(req.GetVersion(), current.Version))
+	if err != nil {
+		return nil, writeError(err)
+	}
+	return vertexToProto(vertex), nil
+}
+
+// DeleteVertex usuwa wierzchołek
+func (s *Server) DeleteVertex(ctx context.Context, req *overviewpb.DeleteVertexRequest) (*overviewpb.DeleteVertexResponse, error) {
+	current, err := s.storage.GetVertexByIDOrSlug(req.GetId())
+	if err != nil {
+		return nil, writeError(err)
+	}
+	if err := s.storage.DeleteVertex(current.ID, expectedVersion(req.GetVersion(), current.Version)); err != nil {
+		return nil, writeError(err)
+	}
+	return &overviewpb.DeleteVertexResponse{}, nil
+}
+
+// ListEdges zwraca wszystkie relacje
+func (s *Server) ListEdges(ctx context.Context, req *overviewpb.ListEdgesRequest) (*overviewpb.ListEdgesResponse, error) {
+	edges, err := s.storage.GetAllEdges()
+	if err != nil {
+		return nil, internalError(err)
+	}
+	resp := &overviewpb.ListEdgesResponse{Edges: make([]*overviewpb.Edge, len(edges))}
+	for i := range edges {
+		resp.Edges[i] = edgeToProto(&edges[i])
+	}
+	return resp, nil
+}
+
+// GetEdge zwraca relację po ID
+func (s *Server) GetEdge(ctx context.Context, req *overviewpb.GetEdgeRequest) (*overviewpb.Edge, error) {
+	edge, err := s.storage.GetEdgeByID(req.GetId())
+	if err != nil {
+		return nil, writeError(err)
+	}
+	return edgeToProto(edge), nil
+}
+
+// CreateEdge tworzy nową relację
+func (s *Server) CreateEdge(ctx context.Context, req *overviewpb.CreateEdgeRequest) (*overviewpb.Edge, error) {
+	edge := edgeFromProto(req.GetEdge())
+	if edge.From == "" {
+		return nil, status.Error(codes.InvalidArgument, "from is required")
+	}
+	if edge.To == "" {
+		return nil, status.Error(codes.InvalidArgument, "to is required")
+	}
+	if err := s.storage.CreateEdge(edge); err != nil {
+		return nil, writeError(err)
+	}
+	return edgeToProto(edge), nil
+}
+
+// UpdateEdge zastępuje pola relacji
+func (s *Server) UpdateEdge(ctx context.Context, req *overviewpb.UpdateEdgeRequest) (*overviewpb.Edge, error) {
+	current, err := s.storage.GetEdgeByID(req.GetId())
+	if err != nil {
+		return nil, writeError(err)
+	}
+	edge := edgeFromProto(req.GetEdge())
+	edge.ID = current.ID
+	edge.Version = expectedVersion(req.GetVersion(), current.Version)
+	if err := s.storage.UpdateEdge(edge); err != nil {
+		return nil, writeError(err)
+	}
+	updated, err := s.storage.GetEdgeByID(edge.ID)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return edgeToProto(updated), nil
+}
+
+// DeleteEdge usuwa relację
+func (s *Server) DeleteEdge(ctx context.Context, req *overviewpb.DeleteEdgeRequest) (*overviewpb.DeleteEdgeResponse, error) {
+	current, err := s.storage.GetEdgeByID(req.GetId())
+	if err != nil {
+		return nil, writeError(err)
+	}
+	if err := s.storage.DeleteEdge(current.ID, expectedVersion(req.GetVersion(), current.Version)); err != nil {
+		return nil, writeError(err)
+	}
+	return &overviewpb.DeleteEdgeResponse{}, nil
+}
+
+// GetGraph zwraca pełny graf z naruszeniami modelu warstwowego
+func (s *Server) GetGraph(ctx context.Context, req *overviewpb.GetGraphRequest) (*overviewpb.Graph, error) {
+	graph, err := s.storage.GetGraph()
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return graphToProto(graph), nil
+}
+
+// Watch strumieniuje zmiany wierzchołków i relacji do zamknięcia przez
+// klienta; klient, który nie nadąża, dostaje RESOURCE_EXHAUSTED
+func (s *Server) Watch(req *overviewpb.WatchRequest, stream overviewpb.GraphService_WatchServer) error {
+	filter := make(map[string]bool, len(req.GetTypes()))
+	for _, t := range req.GetTypes() {
+		if !events.IsType(t) {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("unknown event type %q", t))
+		}
+		filter[t] = true
+	}
+
+	sub := s.bus.Subscribe(watchBuffer)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-sub.C:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, sub.Err().Error())
+			}
+			if len(filter) > 0 && !filter[event.Type] {
+				continue
+			}
+			if err := stream.Send(eventToProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// expectedVersion zwraca wersję z żądania, a gdy jej nie podano (0) -
+// bieżącą wersję rekordu (zapis bez kontroli współbieżności)
+func expectedVersion(requested, current int64) int64 {
+	if requested != 0 {
+		return requested
+	}
+	return current
+}
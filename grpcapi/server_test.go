@@ -0,0 +1,293 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"microservice_overview/events"
+	"microservice_overview/models"
+	"microservice_overview/problem"
+	"microservice_overview/proto/overviewpb"
+	"microservice_overview/storage"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newTestClient uruchamia serwer gRPC w pamięci i zwraca klienta
+func newTestClient(t *testing.T) overviewpb.GraphServiceClient {
+	t.Helper()
+	os.Setenv("DEV_MODE", "true")
+	defer os.Unsetenv("DEV_MODE")
+	db, err := storage.NewStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	bus := events.NewBus()
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewGRPCServer(events.NewPublishingStorage(db, bus), bus)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return overviewpb.NewGraphServiceClient(conn)
+}
+
+func TestVertexAndEdgeCRUD(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	metadata, _ := structpb.NewStruct(map[string]interface{}{"engine": "postgres"})
+	db, err := client.CreateVertex(ctx, &overviewpb.CreateVertexRequest{Vertex: &overviewpb.Vertex{Name: "Orders DB", Kind: "database", Metadata: metadata}})
+	if err != nil {
+		t.Fatalf("CreateVertex: %v", err)
+	}
+	if db.Id == "" || db.Slug != "orders-db" || db.Version != 1 || db.Metadata.AsMap()["engine"] != "postgres" {
+		t.Errorf("unexpected vertex %+v", db)
+	}
+	orders, _ := client.CreateVertex(ctx, &overviewpb.CreateVertexRequest{Vertex: &overviewpb.Vertex{Name: "Orders"}})
+
+	edge, err := client.CreateEdge(ctx, &overviewpb.CreateEdgeRequest{Edge: &overviewpb.Edge{From: orders.Id, To: db.Id, Type: "calls", LatencyMs: 3}})
+	if err != nil {
+		t.Fatalf("CreateEdge: %v", err)
+	}
+
+	updated, err := client.UpdateVertex(ctx, &overviewpb.UpdateVertexRequest{Id: "orders", Vertex: &overviewpb.Vertex{Name: "Orders API"}, Version: 1})
+	if err != nil {
+		t.Fatalf("UpdateVertex: %v", err)
+	}
+	if updated.Name != "Orders API" || updated.Version != 2 || updated.CreatedAt.AsTime().IsZero() {
+		t.Errorf("unexpected updated vertex %+v", updated)
+	}
+
+	graph, err := client.GetGraph(ctx, &overviewpb.GetGraphRequest{})
+	if err != nil {
+		t.Fatalf("GetGraph: %v", err)
+	}
+	if len(graph.Vertices) != 2 || len(graph.Edges) != 1 || graph.Edges[0].LatencyMs != 3 {
+		t.Errorf("unexpected graph %+v", graph)
+	}
+
+	if _, err := client.DeleteEdge(ctx, &overviewpb.DeleteEdgeRequest{Id: edge.Id}); err != nil {
+		t.Fatalf("DeleteEdge: %v", err)
+	}
+	edges, _ := client.ListEdges(ctx, &overviewpb.ListEdgesRequest{})
+	if len(edges.Edges) != 0 {
+		t.Errorf("expected no edges, got %v", edges.Edges)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+	client.CreateVertex(ctx, &overviewpb.CreateVertexRequest{Vertex: &overviewpb.Vertex{Id: "a", Name: "A"}})
+
+	tests := []struct {
+		name   string
+		call   func() error
+		code   codes.Code
+		reason string
+	}{
+		{"missing vertex", func() error {
+			_, err := client.GetVertex(ctx, &overviewpb.GetVertexRequest{Id: "missing"})
+			return err
+		}, codes.NotFound, ""},
+		{"missing name", func() error {
+			_, err := client.CreateVertex(ctx, &overviewpb.CreateVertexRequest{Vertex: &overviewpb.Vertex{}})
+			return err
+		}, codes.InvalidArgument, ""},
+		{"unknown kind", func() error {
+			_, err := client.CreateVertex(ctx, &overviewpb.CreateVertexRequest{Vertex: &overviewpb.Vertex{Name: "B", Kind: "no-such-kind"}})
+			return err
+		}, codes.InvalidArgument, ""},
+		{"stale version", func() error {
+			_, err := client.DeleteVertex(ctx, &overviewpb.DeleteVertexRequest{Id: "a", Version: 5})
+			return err
+		}, codes.Aborted, problem.CodeVersionConflict},
+		{"edge to missing vertex", func() error {
+			_, err := client.CreateEdge(ctx, &overviewpb.CreateEdgeRequest{Edge: &overviewpb.Edge{From: "a", To: "missing"}})
+			return err
+		}, codes.InvalidArgument, ""},
+		{"duplicate ID", func() error {
+			_, err := client.CreateVertex(ctx, &overviewpb.CreateVertexRequest{Vertex: &overviewpb.Vertex{Id: "a", Name: "Again"}})
+			return err
+		}, codes.AlreadyExists, problem.CodeDuplicateID},
+		{"cycle in hierarchy", func() error {
+			parent := "a"
+			_, err := client.MoveVertex(ctx, &overviewpb.MoveVertexRequest{Id: "a", ParentId: &parent})
+			return err
+		}, codes.FailedPrecondition, problem.CodeHierarchyCycle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(tt.call())
+			if st.Code() != tt.code {
+				t.Errorf("expected %s, got %s", tt.code, st.Code())
+			}
+			if tt.reason != "" && errorReason(st) != tt.reason {
+				t.Errorf("expected reason %s, got %q", tt.reason, errorReason(st))
+			}
+		})
+	}
+}
+
+// failingStorage zwraca błąd bazy przy odczycie wierzchołków i relacji
+type failingStorage struct {
+	storage.Storage
+}
+
+func (failingStorage) GetVertexByIDOrSlug(ref string) (*models.Vertex, error) {
+	return nil, errors.New("pq: connection refused")
+}
+
+func (failingStorage) GetEdgeByID(id string) (*models.Edge, error) {
+	return nil, errors.New("pq: connection refused")
+}
+
+func TestGetErrors(t *testing.T) {
+	ctx := context.Background()
+	server := NewServer(failingStorage{}, events.NewBus())
+
+	// Błąd bazy to nie brak zasobu - klient nie może uznać wierzchołka za usunięty
+	calls := map[string]func() error{
+		"GetVertex": func() error {
+			_, err := server.GetVertex(ctx, &overviewpb.GetVertexRequest{Id: "a"})
+			return err
+		},
+		"UpdateVertex": func() error {
+			_, err := server.UpdateVertex(ctx, &overviewpb.UpdateVertexRequest{Id: "a", Vertex: &overviewpb.Vertex{Name: "A"}})
+			return err
+		},
+		"DeleteVertex": func() error {
+			_, err := server.DeleteVertex(ctx, &overviewpb.DeleteVertexRequest{Id: "a"})
+			return err
+		},
+		"GetEdge": func() error {
+			_, err := server.GetEdge(ctx, &overviewpb.GetEdgeRequest{Id: "e1"})
+			return err
+		},
+		"DeleteEdge": func() error {
+			_, err := server.DeleteEdge(ctx, &overviewpb.DeleteEdgeRequest{Id: "e1"})
+			return err
+		},
+	}
+	for name, call := range calls {
+		if st := status.Convert(call()); st.Code() != codes.Internal || st.Message() != problem.GenericDetail {
+			t.Errorf("%s: expected a generic internal error, got %s %q", name, st.Code(), st.Message())
+		}
+	}
+
+	// Brak zasobu ma kod NOT_FOUND w szczegółach
+	client := newTestClient(t)
+	_, err := client.GetEdge(ctx, &overviewpb.GetEdgeRequest{Id: "missing"})
+	if st := status.Convert(err); st.Code() != codes.NotFound || errorReason(st) != problem.CodeNotFound {
+		t.Errorf("expected NOT_FOUND, got %s %q", st.Code(), errorReason(st))
+	}
+}
+
+// errorReason zwraca kod błędu REST ze szczegółów ErrorInfo
+func errorReason(st *status.Status) string {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+func TestWriteError_MatchesRESTCodes(t *testing.T) {
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{fmt.Errorf("%w: a", storage.ErrHierarchyCycle), codes.FailedPrecondition},
+		{fmt.Errorf("%w: a", storage.ErrEdgeOnNonLeaf), codes.FailedPrecondition},
+		{fmt.Errorf("%w: calls", storage.ErrEdgeTypeInUse), codes.FailedPrecondition},
+		{fmt.Errorf("%w: x", storage.ErrRuleViolation), codes.FailedPrecondition},
+		{fmt.Errorf("%w: x", storage.ErrParentNotFound), codes.InvalidArgument},
+		{fmt.Errorf("%w: x", storage.ErrInvalidSLO), codes.InvalidArgument},
+	}
+	for _, tc := range cases {
+		_, code, _ := problem.For(tc.err)
+		st := status.Convert(writeError(tc.err))
+		if st.Code() != tc.code || errorReason(st) != code {
+			t.Errorf("writeError(%v) = %s %q, expected %s %s", tc.err, st.Code(), errorReason(st), tc.code, code)
+		}
+	}
+
+	// Nieznany błąd nie ujawnia przyczyny
+	if st := status.Convert(writeError(errors.New("connection refused"))); st.Code() != codes.Internal || st.Message() != problem.GenericDetail {
+		t.Errorf("expected a generic internal error, got %s %q", st.Code(), st.Message())
+	}
+}
+
+func TestWatch(t *testing.T) {
+	client := newTestClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &overviewpb.WatchRequest{Types: []string{events.VertexCreated, events.VertexDeleted}})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	// Subskrypcja powstaje po stronie serwera asynchronicznie - czekamy na
+	// pierwsze zdarzenie, tworząc wierzchołki do skutku
+	received := make(chan *overviewpb.Event)
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				close(received)
+				return
+			}
+			received <- event
+		}
+	}()
+
+	var first *overviewpb.Event
+	for first == nil {
+		client.CreateVertex(ctx, &overviewpb.CreateVertexRequest{Vertex: &overviewpb.Vertex{Name: "Probe"}})
+		select {
+		case first = <-received:
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	if first.Type != events.VertexCreated || first.GetVertex().GetName() != "Probe" {
+		t.Fatalf("unexpected event %+v", first)
+	}
+
+	// Aktualizacja jest odfiltrowana, usunięcie nie
+	client.UpdateVertex(ctx, &overviewpb.UpdateVertexRequest{Id: first.GetVertex().Id, Vertex: &overviewpb.Vertex{Name: "Renamed"}})
+	client.DeleteVertex(ctx, &overviewpb.DeleteVertexRequest{Id: first.GetVertex().Id})
+	for event := range received {
+		if event.Type == events.VertexCreated {
+			continue // nadmiarowe wierzchołki z pętli powyżej
+		}
+		if event.Type != events.VertexDeleted || event.GetVertex().GetName() != "Renamed" {
+			t.Errorf("expected vertex.deleted with the last known state, got %+v", event)
+		}
+		break
+	}
+
+	badStream, _ := client.Watch(ctx, &overviewpb.WatchRequest{Types: []string{"vertex.renamed"}})
+	if _, err := badStream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an unknown event type, got %v", err)
+	}
+}
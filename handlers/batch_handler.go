@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"microservice_overview/models"
+	"microservice_overview/problem"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBatchOperations limit operacji w jednej paczce; zdarzenia paczki trafiają
+// do subskrybentów naraz, więc limit nie może przekraczać events.SubscriberBuffer
+const maxBatchOperations = 1000
+
+// Operacje paczki
+const (
+	batchCreate = "create"
+	batchUpdate = "update"
+	batchDelete = "delete"
+	batchMove   = "move" // Tylko wierzchołki
+)
+
+// Typy obiektów paczki
+const (
+	batchVertex = "vertex"
+	batchEdge   = "edge"
+)
+
+// batchRefPrefix poprzedza nazwę obiektu utworzonego wcześniej w paczce, np. "$payments"
+const batchRefPrefix = "$"
+
+// BatchHandler wykonuje paczki zmian wierzchołków i relacji w jednej transakcji
+type BatchHandler struct {
+	storage storage.Storage
+}
+
+// NewBatchHandler tworzy nowy BatchHandler
+func NewBatchHandler(s storage.Storage) *BatchHandler {
+	return &BatchHandler{storage: s}
+}
+
+// batchRequest lista operacji wykonywanych po kolei
+type batchRequest struct {
+	Operations []batchOperation `json:"operations"`
+}
+
+// batchOperation pojedyncza zmiana. Pola id, parent_id oraz from i to relacji
+// mogą wskazywać obiekt utworzony wcześniej w tej samej paczce ("$" i jego ref)
+type batchOperation struct {
+	Op       string         `json:"op"`                  // create, update, delete lub move
+	Type     string         `json:"type"`                // vertex lub edge
+	Ref      string         `json:"ref,omitempty"`       // Nazwa wyniku dla kolejnych operacji
+	ID       string         `json:"id,omitempty"`        // Zmieniany obiekt (update, delete, move); wierzchołek także po slugu
+	Version  int64          `json:"version,omitempty"`   // Oczekiwana wersja (0 - bez sprawdzania)
+	ParentID *string        `json:"parent_id,omitempty"` // Nowy rodzic (move); null lub brak - najwyższy poziom
+	Vertex   *models.Vertex `json:"vertex,omitempty"`    // Dane wierzchołka (create, update)
+	Edge     *models.Edge   `json:"edge,omitempty"`      // Dane relacji (create, update)
+}
+
+// batchResult wynik operacji; dla create, update i move zawiera stan obiektu po zmianie
+type batchResult struct {
+	Op     string         `json:"op"`
+	Type   string         `json:"type"`
+	Ref    string         `json:"ref,omitempty"`
+	Status int            `json:"status"`
+	ID     string         `json:"id"`
+	Vertex *models.Vertex `json:"vertex,omitempty"`
+	Edge   *models.Edge   `json:"edge,omitempty"`
+}
+
+// batchResponse wyniki w kolejności operacji
+type batchResponse struct {
+	Results []batchResult `json:"results"`
+}
+
+// batchError błąd operacji paczki wraz z jej indeksem
+type batchError struct {
+	index    int
+	fallback int // Status dla błędów bez własnego (zob. respondError)
+	err      error
+}
+
+func (e *batchError) Error() string { return e.err.Error() }
+func (e *batchError) Unwrap() error { return e.err }
+
+// ExecuteBatch wykonuje operacje po kolei w jednej transakcji. Błąd dowolnej
+// operacji wycofuje całą paczkę; odpowiedź wskazuje ją w polu operation
+func (h *BatchHandler) ExecuteBatch(c *gin.Context) {
+	var req batchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if len(req.Operations) == 0 {
+		respondProblem(c, http.StatusBadRequest, "operations must not be empty")
+		return
+	}
+	if len(req.Operations) > maxBatchOperations {
+		respondProblem(c, http.StatusBadRequest, fmt.Sprintf("at most %d operations are allowed", maxBatchOperations))
+		return
+	}
+	if err := validateBatch(req.Operations); err != nil {
+		respondBatchError(c, err)
+		return
+	}
+
+	var results []batchResult
+	err := h.storage.Transaction(func(tx storage.Storage) error {
+		results = make([]batchResult, 0, len(req.Operations))
+		refs := make(map[string]string)
+		for i, op := range req.Operations {
+			result, err := op.execute(tx, refs)
+			if err != nil {
+				return &batchError{index: i, fallback: http.StatusInternalServerError, err: err}
+			}
+			if op.Ref != "" {
+				refs[op.Ref] = result.ID
+			}
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		respondBatchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, batchResponse{Results: results})
+}
+
+// respondBatchError wysyła błąd operacji jako problem z jej indeksem
+func respondBatchError(c *gin.Context, err error) {
+	var opErr *batchError
+	if !errors.As(err, &opErr) {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	p := problemFor(opErr.fallback, opErr.err)
+	p.Detail = fmt.Sprintf("operations[%d]: %s", opErr.index, p.Detail)
+	p.Operation = &opErr.index
+	problem.Abort(c, p)
+}
+
+// validateBatch sprawdza operacje przed otwarciem transakcji: wymagane pola
+// oraz odwołania tylko do refów zadeklarowanych we wcześniejszych operacjach
+func validateBatch(operations []batchOperation) error {
+	declared := make(map[string]bool)
+	for i, op := range operations {
+		if err := op.validate(declared); err != nil {
+			return &batchError{index: i, fallback: http.StatusBadRequest, err: err}
+		}
+		if op.Ref != "" {
+			declared[op.Ref] = true
+		}
+	}
+	return nil
+}
+
+// validate sprawdza pola operacji; declared - refy wcześniejszych operacji
+func (op *batchOperation) validate(declared map[string]bool) error {
+	switch op.Type {
+	case batchVertex:
+		switch op.Op {
+		case batchCreate, batchUpdate:
+			if op.Vertex == nil {
+				return errors.New("vertex is required")
+			}
+			if op.Vertex.Name == "" {
+				return errors.New("vertex.name is required")
+			}
+		case batchDelete, batchMove:
+		default:
+			return fmt.Errorf("unknown op %q - expected create, update, delete or move", op.Op)
+		}
+	case batchEdge:
+		switch op.Op {
+		case batchCreate:
+			if op.Edge == nil {
+				return errors.New("edge is required")
+			}
+			if op.Edge.From == "" {
+				return errors.New("edge.from is required")
+			}
+			if op.Edge.To == "" {
+				return errors.New("edge.to is required")
+			}
+		case batchUpdate:
+			if op.Edge == nil {
+				return errors.New("edge is required")
+			}
+		case batchDelete:
+		default:
+			return fmt.Errorf("unknown op %q for edge - expected create, update or delete", op.Op)
+		}
+	default:
+		return fmt.Errorf("unknown type %q - expected vertex or edge", op.Type)
+	}
+
+	if op.Op != batchCreate && op.ID == "" {
+		return fmt.Errorf("id is required for %s", op.Op)
+	}
+
+	if op.Ref != "" {
+		if op.Op == batchDelete {
+			return errors.New("ref is not allowed for delete")
+		}
+		if strings.HasPrefix(op.Ref, batchRefPrefix) {
+			return fmt.Errorf("ref must not start with %s", batchRefPrefix)
+		}
+		if declared[op.Ref] {
+			return fmt.Errorf("ref %s is already used", op.Ref)
+		}
+	}
+
+	for _, value := range op.references() {
+		if name, isRef := strings.CutPrefix(value, batchRefPrefix); isRef && !declared[name] {
+			return fmt.Errorf("unknown reference %s - refs must be declared by an earlier operation", value)
+		}
+	}
+	return nil
+}
+
+// references zwraca pola operacji, które mogą wskazywać ref
+func (op *batchOperation) references() []string {
+	values := []string{op.ID}
+	if op.ParentID != nil {
+		values = append(values, *op.ParentID)
+	}
+	if op.Vertex != nil && op.Vertex.ParentID != nil {
+		values = append(values, *op.Vertex.ParentID)
+	}
+	if op.Edge != nil {
+		values = append(values, op.Edge.From, op.Edge.To)
+	}
+	return values
+}
+
+// execute wykonuje operację na storage transakcji; refs mapuje nazwy na ID
+// obiektów utworzonych wcześniej w paczce
+func (op *batchOperation) execute(tx storage.Storage, refs map[string]string) (batchResult, error) {
+	resolve := func(value string) string {
+		if name, isRef := strings.CutPrefix(value, batchRefPrefix); isRef {
+			return refs[name]
+		}
+		return value
+	}
+	resolvePtr := func(value *string) *string {
+		if value == nil {
+			return nil
+		}
+		resolved := resolve(*value)
+		return &resolved
+	}
+
+	result := batchResult{Op: op.Op, Type: op.Type, Ref: op.Ref, Status: http.StatusOK}
+	switch op.Type {
+	case batchVertex:
+		if op.Op == batchCreate {
+			vertex := *op.Vertex
+			vertex.ParentID = resolvePtr(vertex.ParentID)
+			if err := tx.CreateVertex(&vertex); err != nil {
+				return result, err
+			}
+			result.Status, result.ID, result.Vertex = http.StatusCreated, vertex.ID, &vertex
+			return result, nil
+		}
+
+		current, err := tx.GetVertexByIDOrSlug(resolve(op.ID))
+		if err != nil {
+			return result, err
+		}
+		version := op.Version
+		if version == 0 {
+			version = current.Version
+		}
+		result.ID = current.ID
+
+		switch op.Op {
+		case batchUpdate:
+			vertex := *op.Vertex
+			vertex.ID = current.ID
+			vertex.Version = version
+			vertex.ParentID = resolvePtr(vertex.ParentID)
+			if err := tx.UpdateVertex(&vertex); err != nil {
+				return result, err
+			}
+			result.Vertex = &vertex
+		case batchMove:
+			vertex, err := tx.MoveVertex(current.ID, resolvePtr(op.ParentID), version)
+			if err != nil {
+				return result, err
+			}
+			result.Vertex = vertex
+		case batchDelete:
+			if err := tx.DeleteVertex(current.ID, version); err != nil {
+				return result, err
+			}
+		}
+		return result, nil
+
+	default:
+		if op.Op == batchCreate {
+			edge := *op.Edge
+			edge.From, edge.To = resolve(edge.From), resolve(edge.To)
+			if err := tx.CreateEdge(&edge); err != nil {
+				return result, err
+			}
+			result.Status, result.ID, result.Edge = http.StatusCreated, edge.ID, &edge
+			return result, nil
+		}
+
+		current, err := tx.GetEdgeByID(resolve(op.ID))
+		if err != nil {
+			return result, err
+		}
+		version := op.Version
+		if version == 0 {
+			version = current.Version
+		}
+		result.ID = current.ID
+
+		switch op.Op {
+		case batchUpdate:
+			edge := *op.Edge
+			edge.ID = current.ID
+			edge.Version = version
+			edge.From, edge.To = resolve(edge.From), resolve(edge.To)
+			if err := tx.UpdateEdge(&edge); err != nil {
+				return result, err
+			}
+			result.Edge = &edge
+		case batchDelete:
+			if err := tx.DeleteEdge(current.ID, version); err != nil {
+				return result, err
+			}
+		}
+		return result, nil
+	}
+}
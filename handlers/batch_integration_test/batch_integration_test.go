@@ -0,0 +1,284 @@
+package batch_integration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"microservice_overview/events"
+	"microservice_overview/handlers"
+	"microservice_overview/models"
+	"microservice_overview/storage"
+	"microservice_overview/webhooks"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupTestRouter rejestruje trasy API przez handlers.RegisterAPI, tak jak main.go
+func setupTestRouter() (*gin.Engine, storage.Storage, *events.Bus) {
+	gin.SetMode(gin.TestMode)
+
+	// Ustaw tryb developerski dla testów
+	os.Setenv("DEV_MODE", "true")
+
+	// Utwórz storage z bazą w pamięci
+	db, err := storage.NewStorage()
+	if err != nil {
+		os.Unsetenv("DEV_MODE")
+		panic("failed to create storage: " + err.Error())
+	}
+	bus := events.NewBus()
+	s := events.NewPublishingStorage(db, bus)
+
+	// Utwórz router z tymi samymi trasami co main.go
+	r := gin.New()
+	deps := handlers.Dependencies{
+		Storage:     s,
+		Bus:         bus,
+		Dispatcher:  webhooks.NewDispatcher(s, bus),
+		Idempotency: handlers.NewIdempotency(s, time.Hour),
+	}
+	if err := handlers.RegisterAPI(r, deps, handlers.APIVersion{Name: "v1"}, handlers.APIVersion{Name: "v2"}); err != nil {
+		panic("failed to register routes: " + err.Error())
+	}
+
+	return r, s, bus
+}
+
+type batchResponse struct {
+	Results []struct {
+		Op     string         `json:"op"`
+		Type   string         `json:"type"`
+		Ref    string         `json:"ref"`
+		Status int            `json:"status"`
+		ID     string         `json:"id"`
+		Vertex *models.Vertex `json:"vertex"`
+		Edge   *models.Edge   `json:"edge"`
+	} `json:"results"`
+}
+
+func doBatch(r *gin.Engine, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", "/api/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestBatch_CreateWithReferences_Integration(t *testing.T) {
+	r, s, bus := setupTestRouter()
+	s.CreateVertex(&models.Vertex{ID: "ledger", Name: "Ledger"})
+	sub := bus.Subscribe(16)
+	defer sub.Close()
+
+	w := doBatch(r, `{"operations": [
+		{"op": "create", "type": "vertex", "ref": "team", "vertex": {"name": "Payments", "kind": "team"}},
+		{"op": "create", "type": "vertex", "ref": "api", "vertex": {"name": "Payments API", "parent_id": "$team"}},
+		{"op": "create", "type": "edge", "ref": "call", "edge": {"from": "$api", "to": "ledger", "type": "calls"}},
+		{"op": "update", "type": "edge", "id": "$call", "edge": {"from": "$api", "to": "ledger", "type": "calls", "latency_ms": 20}},
+		{"op": "create", "type": "vertex", "ref": "old", "vertex": {"name": "Legacy"}},
+		{"op": "move", "type": "vertex", "id": "$old", "parent_id": "$team"},
+		{"op": "delete", "type": "vertex", "id": "$old"}
+	]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response batchResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if len(response.Results) != 7 {
+		t.Fatalf("Expected 7 results, got %d", len(response.Results))
+	}
+	team, api, call := response.Results[0], response.Results[1], response.Results[2]
+	if team.Status != http.StatusCreated || team.ID == "" || team.Vertex == nil || team.Vertex.Slug != "payments" {
+		t.Errorf("Unexpected result of the first operation: %+v", team)
+	}
+	if api.Vertex == nil || api.Vertex.ParentID == nil || *api.Vertex.ParentID != team.ID {
+		t.Errorf("Expected the reference to resolve to the created parent %s, got %+v", team.ID, api.Vertex)
+	}
+	if call.Edge == nil || call.Edge.From != api.ID {
+		t.Errorf("Expected the edge to start at the created vertex %s, got %+v", api.ID, call.Edge)
+	}
+	if update := response.Results[3]; update.Status != http.StatusOK || update.ID != call.ID || update.Edge.Version != 2 {
+		t.Errorf("Expected the edge update to apply to the created edge, got %+v", update)
+	}
+	if moved := response.Results[5]; moved.Vertex == nil || moved.Vertex.ParentID == nil || *moved.Vertex.ParentID != team.ID {
+		t.Errorf("Expected the moved vertex under the team, got %+v", moved.Vertex)
+	}
+	if deleted := response.Results[6]; deleted.ID != response.Results[4].ID || deleted.Vertex != nil {
+		t.Errorf("Unexpected result of delete: %+v", deleted)
+	}
+
+	edge, err := s.GetEdgeByID(call.ID)
+	if err != nil || edge.LatencyMs != 20 {
+		t.Errorf("Expected the stored edge to be updated, got %+v %v", edge, err)
+	}
+	if _, err := s.GetVertexByID(response.Results[4].ID); err == nil {
+		t.Error("Expected the deleted vertex to be gone")
+	}
+
+	// Zdarzenia wszystkich operacji trafiają na szynę po zatwierdzeniu
+	expected := []string{events.VertexCreated, events.VertexCreated, events.EdgeCreated, events.EdgeUpdated, events.VertexCreated, events.VertexUpdated, events.VertexDeleted}
+	for _, eventType := range expected {
+		if event := <-sub.C; event.Type != eventType {
+			t.Fatalf("Expected event %s, got %s", eventType, event.Type)
+		}
+	}
+}
+
+func TestBatch_RollbackOnFailure_Integration(t *testing.T) {
+	r, s, bus := setupTestRouter()
+	s.CreateVertex(&models.Vertex{ID: "domain", Name: "Domain"})
+	s.CreateVertex(&models.Vertex{ID: "service", Name: "Service", ParentID: stringPtr("domain")})
+	sub := bus.Subscribe(16)
+	defer sub.Close()
+
+	tests := []struct {
+		name         string
+		operations   string
+		expectedCode int
+		problemCode  string
+		operation    int
+	}{
+		{
+			name: "edge on non-leaf vertex",
+			operations: `[
+				{"op": "create", "type": "vertex", "ref": "a", "vertex": {"name": "A"}},
+				{"op": "create", "type": "edge", "edge": {"from": "$a", "to": "domain", "type": "calls"}}
+			]`,
+			expectedCode: http.StatusConflict,
+			problemCode:  "EDGE_ON_NON_LEAF",
+			operation:    1,
+		},
+		{
+			name: "hierarchy cycle",
+			operations: `[
+				{"op": "create", "type": "vertex", "ref": "child", "vertex": {"name": "Child", "parent_id": "service"}},
+				{"op": "move", "type": "vertex", "id": "domain", "parent_id": "$child"}
+			]`,
+			expectedCode: http.StatusConflict,
+			problemCode:  "HIERARCHY_CYCLE",
+			operation:    1,
+		},
+		{
+			name: "missing vertex",
+			operations: `[
+				{"op": "create", "type": "vertex", "vertex": {"name": "Kept?"}},
+				{"op": "update", "type": "vertex", "id": "missing", "vertex": {"name": "X"}}
+			]`,
+			expectedCode: http.StatusNotFound,
+			problemCode:  "NOT_FOUND",
+			operation:    1,
+		},
+		{
+			name: "stale version",
+			operations: `[
+				{"op": "create", "type": "vertex", "vertex": {"name": "Kept?"}},
+				{"op": "delete", "type": "vertex", "id": "service", "version": 7}
+			]`,
+			expectedCode: http.StatusPreconditionFailed,
+			problemCode:  "VERSION_CONFLICT",
+			operation:    1,
+		},
+		{
+			name: "duplicate ID",
+			operations: `[
+				{"op": "create", "type": "vertex", "vertex": {"id": "x", "name": "X"}},
+				{"op": "create", "type": "vertex", "vertex": {"id": "x", "name": "X again"}}
+			]`,
+			expectedCode: http.StatusConflict,
+			problemCode:  "DUPLICATE_ID",
+			operation:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doBatch(r, `{"operations": `+tt.operations+`}`)
+			if w.Code != tt.expectedCode {
+				t.Fatalf("Expected status code %d, got %d. Body: %s", tt.expectedCode, w.Code, w.Body.String())
+			}
+			var problem struct {
+				Code      string `json:"code"`
+				Detail    string `json:"detail"`
+				Operation *int   `json:"operation"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &problem)
+			if problem.Code != tt.problemCode || problem.Operation == nil || *problem.Operation != tt.operation {
+				t.Errorf("Expected %s at operation %d, got %s", tt.problemCode, tt.operation, w.Body.String())
+			}
+
+			vertices, _ := s.GetAllVertices()
+			if len(vertices) != 2 {
+				t.Errorf("Expected the batch to be rolled back, got %d vertices", len(vertices))
+			}
+		})
+	}
+
+	select {
+	case event := <-sub.C:
+		t.Errorf("Expected no events from rolled back batches, got %+v", event)
+	default:
+	}
+}
+
+func TestBatch_InvalidRequest_Integration(t *testing.T) {
+	r, s, _ := setupTestRouter()
+
+	tests := []struct {
+		name       string
+		operations string
+		message    string
+		operation  int
+	}{
+		{"empty", `[]`, "operations must not be empty", -1},
+		{"unknown type", `[{"op": "create", "type": "layer"}]`, "unknown type", 0},
+		{"unknown op", `[{"op": "upsert", "type": "vertex", "vertex": {"name": "A"}}]`, "unknown op", 0},
+		{"move edge", `[{"op": "move", "type": "edge", "id": "e1"}]`, "for edge", 0},
+		{"missing name", `[{"op": "create", "type": "vertex", "vertex": {}}]`, "vertex.name is required", 0},
+		{"missing id", `[{"op": "delete", "type": "edge"}]`, "id is required for delete", 0},
+		{"missing edge end", `[{"op": "create", "type": "edge", "edge": {"from": "a"}}]`, "edge.to is required", 0},
+		{"forward reference", `[
+			{"op": "create", "type": "vertex", "vertex": {"name": "A", "parent_id": "$b"}},
+			{"op": "create", "type": "vertex", "ref": "b", "vertex": {"name": "B"}}
+		]`, "unknown reference $b", 0},
+		{"duplicate ref", `[
+			{"op": "create", "type": "vertex", "ref": "a", "vertex": {"name": "A"}},
+			{"op": "create", "type": "vertex", "ref": "a", "vertex": {"name": "B"}}
+		]`, "ref a is already used", 1},
+		{"ref on delete", `[{"op": "delete", "type": "vertex", "id": "a", "ref": "gone"}]`, "ref is not allowed for delete", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doBatch(r, `{"operations": `+tt.operations+`}`)
+			if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), tt.message) {
+				t.Fatalf("Expected 400 with %q, got %d %s", tt.message, w.Code, w.Body.String())
+			}
+			var problem struct {
+				Code      string `json:"code"`
+				Operation *int   `json:"operation"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &problem)
+			if problem.Code != "INVALID_REQUEST" {
+				t.Errorf("Expected INVALID_REQUEST, got %s", problem.Code)
+			}
+			if tt.operation >= 0 && (problem.Operation == nil || *problem.Operation != tt.operation) {
+				t.Errorf("Expected operation %d, got %s", tt.operation, w.Body.String())
+			}
+		})
+	}
+
+	if vertices, _ := s.GetAllVertices(); len(vertices) != 0 {
+		t.Errorf("Expected nothing to be written, got %d vertices", len(vertices))
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"microservice_overview/models"
@@ -23,7 +24,7 @@ func NewEdgeHandler(s storage.Storage) *EdgeHandler {
 func (h *EdgeHandler) GetAllEdges(c *gin.Context) {
 	edges, err := h.storage.GetAllEdges()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, err)
 		return
 	}
 	c.JSON(http.StatusOK, edges)
@@ -34,74 +35,143 @@ func (h *EdgeHandler) GetEdgeByID(c *gin.Context) {
 	id := c.Param("id")
 	edge, err := h.storage.GetEdgeByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "edge not found"})
+		respondError(c, http.StatusInternalServerError, err)
 		return
 	}
+	c.Header("ETag", versionETag(edge.Version))
 	c.JSON(http.StatusOK, edge)
 }
 
-// CreateEdge tworzy nową relację
+// CreateEdge tworzy nową relację (bez id - ID generuje serwer)
 func (h *EdgeHandler) CreateEdge(c *gin.Context) {
 	var edge models.Edge
 	if err := c.ShouldBindJSON(&edge); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	if edge.ID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "id is required"})
+		respondError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	if edge.From == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "from is required"})
+		respondProblem(c, http.StatusBadRequest, "from is required")
 		return
 	}
 
 	if edge.To == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "to is required"})
+		respondProblem(c, http.StatusBadRequest, "to is required")
 		return
 	}
 
 	if err := h.storage.CreateEdge(&edge); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	c.Header("ETag", versionETag(edge.Version))
 	c.JSON(http.StatusCreated, edge)
 }
 
-// UpdateEdge aktualizuje istniejącą relację
+// UpdateEdge aktualizuje istniejącą relację (wymaga nagłówka If-Match)
 func (h *EdgeHandler) UpdateEdge(c *gin.Context) {
 	id := c.Param("id")
 
 	var edge models.Edge
 	if err := c.ShouldBindJSON(&edge); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	current, err := h.storage.GetEdgeByID(id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !checkIfMatch(c, current.Version) {
 		return
 	}
 
 	edge.ID = id
+	edge.Version = current.Version
 
 	if err := h.storage.UpdateEdge(&edge); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	c.Header("ETag", versionETag(edge.Version))
 	c.JSON(http.StatusOK, edge)
 }
 
-// DeleteEdge usuwa relację
-func (h *EdgeHandler) DeleteEdge(c *gin.Context) {
+// PatchEdge częściowo aktualizuje relację (merge patch lub JSON Patch, If-Match opcjonalny)
+func (h *EdgeHandler) PatchEdge(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.storage.DeleteEdge(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	current, err := h.storage.GetEdgeByID(id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !checkOptionalIfMatch(c, current.Version) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "edge deleted"})
+	doc, err := json.Marshal(current)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	patched, ok := applyRequestPatch(c, doc)
+	if !ok {
+		return
+	}
+
+	var edge models.Edge
+	if err := json.Unmarshal(patched, &edge); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if edge.ID != id {
+		respondProblem(c, http.StatusBadRequest, "id cannot be changed")
+		return
+	}
+
+	if edge.From == "" {
+		respondProblem(c, http.StatusBadRequest, "from is required")
+		return
+	}
+
+	if edge.To == "" {
+		respondProblem(c, http.StatusBadRequest, "to is required")
+		return
+	}
+
+	edge.Version = current.Version
+
+	if err := h.storage.UpdateEdge(&edge); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("ETag", versionETag(edge.Version))
+	c.JSON(http.StatusOK, edge)
 }
 
+// DeleteEdge usuwa relację (wymaga nagłówka If-Match)
+func (h *EdgeHandler) DeleteEdge(c *gin.Context) {
+	id := c.Param("id")
 
+	current, err := h.storage.GetEdgeByID(id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !checkIfMatch(c, current.Version) {
+		return
+	}
+
+	if err := h.storage.DeleteEdge(id, current.Version); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
 
+	c.JSON(http.StatusOK, gin.H{"message": "edge deleted"})
+}
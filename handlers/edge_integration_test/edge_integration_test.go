@@ -3,9 +3,11 @@ package edge_integration_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"microservice_overview/handlers"
@@ -38,6 +40,7 @@ func setupTestRouter() (*gin.Engine, storage.Storage) {
 		api.GET("/edges/:id", edgeHandler.GetEdgeByID)
 		api.POST("/edges", edgeHandler.CreateEdge)
 		api.PUT("/edges/:id", edgeHandler.UpdateEdge)
+		api.PATCH("/edges/:id", edgeHandler.PatchEdge)
 		api.DELETE("/edges/:id", edgeHandler.DeleteEdge)
 	}
 
@@ -105,8 +108,13 @@ func TestCreateEdge_WithParentVertex_ShouldFail_Integration(t *testing.T) {
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Expected status code %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d, got %d. Body: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+	var body map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if body["code"] != "EDGE_ON_NON_LEAF" {
+		t.Errorf("Expected code EDGE_ON_NON_LEAF, got %v", body["code"])
 	}
 }
 
@@ -196,6 +204,7 @@ func TestUpdateEdge_Integration(t *testing.T) {
 	jsonValue, _ := json.Marshal(updated)
 	req, _ := http.NewRequest("PUT", "/api/edges/update-edge", bytes.NewBuffer(jsonValue))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
 
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
@@ -210,6 +219,56 @@ func TestUpdateEdge_Integration(t *testing.T) {
 	if response.To != updated.To {
 		t.Errorf("Expected To %s, got %s", updated.To, response.To)
 	}
+	if response.Version != 2 {
+		t.Errorf("Expected version 2, got %d", response.Version)
+	}
+	if w.Header().Get("ETag") != `"2"` {
+		t.Errorf("Expected ETag \"2\", got %s", w.Header().Get("ETag"))
+	}
+}
+
+func TestUpdateEdge_Preconditions_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "v1", Name: "Vertex 1"})
+	s.CreateVertex(&models.Vertex{ID: "v2", Name: "Vertex 2"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "v1", To: "v2", Type: "calls"})
+
+	tests := []struct {
+		name           string
+		method         string
+		ifMatch        string
+		expectedStatus int
+	}{
+		{name: "update without If-Match", method: "PUT", ifMatch: "", expectedStatus: http.StatusPreconditionRequired},
+		{name: "update with stale version", method: "PUT", ifMatch: `"7"`, expectedStatus: http.StatusPreconditionFailed},
+		{name: "delete without If-Match", method: "DELETE", ifMatch: "", expectedStatus: http.StatusPreconditionRequired},
+		{name: "delete with stale version", method: "DELETE", ifMatch: `"7"`, expectedStatus: http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonValue, _ := json.Marshal(models.Edge{From: "v2", To: "v1", Type: "calls"})
+			req, _ := http.NewRequest(tt.method, "/api/edges/e1", bytes.NewBuffer(jsonValue))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+
+	// Żadna z odrzuconych operacji nie zmieniła relacji
+	edge, err := s.GetEdgeByID("e1")
+	if err != nil || edge.From != "v1" || edge.Version != 1 {
+		t.Errorf("Expected edge to stay unchanged, got %+v (err %v)", edge, err)
+	}
 }
 
 func TestDeleteEdge_Integration(t *testing.T) {
@@ -225,6 +284,7 @@ func TestDeleteEdge_Integration(t *testing.T) {
 	s.CreateEdge(edge)
 
 	req, _ := http.NewRequest("DELETE", "/api/edges/delete-edge", nil)
+	req.Header.Set("If-Match", `"1"`)
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 
@@ -247,11 +307,6 @@ func TestCreateEdge_Validation_Integration(t *testing.T) {
 		edge           models.Edge
 		expectedStatus int
 	}{
-		{
-			name:           "missing ID",
-			edge:           models.Edge{From: "v1", To: "v2"},
-			expectedStatus: http.StatusBadRequest,
-		},
 		{
 			name:           "missing From",
 			edge:           models.Edge{ID: "e1", To: "v2"},
@@ -280,7 +335,189 @@ func TestCreateEdge_Validation_Integration(t *testing.T) {
 	}
 }
 
+func TestCreateEdge_EdgeTypeCatalogue_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "v1", Name: "Vertex 1"})
+	s.CreateVertex(&models.Vertex{ID: "v2", Name: "Vertex 2"})
+
+	tests := []struct {
+		name           string
+		edgeType       string
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "known type", edgeType: "calls", expectedStatus: http.StatusCreated, expectedType: "calls"},
+		{name: "type is normalized", edgeType: "Calls-Async", expectedStatus: http.StatusCreated, expectedType: "calls_async"},
+		{name: "empty type", edgeType: "", expectedStatus: http.StatusCreated, expectedType: ""},
+		{name: "unknown type", edgeType: "call", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonValue, _ := json.Marshal(models.Edge{From: "v1", To: "v2", Type: tt.edgeType})
+			req, _ := http.NewRequest("POST", "/api/edges", bytes.NewBuffer(jsonValue))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status code %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.expectedStatus == http.StatusCreated {
+				var response models.Edge
+				json.Unmarshal(w.Body.Bytes(), &response)
+				if response.Type != tt.expectedType {
+					t.Errorf("Expected type %q, got %q", tt.expectedType, response.Type)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateEdge_WithoutID_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "v1", Name: "Vertex 1"})
+	s.CreateVertex(&models.Vertex{ID: "v2", Name: "Vertex 2"})
+
+	req, _ := http.NewRequest("POST", "/api/edges", bytes.NewBufferString(`{"from": "v1", "to": "v2", "type": "calls"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var response models.Edge
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	if response.ID == "" {
+		t.Fatal("Expected generated ID")
+	}
+	if _, err := s.GetEdgeByID(response.ID); err != nil {
+		t.Errorf("Expected edge %s to be stored, got %v", response.ID, err)
+	}
+}
+
+func TestPatchEdge_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "v1", Name: "Vertex 1"})
+	s.CreateVertex(&models.Vertex{ID: "v2", Name: "Vertex 2"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "v1", To: "v2", Type: "calls"})
+
+	req, _ := http.NewRequest("PATCH", "/api/edges/e1", bytes.NewBufferString(`{"type": "requires"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("If-Match", `"1"`)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	edge, _ := s.GetEdgeByID("e1")
+	if edge.Type != "requires" || edge.From != "v1" || edge.To != "v2" {
+		t.Errorf("Unexpected edge after patch: %+v", edge)
+	}
+
+	// Reguły storage obowiązują również dla PATCH
+	req, _ = http.NewRequest("PATCH", "/api/edges/e1", bytes.NewBufferString(`[{"op": "replace", "path": "/to", "value": "missing"}]`))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), `"code":"ENDPOINT_NOT_FOUND"`) {
+		t.Errorf("Expected status code %d with ENDPOINT_NOT_FOUND, got %d. Body: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
 
+func TestCreateEdge_Latency_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "v1", Name: "Vertex 1"})
+	s.CreateVertex(&models.Vertex{ID: "v2", Name: "Vertex 2"})
+
+	req, _ := http.NewRequest("POST", "/api/edges", bytes.NewBufferString(`{"from": "v1", "to": "v2", "type": "calls", "latency_ms": -1}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+
+	req, _ = http.NewRequest("POST", "/api/edges", bytes.NewBufferString(`{"from": "v1", "to": "v2", "type": "calls", "latency_ms": 12.5}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var response models.Edge
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if w.Code != http.StatusCreated || response.LatencyMs != 12.5 {
+		t.Errorf("Expected edge with latency 12.5ms, got %d %+v", w.Code, response)
+	}
+}
+
+// failingStorage zwraca błąd bazy przy zapisie relacji
+type failingStorage struct {
+	storage.Storage
+}
+
+func (s *failingStorage) CreateEdge(edge *models.Edge) error {
+	return errors.New("pq: connection refused")
+}
+
+func TestCreateEdge_Errors_Integration(t *testing.T) {
+	_, s := setupTestRouter()
+	s.CreateVertex(&models.Vertex{ID: "v1", Name: "Vertex 1"})
+	s.CreateVertex(&models.Vertex{ID: "v2", Name: "Vertex 2"})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/edges", handlers.NewEdgeHandler(s).CreateEdge)
+	failing := gin.New()
+	failing.POST("/api/edges", handlers.NewEdgeHandler(&failingStorage{Storage: s}).CreateEdge)
+
+	post := func(r *gin.Engine, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/api/edges", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Błędne dane wejściowe mają własny kod
+	w := post(r, `{"from": "v1", "to": "v2", "type": "no_such_type"}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "unknown edge type") {
+		t.Errorf("Expected 400 for an unknown edge type, got %d %s", w.Code, w.Body.String())
+	}
+
+	// Ta sama relacja nie może powstać dwa razy
+	if w := post(r, `{"from": "v1", "to": "v2", "type": "calls"}`); w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d %s", w.Code, w.Body.String())
+	}
+	w = post(r, `{"from": "v1", "to": "v2", "type": "Calls"}`)
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "DUPLICATE_EDGE") {
+		t.Errorf("Expected 409 DUPLICATE_EDGE for a duplicate edge, got %d %s", w.Code, w.Body.String())
+	}
+
+	// Błąd bazy to 500 bez szczegółów
+	w = post(failing, `{"from": "v2", "to": "v1", "type": "calls"}`)
+	if w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), "pq:") {
+		t.Errorf("Expected a generic 500 for a database error, got %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+
+	"microservice_overview/models"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EdgeTypeHandler obsługuje żądania związane z katalogiem typów relacji
+type EdgeTypeHandler struct {
+	storage storage.Storage
+}
+
+// NewEdgeTypeHandler tworzy nowy EdgeTypeHandler
+func NewEdgeTypeHandler(s storage.Storage) *EdgeTypeHandler {
+	return &EdgeTypeHandler{storage: s}
+}
+
+// GetAllEdgeTypes zwraca katalog typów relacji
+func (h *EdgeTypeHandler) GetAllEdgeTypes(c *gin.Context) {
+	edgeTypes, err := h.storage.GetAllEdgeTypes()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, edgeTypes)
+}
+
+// GetEdgeType zwraca typ relacji po nazwie
+func (h *EdgeTypeHandler) GetEdgeType(c *gin.Context) {
+	edgeType, err := h.storage.GetEdgeType(c.Param("name"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, edgeType)
+}
+
+// CreateEdgeType dodaje typ relacji do katalogu
+func (h *EdgeTypeHandler) CreateEdgeType(c *gin.Context) {
+	var edgeType models.EdgeType
+	if err := c.ShouldBindJSON(&edgeType); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if edgeType.Name == "" {
+		respondProblem(c, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	if err := h.storage.CreateEdgeType(&edgeType); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, edgeType)
+}
+
+// UpdateEdgeType aktualizuje typ relacji (nazwa jest niezmienna)
+func (h *EdgeTypeHandler) UpdateEdgeType(c *gin.Context) {
+	current, err := h.storage.GetEdgeType(c.Param("name"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	var edgeType models.EdgeType
+	if err := c.ShouldBindJSON(&edgeType); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	edgeType.Name = current.Name
+
+	if err := h.storage.UpdateEdgeType(&edgeType); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, edgeType)
+}
+
+// DeleteEdgeType usuwa typ relacji, jeśli żadna relacja go nie używa
+func (h *EdgeTypeHandler) DeleteEdgeType(c *gin.Context) {
+	current, err := h.storage.GetEdgeType(c.Param("name"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := h.storage.DeleteEdgeType(current.Name); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "edge type deleted"})
+}
@@ -0,0 +1,185 @@
+package edge_type_integration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"microservice_overview/handlers"
+	"microservice_overview/models"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupTestRouter() (*gin.Engine, storage.Storage) {
+	gin.SetMode(gin.TestMode)
+
+	// Ustaw tryb developerski dla testów
+	os.Setenv("DEV_MODE", "true")
+
+	// Utwórz storage z bazą w pamięci
+	s, err := storage.NewStorage()
+	if err != nil {
+		os.Unsetenv("DEV_MODE")
+		panic("failed to create storage: " + err.Error())
+	}
+
+	// Utwórz router
+	r := gin.New()
+	edgeTypeHandler := handlers.NewEdgeTypeHandler(s)
+
+	api := r.Group("/api")
+	{
+		api.GET("/edge-types", edgeTypeHandler.GetAllEdgeTypes)
+		api.GET("/edge-types/:name", edgeTypeHandler.GetEdgeType)
+		api.POST("/edge-types", edgeTypeHandler.CreateEdgeType)
+		api.PUT("/edge-types/:name", edgeTypeHandler.UpdateEdgeType)
+		api.DELETE("/edge-types/:name", edgeTypeHandler.DeleteEdgeType)
+	}
+
+	return r, s
+}
+
+func TestGetAllEdgeTypes_DefaultCatalogue_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("GET", "/api/edge-types", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var edgeTypes []models.EdgeType
+	json.Unmarshal(w.Body.Bytes(), &edgeTypes)
+
+	byName := make(map[string]models.EdgeType)
+	for _, et := range edgeTypes {
+		byName[et.Name] = et
+	}
+	if !byName["calls"].Synchronous {
+		t.Error("Expected default calls type to be synchronous")
+	}
+	if byName["calls_async"].Synchronous {
+		t.Error("Expected default calls_async type to be asynchronous")
+	}
+}
+
+func TestCreateEdgeType_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	body := `{
+		"name": "Reads From",
+		"description": "Odczyt danych",
+		"direction": "forward",
+		"synchronous": true,
+		"color": "#8E44AD",
+		"style": "dashed",
+		"allowed_source_kinds": ["service"],
+		"allowed_target_kinds": ["database"]
+	}`
+	req, _ := http.NewRequest("POST", "/api/edge-types", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	req, _ = http.NewRequest("GET", "/api/edge-types/reads_from", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var edgeType models.EdgeType
+	json.Unmarshal(w.Body.Bytes(), &edgeType)
+
+	if edgeType.Name != "reads_from" || edgeType.Style != "dashed" {
+		t.Errorf("Unexpected edge type: %+v", edgeType)
+	}
+	if len(edgeType.AllowedTargetKinds) != 1 || edgeType.AllowedTargetKinds[0] != "database" {
+		t.Errorf("Expected allowed target kinds [database], got %v", edgeType.AllowedTargetKinds)
+	}
+}
+
+func TestCreateEdgeType_Validation_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "missing name", body: `{"description": "x"}`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid name", body: `{"name": "1st!"}`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid direction", body: `{"name": "x", "direction": "sideways"}`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid style", body: `{"name": "x", "style": "wavy"}`, expectedStatus: http.StatusBadRequest},
+		{name: "duplicate", body: `{"name": "Calls"}`, expectedStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/api/edge-types", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpdateEdgeType_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	req, _ := http.NewRequest("PUT", "/api/edge-types/calls", bytes.NewBufferString(`{"description": "HTTP", "synchronous": true, "color": "#000000"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	edgeType, _ := s.GetEdgeType("calls")
+	if edgeType.Color != "#000000" || edgeType.Direction != models.DirectionForward {
+		t.Errorf("Unexpected edge type after update: %+v", edgeType)
+	}
+}
+
+func TestDeleteEdgeType_InUse_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "v1", Name: "Vertex 1"})
+	s.CreateVertex(&models.Vertex{ID: "v2", Name: "Vertex 2"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "v1", To: "v2", Type: "calls"})
+
+	req, _ := http.NewRequest("DELETE", "/api/edge-types/calls", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", http.StatusConflict, w.Code)
+	}
+
+	req, _ = http.NewRequest("DELETE", "/api/edge-types/publishes", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if _, err := s.GetEdgeType("publishes"); err == nil {
+		t.Error("Edge type should be deleted")
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"microservice_overview/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondProblem wysyła błąd z domyślnym kodem dla statusu
+func respondProblem(c *gin.Context, status int, detail string) {
+	problem.Abort(c, problem.New(status, "", detail))
+}
+
+// respondError wysyła błąd jako problem+json. Znane błędy dostają własny
+// status i kod, pozostałe - status fallback
+func respondError(c *gin.Context, fallback int, err error) {
+	problem.Abort(c, problemFor(fallback, err))
+}
+
+// problemFor opisuje błąd jako problem (zob. problem.FromError); przyczyna
+// błędów 5xx trafia tylko do logu
+func problemFor(fallback int, err error) *problem.Problem {
+	p := problem.FromError(fallback, err)
+	if p.Status >= http.StatusInternalServerError {
+		log.Printf("internal error (%s): %v", p.Code, err)
+	}
+	return p
+}
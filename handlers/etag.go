@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// versionETag buduje ETag z wersji rekordu
+func versionETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// contentETag buduje ETag z treści odpowiedzi
+func contentETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return strconv.Quote(hex.EncodeToString(sum[:16]))
+}
+
+// etagMatches sprawdza czy nagłówek If-Match / If-None-Match (lista tagów lub "*") zawiera etag
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		candidate = strings.TrimPrefix(candidate, "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIfMatch wymusza nagłówek If-Match zgodny z aktualną wersją rekordu.
+// Zwraca false (i wysyła odpowiedź) gdy nagłówka brakuje (428) lub wersja się nie zgadza (412).
+func checkIfMatch(c *gin.Context, version int64) bool {
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		respondProblem(c, http.StatusPreconditionRequired, "If-Match header is required")
+		return false
+	}
+	if !etagMatches(ifMatch, versionETag(version)) {
+		c.Header("ETag", versionETag(version))
+		respondProblem(c, http.StatusPreconditionFailed, "resource was modified - If-Match does not match current version")
+		return false
+	}
+	return true
+}
+
+// checkOptionalIfMatch sprawdza If-Match tylko gdy klient go przesłał
+func checkOptionalIfMatch(c *gin.Context, version int64) bool {
+	if c.GetHeader("If-Match") == "" {
+		return true
+	}
+	return checkIfMatch(c, version)
+}
+
+// respondWithETag wysyła odpowiedź JSON z ETagiem wyliczonym z treści,
+// a przy zgodnym If-None-Match zwraca 304 bez treści
+func respondWithETag(c *gin.Context, status int, obj interface{}) {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	etag := contentETag(body)
+	c.Header("ETag", etag)
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" && etagMatches(ifNoneMatch, etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(status, "application/json; charset=utf-8", body)
+}
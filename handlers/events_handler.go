@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"microservice_overview/events"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Parametry strumienia zdarzeń
+const (
+	eventStreamBuffer    = events.SubscriberBuffer // Zdarzenia buforowane dla jednego klienta
+	eventStreamHeartbeat = 15 * time.Second        // Komentarz podtrzymujący połączenie (proxy)
+	eventStreamRetryMs   = 3000                    // Czas, po którym EventSource łączy się ponownie
+)
+
+// EventsHandler udostępnia zmiany grafu jako Server-Sent Events
+// Last-Event-ID ma postać <strumień>-<numer> (zob. events.Bus.Stream)
+type EventsHandler struct {
+	bus *events.Bus
+}
+
+// NewEventsHandler tworzy nowy EventsHandler
+func NewEventsHandler(bus *events.Bus) *EventsHandler {
+	return &EventsHandler{bus: bus}
+}
+
+// StreamGraphEvents wysyła zdarzenia vertex.* i edge.* w miarę zapisów.
+// Parametr types (lista po przecinku) zawęża strumień. Po ponownym połączeniu
+// z nagłówkiem Last-Event-ID pominięte zdarzenia są dosyłane; jeśli nie da
+// się tego zrobić, klient dostaje zdarzenie reset i powinien wczytać graf od nowa
+func (h *EventsHandler) StreamGraphEvents(c *gin.Context) {
+	filter := make(map[string]bool)
+	if types := c.Query("types"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			t = strings.TrimSpace(t)
+			if !events.IsType(t) {
+				respondProblem(c, http.StatusBadRequest, fmt.Sprintf("unknown event type %q", t))
+				return
+			}
+			filter[t] = true
+		}
+	}
+
+	var sub *events.Subscription
+	var missed []events.Event
+	complete := true
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		if lastID, ok := h.parseEventID(lastEventID); ok {
+			sub, missed, complete = h.bus.SubscribeFrom(lastID, eventStreamBuffer)
+		} else {
+			sub, complete = h.bus.Subscribe(eventStreamBuffer), false
+		}
+	} else {
+		sub = h.bus.Subscribe(eventStreamBuffer)
+	}
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // nginx nie może buforować strumienia
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", eventStreamRetryMs)
+	if !complete {
+		h.writeReset(c, "missed events are no longer available")
+	}
+	for _, event := range missed {
+		if len(filter) == 0 || filter[event.Type] {
+			h.writeEvent(c, event)
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventStreamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": keep-alive\n\n")
+		case event, ok := <-sub.C:
+			if !ok {
+				h.writeReset(c, sub.Err().Error())
+				c.Writer.Flush()
+				return
+			}
+			if len(filter) > 0 && !filter[event.Type] {
+				continue
+			}
+			h.writeEvent(c, event)
+		}
+		c.Writer.Flush()
+	}
+}
+
+func (h *EventsHandler) writeEvent(c *gin.Context, event events.Event) {
+	data, _ := json.Marshal(event)
+	fmt.Fprintf(c.Writer, "id: %s-%d\nevent: %s\ndata: %s\n\n", h.bus.Stream(), event.ID, event.Type, data)
+}
+
+// writeReset informuje klienta, że musi wczytać graf od nowa
+func (h *EventsHandler) writeReset(c *gin.Context, reason string) {
+	data, _ := json.Marshal(gin.H{"reason": reason})
+	fmt.Fprintf(c.Writer, "event: reset\ndata: %s\n\n", data)
+}
+
+// parseEventID zwraca numer zdarzenia z Last-Event-ID; ok = false, gdy
+// identyfikator pochodzi z innego strumienia lub jest niepoprawny
+func (h *EventsHandler) parseEventID(id string) (int64, bool) {
+	stream, number, found := strings.Cut(id, "-")
+	if !found || stream != h.bus.Stream() {
+		return 0, false
+	}
+	lastID, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return lastID, true
+}
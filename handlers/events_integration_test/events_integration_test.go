@@ -0,0 +1,215 @@
+package events_integration_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"microservice_overview/events"
+	"microservice_overview/handlers"
+	"microservice_overview/models"
+	"microservice_overview/storage"
+	"microservice_overview/webhooks"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupTestServer rejestruje trasy API przez handlers.RegisterAPI, tak jak main.go
+func setupTestServer(t *testing.T) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+
+	// Ustaw tryb developerski dla testów
+	os.Setenv("DEV_MODE", "true")
+
+	// Utwórz storage z bazą w pamięci
+	db, err := storage.NewStorage()
+	if err != nil {
+		os.Unsetenv("DEV_MODE")
+		panic("failed to create storage: " + err.Error())
+	}
+	bus := events.NewBus()
+	s := events.NewPublishingStorage(db, bus)
+
+	// Utwórz router z tymi samymi trasami co main.go
+	r := gin.New()
+	deps := handlers.Dependencies{
+		Storage:     s,
+		Bus:         bus,
+		Dispatcher:  webhooks.NewDispatcher(s, bus),
+		Idempotency: handlers.NewIdempotency(s, time.Hour),
+	}
+	if err := handlers.RegisterAPI(r, deps, handlers.APIVersion{Name: "v1"}, handlers.APIVersion{Name: "v2"}); err != nil {
+		panic("failed to register routes: " + err.Error())
+	}
+
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// sseEvent pojedyncze zdarzenie odczytane ze strumienia
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// openStream łączy się ze strumieniem i czeka na pierwszy blok (retry),
+// po którym subskrypcja jest już aktywna
+func openStream(t *testing.T, server *httptest.Server, query, lastEventID string) (*bufio.Reader, func()) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/graph/events"+query, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		cancel()
+		t.Fatalf("Expected an event stream, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	reader := bufio.NewReader(resp.Body)
+	if line, _ := reader.ReadString('\n'); !strings.HasPrefix(line, "retry:") {
+		t.Fatalf("Expected retry first, got %q", line)
+	}
+	reader.ReadString('\n')
+	return reader, func() {
+		cancel()
+		resp.Body.Close()
+	}
+}
+
+func readEvent(t *testing.T, reader *bufio.Reader) sseEvent {
+	t.Helper()
+	var event sseEvent
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("stream ended: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "":
+			if event.Event != "" {
+				return event
+			}
+		case strings.HasPrefix(line, "id: "):
+			event.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.Data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func post(t *testing.T, server *httptest.Server, path string, body interface{}) {
+	t.Helper()
+	postExpecting(t, server, path, body, http.StatusCreated)
+}
+
+func postExpecting(t *testing.T, server *httptest.Server, path string, body interface{}, status int) {
+	t.Helper()
+	jsonValue, _ := json.Marshal(body)
+	resp, err := http.Post(server.URL+path, "application/json", bytes.NewBuffer(jsonValue))
+	if err != nil {
+		t.Fatalf("POST %s: %v", path, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != status {
+		t.Fatalf("POST %s: expected %d, got %d", path, status, resp.StatusCode)
+	}
+}
+
+func TestGraphEvents_Integration(t *testing.T) {
+	server := setupTestServer(t)
+	reader, closeStream := openStream(t, server, "", "")
+	defer closeStream()
+
+	post(t, server, "/api/vertices", models.Vertex{ID: "a", Name: "A"})
+	post(t, server, "/api/vertices", models.Vertex{ID: "b", Name: "B"})
+	post(t, server, "/api/edges", models.Edge{ID: "e1", From: "a", To: "b", Type: "calls"})
+
+	var received []sseEvent
+	for i := 0; i < 3; i++ {
+		received = append(received, readEvent(t, reader))
+	}
+	if received[0].Event != events.VertexCreated || received[2].Event != events.EdgeCreated {
+		t.Fatalf("Unexpected events %+v", received)
+	}
+	var payload events.Event
+	json.Unmarshal([]byte(received[2].Data), &payload)
+	if payload.Edge == nil || payload.Edge.From != "a" || payload.Type != events.EdgeCreated {
+		t.Errorf("Expected the created edge in the payload, got %s", received[2].Data)
+	}
+
+	// Wznowienie z Last-Event-ID dosyła pominięte zdarzenia
+	resumed, closeResumed := openStream(t, server, "", received[0].ID)
+	defer closeResumed()
+	if event := readEvent(t, resumed); event.ID != received[1].ID {
+		t.Errorf("Expected replay from %s, got %+v", received[1].ID, event)
+	}
+	if event := readEvent(t, resumed); event.ID != received[2].ID {
+		t.Errorf("Expected replay of %s, got %+v", received[2].ID, event)
+	}
+}
+
+func TestGraphEvents_FilterAndReset_Integration(t *testing.T) {
+	server := setupTestServer(t)
+
+	// Identyfikator z innego procesu - klient musi wczytać graf od nowa
+	reader, closeStream := openStream(t, server, "?types=edge.created", "someotherprocess-42")
+	defer closeStream()
+	if event := readEvent(t, reader); event.Event != "reset" {
+		t.Errorf("Expected reset, got %+v", event)
+	}
+
+	post(t, server, "/api/vertices", models.Vertex{ID: "a", Name: "A"})
+	post(t, server, "/api/vertices", models.Vertex{ID: "b", Name: "B"})
+	post(t, server, "/api/edges", models.Edge{ID: "e1", From: "a", To: "b", Type: "calls"})
+	if event := readEvent(t, reader); event.Event != events.EdgeCreated {
+		t.Errorf("Expected only edge.created, got %+v", event)
+	}
+
+	resp, err := http.Get(server.URL + "/api/graph/events?types=vertex.renamed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code %d for unknown type, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestGraphEvents_LargeBatch_Integration(t *testing.T) {
+	server := setupTestServer(t)
+	reader, closeStream := openStream(t, server, "", "")
+	defer closeStream()
+
+	// Paczka o maksymalnym rozmiarze publikuje wszystkie zdarzenia naraz -
+	// subskrybent nie może zostać rozłączony jako zbyt wolny
+	const size = 1000
+	operations := make([]map[string]interface{}, size)
+	for i := range operations {
+		operations[i] = map[string]interface{}{"op": "create", "type": "vertex", "vertex": models.Vertex{ID: fmt.Sprintf("v%d", i), Name: fmt.Sprintf("V%d", i)}}
+	}
+	postExpecting(t, server, "/api/batch", map[string]interface{}{"operations": operations}, http.StatusOK)
+
+	for i := 0; i < size; i++ {
+		if event := readEvent(t, reader); event.Event != events.VertexCreated {
+			t.Fatalf("Expected vertex.created #%d, got %+v", i, event)
+		}
+	}
+}
@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
+	"microservice_overview/analysis"
+	"microservice_overview/models"
 	"microservice_overview/storage"
 
 	"github.com/gin-gonic/gin"
@@ -18,15 +21,217 @@ func NewGraphHandler(s storage.Storage) *GraphHandler {
 	return &GraphHandler{storage: s}
 }
 
-// GetGraph zwraca pełny graf (wszystkie wierzchołki i relacje)
+// GetGraph zwraca pełny graf (wszystkie wierzchołki i relacje).
+// Obsługuje If-None-Match - niezmieniony graf zwraca 304 bez treści.
 func (h *GraphHandler) GetGraph(c *gin.Context) {
 	graph, err := h.storage.GetGraph()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, err)
 		return
 	}
-	c.JSON(http.StatusOK, graph)
+	respondWithETag(c, http.StatusOK, graph)
 }
 
+// ValidateGraph sprawdza spójność zapisanego grafu i zwraca listę naruszeń
+func (h *GraphHandler) ValidateGraph(c *gin.Context) {
+	report, err := h.storage.ValidateGraph()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// RepairGraph naprawia naruszenia spójności grafu.
+// Parametry: strategy (detach - domyślnie, delete), dry_run (true - tylko podgląd zmian)
+func (h *GraphHandler) RepairGraph(c *gin.Context) {
+	strategy := c.DefaultQuery("strategy", models.RepairStrategyDetach)
+	if strategy != models.RepairStrategyDetach && strategy != models.RepairStrategyDelete {
+		respondProblem(c, http.StatusBadRequest, "strategy must be one of: detach, delete")
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+
+	report, err := h.storage.RepairGraph(strategy, dryRun)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// dependencyGraph buduje graf zależności do analiz z aktualnego stanu bazy
+func (h *GraphHandler) dependencyGraph() (*analysis.Graph, error) {
+	graph, err := h.storage.GetGraph()
+	if err != nil {
+		return nil, err
+	}
+	edgeTypes, err := h.storage.GetAllEdgeTypes()
+	if err != nil {
+		return nil, err
+	}
+	return analysis.NewGraph(graph.Vertices, graph.Edges, edgeTypes), nil
+}
+
+// GetMetrics zwraca miary wierzchołków (fan-in/out, centralność, PageRank,
+// niestabilność, głębokość) oraz statystyki całego grafu zależności
+func (h *GraphHandler) GetMetrics(c *gin.Context) {
+	g, err := h.dependencyGraph()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, analysis.Metrics(g))
+}
+
+// GetResilience zwraca pojedyncze punkty awarii: wierzchołki (punkty
+// artykulacji) i zależności (mosty), których awaria odcina część systemu
+func (h *GraphHandler) GetResilience(c *gin.Context) {
+	g, err := h.dependencyGraph()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, analysis.Resilience(g))
+}
+
+// GetLatency zwraca najgorsze opóźnienie synchroniczne i ścieżkę krytyczną
+// od punktu wejścia. Parametry: from (ID lub slug wierzchołka, wymagany),
+// budget_ms (opcjonalny budżet opóźnienia, np. z SLO)
+func (h *GraphHandler) GetLatency(c *gin.Context) {
+	from := c.Query("from")
+	if from == "" {
+		respondProblem(c, http.StatusBadRequest, "from query parameter is required")
+		return
+	}
+	var budget *float64
+	if raw := c.Query("budget_ms"); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || value < 0 {
+			respondProblem(c, http.StatusBadRequest, "budget_ms must be a non-negative number")
+			return
+		}
+		budget = &value
+	}
+
+	entrypoint, err := h.storage.GetVertexByIDOrSlug(from)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	graph, err := h.storage.GetGraph()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	edgeTypes, err := h.storage.GetAllEdgeTypes()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
 
+	report, err := analysis.CriticalPath(graph.Vertices, graph.Edges, edgeTypes, entrypoint.ID, budget)
+	if err != nil {
+		// Cykl wywołań synchronicznych - 422 z listą wierzchołków cyklu
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// GetAvailability zwraca teoretyczną dostępność wierzchołków wynikającą z ich
+// twardych i miękkich zależności oraz listę wierzchołków z nieosiągalnym SLO.
+// Parametr from (ID lub slug) ogranicza raport do jednego punktu wejścia.
+func (h *GraphHandler) GetAvailability(c *gin.Context) {
+	var entrypoint *models.Vertex
+	if from := c.Query("from"); from != "" {
+		vertex, err := h.storage.GetVertexByIDOrSlug(from)
+		if err != nil {
+			respondError(c, http.StatusInternalServerError, err)
+			return
+		}
+		entrypoint = vertex
+	}
+
+	graph, err := h.storage.GetGraph()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	edgeTypes, err := h.storage.GetAllEdgeTypes()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	report := analysis.Availability(graph.Vertices, graph.Edges, edgeTypes)
+	if entrypoint != nil {
+		filtered := &models.AvailabilityReport{Vertices: []models.VertexAvailability{}, Unachievable: []string{}}
+		for _, item := range report.Vertices {
+			if item.ID == entrypoint.ID {
+				filtered.Vertices = append(filtered.Vertices, item)
+				if item.Unachievable {
+					filtered.Unachievable = append(filtered.Unachievable, item.ID)
+				}
+			}
+		}
+		report = filtered
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// GetOrphans zwraca wierzchołki niebiorące udziału w ruchu: liście bez relacji,
+// liście nieosiągalne z punktów wejścia i puste wierzchołki grupujące
+func (h *GraphHandler) GetOrphans(c *gin.Context) {
+	graph, err := h.storage.GetGraph()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	edgeTypes, err := h.storage.GetAllEdgeTypes()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	kinds, err := h.storage.GetAllVertexKinds()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, analysis.Orphans(graph.Vertices, graph.Edges, edgeTypes, kinds))
+}
+
+// GetNeighborhood zwraca podgraf w otoczeniu wierzchołka.
+// Parametry: vertex (ID lub slug, wymagany), radius (liczba relacji, domyślnie 1),
+// direction (out, in, both - domyślnie both). Obsługuje If-None-Match jak GetGraph.
+func (h *GraphHandler) GetNeighborhood(c *gin.Context) {
+	ref := c.Query("vertex")
+	if ref == "" {
+		respondProblem(c, http.StatusBadRequest, "vertex query parameter is required")
+		return
+	}
+	radius, err := strconv.Atoi(c.DefaultQuery("radius", "1"))
+	if err != nil || radius < 0 {
+		respondProblem(c, http.StatusBadRequest, "radius must be a non-negative integer")
+		return
+	}
+	direction := c.DefaultQuery("direction", analysis.NeighborhoodBoth)
+	switch direction {
+	case analysis.NeighborhoodOut, analysis.NeighborhoodIn, analysis.NeighborhoodBoth:
+	default:
+		respondProblem(c, http.StatusBadRequest, "direction must be one of: out, in, both")
+		return
+	}
 
+	vertex, err := h.storage.GetVertexByIDOrSlug(ref)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	graph, err := h.storage.GetGraph()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	respondWithETag(c, http.StatusOK, analysis.Neighborhood(graph, vertex.ID, radius, direction))
+}
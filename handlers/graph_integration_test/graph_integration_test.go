@@ -2,6 +2,7 @@ package graph_integration_test
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -34,6 +35,14 @@ func setupTestRouter() (*gin.Engine, storage.Storage) {
 	api := r.Group("/api")
 	{
 		api.GET("/graph", graphHandler.GetGraph)
+		api.GET("/graph/validate", graphHandler.ValidateGraph)
+		api.GET("/graph/metrics", graphHandler.GetMetrics)
+		api.GET("/graph/resilience", graphHandler.GetResilience)
+		api.GET("/graph/latency", graphHandler.GetLatency)
+		api.GET("/graph/availability", graphHandler.GetAvailability)
+		api.GET("/graph/orphans", graphHandler.GetOrphans)
+		api.GET("/graph/neighborhood", graphHandler.GetNeighborhood)
+		api.POST("/graph/repair", graphHandler.RepairGraph)
 	}
 
 	return r, s
@@ -136,7 +145,401 @@ func TestGetGraph_WithHierarchy_Integration(t *testing.T) {
 	}
 }
 
+func TestGetGraph_IfNoneMatch_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "v1", Name: "Vertex 1"})
+
+	req, _ := http.NewRequest("GET", "/api/graph", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected ETag header")
+	}
+
+	// Niezmieniony graf - 304 bez treści
+	req, _ = http.NewRequest("GET", "/api/graph", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotModified, w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected empty body, got %s", w.Body.String())
+	}
+
+	// Po zmianie grafu ETag się zmienia
+	s.CreateVertex(&models.Vertex{ID: "v2", Name: "Vertex 2"})
+
+	req, _ = http.NewRequest("GET", "/api/graph", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if w.Header().Get("ETag") == etag {
+		t.Error("Expected ETag to change after graph modification")
+	}
+}
+
+func TestValidateGraph_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	// Wierzchołka z relacjami nie da się usunąć, więc graf pozostaje spójny
+	s.CreateVertex(&models.Vertex{ID: "v1", Name: "Vertex 1"})
+	s.CreateVertex(&models.Vertex{ID: "v2", Name: "Vertex 2"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "v1", To: "v2", Type: "calls"})
+	if err := s.DeleteVertex("v2", 0); !errors.Is(err, storage.ErrVertexInUse) {
+		t.Fatalf("Expected ErrVertexInUse, got %v", err)
+	}
+
+	req, _ := http.NewRequest("GET", "/api/graph/validate", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var report models.IntegrityReport
+	json.Unmarshal(w.Body.Bytes(), &report)
+
+	if !report.Valid || len(report.Issues) != 0 {
+		t.Errorf("Expected a valid graph, got %v", report.Issues)
+	}
+}
+
+func TestRepairGraph_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "v1", Name: "Vertex 1"})
+	s.CreateVertex(&models.Vertex{ID: "v2", Name: "Vertex 2"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "v1", To: "v2", Type: "calls"})
+
+	// Naprawa spójnego grafu niczego nie zmienia (naprawę rozjechanych danych
+	// sprawdzają testy storage)
+	for _, query := range []string{"dry_run=true", "strategy=detach", "strategy=delete"} {
+		req, _ := http.NewRequest("POST", "/api/graph/repair?"+query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status code %d, got %d", query, http.StatusOK, w.Code)
+		}
+		var report models.RepairReport
+		json.Unmarshal(w.Body.Bytes(), &report)
+		if len(report.Actions) != 0 {
+			t.Errorf("%s: expected no actions, got %v", query, report.Actions)
+		}
+	}
+	if _, err := s.GetEdgeByID("e1"); err != nil {
+		t.Error("Expected the valid edge to be kept")
+	}
+}
+
+func TestRepairGraph_UnknownStrategy_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("POST", "/api/graph/repair?strategy=rebuild", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestGetGraph_LayerViolations_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	// Warstwa domeny dziedziczona po rodzicu
+	s.CreateVertex(&models.Vertex{ID: "orders-domain", Name: "Orders", Kind: "team", Layer: "domain"})
+	s.CreateVertex(&models.Vertex{ID: "gateway", Name: "Gateway", Layer: "edge"})
+	s.CreateVertex(&models.Vertex{ID: "web-bff", Name: "Web BFF", Layer: "bff"})
+	s.CreateVertex(&models.Vertex{ID: "orders", Name: "Orders", ParentID: stringPtr("orders-domain")})
+	s.CreateVertex(&models.Vertex{ID: "orders-db", Name: "Orders DB", Kind: "database", Layer: "data", Metadata: models.JSONMap{"engine": "postgres"}})
+
+	s.CreateEdge(&models.Edge{ID: "ok-1", From: "gateway", To: "web-bff", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "ok-2", From: "web-bff", To: "orders", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "upward", From: "orders", To: "web-bff", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "skip", From: "gateway", To: "orders-db", Type: "calls"})
+
+	req, _ := http.NewRequest("GET", "/api/graph", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var graph models.Graph
+	json.Unmarshal(w.Body.Bytes(), &graph)
+
+	if len(graph.LayerViolations) != 2 {
+		t.Fatalf("Expected 2 layer violations, got %+v", graph.LayerViolations)
+	}
+	byEdge := make(map[string]models.LayerViolation)
+	for _, v := range graph.LayerViolations {
+		byEdge[v.EdgeID] = v
+	}
+	if byEdge["upward"].Type != models.LayerViolationUpward || byEdge["upward"].FromLayer != "domain" {
+		t.Errorf("Unexpected upward violation: %+v", byEdge["upward"])
+	}
+	if byEdge["skip"].Type != models.LayerViolationSkip {
+		t.Errorf("Unexpected skip violation: %+v", byEdge["skip"])
+	}
+}
+
+func TestGetGraphMetrics_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	// gateway -> orders -> payments, gateway -> users -> payments; grupa "shop" nie jest liczona
+	s.CreateVertex(&models.Vertex{ID: "shop", Name: "Shop", Kind: "team"})
+	for _, id := range []string{"gateway", "orders", "users", "payments"} {
+		s.CreateVertex(&models.Vertex{ID: id, Name: id, ParentID: stringPtr("shop")})
+	}
+	s.CreateVertex(&models.Vertex{ID: "isolated", Name: "Isolated"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "gateway", To: "orders", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "e2", From: "gateway", To: "users", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "e3", From: "orders", To: "payments", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "e4", From: "users", To: "payments", Type: "calls"})
+
+	req, _ := http.NewRequest("GET", "/api/graph/metrics", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var metrics models.GraphMetrics
+	json.Unmarshal(w.Body.Bytes(), &metrics)
+
+	if metrics.Stats.Vertices != 5 || metrics.Stats.Dependencies != 4 || metrics.Stats.Components != 2 || metrics.Stats.LongestPath != 2 {
+		t.Errorf("Unexpected stats: %+v", metrics.Stats)
+	}
+
+	byID := make(map[string]models.VertexMetrics)
+	for _, v := range metrics.Vertices {
+		byID[v.ID] = v
+	}
+	payments := byID["payments"]
+	if payments.FanIn != 2 || payments.FanOut != 0 || payments.Instability != 0 || payments.Depth != 2 {
+		t.Errorf("Unexpected payments metrics: %+v", payments)
+	}
+	if gateway := byID["gateway"]; gateway.Instability != 1 || gateway.Depth != 0 {
+		t.Errorf("Unexpected gateway metrics: %+v", gateway)
+	}
+	if byID["payments"].PageRank <= byID["gateway"].PageRank {
+		t.Errorf("Expected payments to have higher PageRank than gateway, got %+v", metrics.Vertices)
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
 
+func TestGetGraphResilience_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	// gateway -> orders -> payments; bez orders płatności są odcięte
+	for _, id := range []string{"gateway", "orders", "payments"} {
+		s.CreateVertex(&models.Vertex{ID: id, Name: id})
+	}
+	s.CreateEdge(&models.Edge{ID: "e1", From: "gateway", To: "orders", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "e2", From: "orders", To: "payments", Type: "calls"})
+
+	req, _ := http.NewRequest("GET", "/api/graph/resilience", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var report models.ResilienceReport
+	json.Unmarshal(w.Body.Bytes(), &report)
+
+	if len(report.ArticulationPoints) != 1 || report.ArticulationPoints[0].ID != "orders" || report.ArticulationPoints[0].Unreachable != 1 {
+		t.Errorf("Expected orders as single articulation point, got %+v", report.ArticulationPoints)
+	}
+	if len(report.Bridges) != 2 {
+		t.Errorf("Expected 2 bridges, got %+v", report.Bridges)
+	}
+	for _, b := range report.Bridges {
+		if len(b.EdgeIDs) != 1 {
+			t.Errorf("Expected bridge to reference its edge, got %+v", b)
+		}
+	}
+}
+
+func TestGetGraphLatency_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "gateway", Name: "Gateway", ProcessingMs: 5})
+	s.CreateVertex(&models.Vertex{ID: "orders", Name: "Orders", ProcessingMs: 20})
+	s.CreateVertex(&models.Vertex{ID: "payments", Name: "Payments", ProcessingMs: 50})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "gateway", To: "orders", Type: "calls", LatencyMs: 2})
+	s.CreateEdge(&models.Edge{ID: "e2", From: "orders", To: "payments", Type: "calls", LatencyMs: 3})
+
+	req, _ := http.NewRequest("GET", "/api/graph/latency?from=gateway&budget_ms=100", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var report models.LatencyReport
+	json.Unmarshal(w.Body.Bytes(), &report)
+
+	if report.TotalMs != 80 || len(report.CriticalPath) != 3 || report.WithinBudget == nil || !*report.WithinBudget || *report.RemainingMs != 20 {
+		t.Errorf("Unexpected latency report: %+v", report)
+	}
+}
+
+func TestGetGraphLatency_Errors_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "a", Name: "A"})
+	s.CreateVertex(&models.Vertex{ID: "b", Name: "B"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "a", To: "b", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "e2", From: "b", To: "a", Type: "calls"})
+
+	cases := map[string]int{
+		"/api/graph/latency":                      http.StatusBadRequest,
+		"/api/graph/latency?from=a&budget_ms=abc": http.StatusBadRequest,
+		"/api/graph/latency?from=missing":         http.StatusNotFound,
+		"/api/graph/latency?from=a":               http.StatusUnprocessableEntity,
+	}
+	for url, expected := range cases {
+		req, _ := http.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != expected {
+			t.Errorf("%s: expected status code %d, got %d", url, expected, w.Code)
+		}
+	}
+}
+
+func TestGetGraphAvailability_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "gateway", Name: "Gateway", AvailabilitySLO: 99.99})
+	s.CreateVertex(&models.Vertex{ID: "orders", Name: "Orders", AvailabilitySLO: 99.9})
+	s.CreateVertex(&models.Vertex{ID: "mailer", Name: "Mailer", AvailabilitySLO: 95})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "gateway", To: "orders", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "e2", From: "orders", To: "mailer", Type: "calls_async"})
+
+	req, _ := http.NewRequest("GET", "/api/graph/availability?from=gateway", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var report models.AvailabilityReport
+	json.Unmarshal(w.Body.Bytes(), &report)
+
+	if len(report.Vertices) != 1 {
+		t.Fatalf("Expected report for entrypoint only, got %+v", report.Vertices)
+	}
+	gateway := report.Vertices[0]
+	if gateway.Achievable != 99.9 || !gateway.Unachievable || len(gateway.SoftDependencies) != 1 {
+		t.Errorf("Unexpected gateway availability: %+v", gateway)
+	}
+
+	req, _ = http.NewRequest("GET", "/api/graph/availability?from=missing", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestGetGraphOrphans_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "gateway", Name: "Gateway", Entrypoint: true})
+	s.CreateVertex(&models.Vertex{ID: "orders", Name: "Orders"})
+	s.CreateVertex(&models.Vertex{ID: "zombie", Name: "Zombie"})
+	s.CreateVertex(&models.Vertex{ID: "legacy", Name: "Legacy"})
+	s.CreateVertex(&models.Vertex{ID: "legacy-worker", Name: "Legacy worker"})
+	s.CreateVertex(&models.Vertex{ID: "old-team", Name: "Old team", Kind: "team"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "gateway", To: "orders", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "e2", From: "legacy", To: "legacy-worker", Type: "calls"})
+
+	req, _ := http.NewRequest("GET", "/api/graph/orphans", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var report models.OrphanReport
+	json.Unmarshal(w.Body.Bytes(), &report)
+
+	if len(report.Isolated) != 1 || report.Isolated[0].ID != "zombie" {
+		t.Errorf("Expected zombie isolated, got %+v", report.Isolated)
+	}
+	if len(report.Unreachable) != 2 {
+		t.Errorf("Expected legacy services unreachable, got %+v", report.Unreachable)
+	}
+	if len(report.EmptyContainers) != 1 || report.EmptyContainers[0].ID != "old-team" {
+		t.Errorf("Expected old-team as empty container, got %+v", report.EmptyContainers)
+	}
+}
+
+func TestGetGraphNeighborhood_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "shop", Name: "Shop", Kind: "team"})
+	s.CreateVertex(&models.Vertex{ID: "orders", Name: "Orders", ParentID: stringPtr("shop")})
+	for _, id := range []string{"gateway", "payments", "bank"} {
+		s.CreateVertex(&models.Vertex{ID: id, Name: id})
+	}
+	s.CreateEdge(&models.Edge{ID: "e1", From: "gateway", To: "orders", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "e2", From: "orders", To: "payments", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "e3", From: "payments", To: "bank", Type: "calls"})
+
+	req, _ := http.NewRequest("GET", "/api/graph/neighborhood?vertex=orders&radius=1&direction=out", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w.Header().Get("ETag") == "" {
+		t.Error("Expected ETag header")
+	}
+
+	var graph models.Graph
+	json.Unmarshal(w.Body.Bytes(), &graph)
+
+	// orders, payments oraz przodek shop
+	if len(graph.Vertices) != 3 || len(graph.Edges) != 1 || graph.Edges[0].ID != "e2" {
+		t.Errorf("Unexpected neighborhood: %+v", graph)
+	}
+
+	cases := map[string]int{
+		"/api/graph/neighborhood":                                 http.StatusBadRequest,
+		"/api/graph/neighborhood?vertex=orders&radius=-1":         http.StatusBadRequest,
+		"/api/graph/neighborhood?vertex=orders&direction=sideway": http.StatusBadRequest,
+		"/api/graph/neighborhood?vertex=missing":                  http.StatusNotFound,
+	}
+	for url, expected := range cases {
+		req, _ := http.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != expected {
+			t.Errorf("%s: expected status code %d, got %d", url, expected, w.Code)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"microservice_overview/gql"
+	"microservice_overview/problem"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQLHandler obsługuje zapytania i mutacje GraphQL
+type GraphQLHandler struct {
+	server *gql.Server
+}
+
+// NewGraphQLHandler tworzy nowy GraphQLHandler
+func NewGraphQLHandler(s storage.Storage) (*GraphQLHandler, error) {
+	server, err := gql.NewServer(s)
+	if err != nil {
+		return nil, err
+	}
+	return &GraphQLHandler{server: server}, nil
+}
+
+// graphQLRequest treść żądania POST /api/graphql
+type graphQLRequest struct {
+	Query         string                 `json:"query" binding:"required"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// Execute wykonuje dokument GraphQL; błędy wykonania trafiają do pola
+// errors odpowiedzi (status 200), zgodnie z konwencją GraphQL over HTTP.
+// Zapytania przekraczające limity (zob. gql.Server.Check) nie są wykonywane
+func (h *GraphQLHandler) Execute(c *gin.Context) {
+	var req graphQLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.server.Check(req.Query); err != nil {
+		problem.Abort(c, problem.New(http.StatusBadRequest, problem.CodeQueryTooComplex, err.Error()))
+		return
+	}
+
+	result := h.server.Do(c.Request.Context(), req.Query, req.Variables, req.OperationName)
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,165 @@
+package graphql_integration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"microservice_overview/handlers"
+	"microservice_overview/models"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupTestRouter() (*gin.Engine, storage.Storage) {
+	gin.SetMode(gin.TestMode)
+
+	// Ustaw tryb developerski dla testów
+	os.Setenv("DEV_MODE", "true")
+
+	// Utwórz storage z bazą w pamięci
+	s, err := storage.NewStorage()
+	if err != nil {
+		os.Unsetenv("DEV_MODE")
+		panic("failed to create storage: " + err.Error())
+	}
+
+	// Utwórz router
+	r := gin.New()
+	graphQLHandler, err := handlers.NewGraphQLHandler(s)
+	if err != nil {
+		panic("failed to build GraphQL schema: " + err.Error())
+	}
+
+	api := r.Group("/api")
+	{
+		api.POST("/graphql", graphQLHandler.Execute)
+	}
+
+	return r, s
+}
+
+type graphQLResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func postGraphQL(r *gin.Engine, query string, variables map[string]interface{}) (*httptest.ResponseRecorder, graphQLResponse) {
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	req, _ := http.NewRequest("POST", "/api/graphql", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var response graphQLResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	return w, response
+}
+
+func TestGraphQLQuery_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "payments", Name: "Payments", Kind: "team"})
+	s.CreateVertex(&models.Vertex{ID: "checkout", Name: "Checkout", ParentID: stringPtr("payments")})
+	s.CreateVertex(&models.Vertex{ID: "ledger", Name: "Ledger", ParentID: stringPtr("payments")})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "checkout", To: "ledger", Type: "calls"})
+
+	w, response := postGraphQL(r, `query Team($id: ID!) {
+		vertex(id: $id) { name children { slug outgoing(type: "calls") { target { name } } } }
+	}`, map[string]interface{}{"id": "payments"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if len(response.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %+v", response.Errors)
+	}
+
+	vertex := response.Data["vertex"].(map[string]interface{})
+	children := vertex["children"].([]interface{})
+	if vertex["name"] != "Payments" || len(children) != 2 {
+		t.Fatalf("Expected Payments with 2 children, got %v", vertex)
+	}
+	checkout := children[0].(map[string]interface{})
+	outgoing := checkout["outgoing"].([]interface{})
+	if checkout["slug"] != "checkout" || len(outgoing) != 1 {
+		t.Errorf("Expected checkout to call ledger, got %v", checkout)
+	}
+}
+
+func TestGraphQLMutation_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "a", Name: "A"})
+	s.CreateVertex(&models.Vertex{ID: "b", Name: "B"})
+
+	w, response := postGraphQL(r, `mutation {
+		create_edge(input: {from: "a", to: "b", type: "calls", latency_ms: 12}) { id version latency_ms }
+	}`, nil)
+	if w.Code != http.StatusOK || len(response.Errors) > 0 {
+		t.Fatalf("Expected edge to be created, got %d: %s", w.Code, w.Body.String())
+	}
+	edge := response.Data["create_edge"].(map[string]interface{})
+	if edge["id"] == "" || edge["latency_ms"] != 12.0 {
+		t.Errorf("Expected created edge, got %v", edge)
+	}
+
+	// Walidacja storage obowiązuje także w GraphQL
+	_, response = postGraphQL(r, `mutation {
+		create_edge(input: {from: "a", to: "b", type: "calls", latency_ms: -1}) { id }
+	}`, nil)
+	if len(response.Errors) != 1 || response.Errors[0].Extensions["code"] != "BAD_USER_INPUT" {
+		t.Errorf("Expected BAD_USER_INPUT for negative latency, got %+v", response.Errors)
+	}
+
+	_, response = postGraphQL(r, `mutation {
+		delete_vertex(id: "missing")
+	}`, nil)
+	if len(response.Errors) != 1 || response.Errors[0].Extensions["code"] != "NOT_FOUND" {
+		t.Errorf("Expected NOT_FOUND for missing vertex, got %+v", response.Errors)
+	}
+}
+
+func TestGraphQL_BadRequest_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("POST", "/api/graphql", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d for missing query, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	w, response := postGraphQL(r, `{ vertex(id: "x") { no_such_field } }`, nil)
+	if w.Code != http.StatusOK || len(response.Errors) == 0 {
+		t.Errorf("Expected validation errors in a 200 response, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGraphQL_TooComplex_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+	s.CreateVertex(&models.Vertex{ID: "a", Name: "A"})
+
+	query := "{ vertex(id: \"a\") { " + strings.Repeat("parent { ", 12) + "id" + strings.Repeat(" }", 12) + " } }"
+	w, _ := postGraphQL(r, query, nil)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status code %d for a too deep query, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+	var problem map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &problem)
+	if problem["code"] != "QUERY_TOO_COMPLEX" || w.Header().Get("Content-Type") != "application/problem+json" {
+		t.Errorf("Expected a QUERY_TOO_COMPLEX problem, got %s %s", w.Header().Get("Content-Type"), w.Body.String())
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
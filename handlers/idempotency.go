@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"microservice_overview/models"
+	"microservice_overview/openapi"
+	"microservice_overview/problem"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Nagłówki idempotencji
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed" // "true" w odpowiedzi odtworzonej z zapisu
+)
+
+const (
+	maxIdempotencyKeyLength   = 255
+	defaultIdempotencyTTL     = 24 * time.Hour  // Okno powtórzeń bez IDEMPOTENCY_TTL
+	idempotencyPurgeInterval  = time.Hour       // Co ile Run usuwa przeterminowane rekordy
+	idempotencyLease          = 5 * time.Minute // Jak długo żądanie w toku blokuje klucz
+	idempotencyReserveRetries = 2
+)
+
+// idempotentMethods metody, dla których honorowany jest Idempotency-Key
+var idempotentMethods = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodDelete: true,
+}
+
+// idempotencyKeyParam opis nagłówka w specyfikacji OpenAPI
+var idempotencyKeyParam = openapi.Param{
+	Name: IdempotencyKeyHeader,
+	Description: "Unikalny klucz żądania (do 255 znaków). Ponowienie z tym samym kluczem w oknie IDEMPOTENCY_TTL " +
+		"dostaje zapisaną odpowiedź z nagłówkiem Idempotent-Replayed: true; klucz użyty z innym żądaniem - 422 " +
+		"IDEMPOTENCY_KEY_REUSED, a w trakcie obsługi pierwszego żądania - 409 IDEMPOTENCY_KEY_IN_USE",
+}
+
+// errIdempotencyKeyContended klucz jest jednocześnie zwalniany i rezerwowany przez inne żądania
+var errIdempotencyKeyContended = errors.New("idempotency key is being reserved by another request")
+
+// Idempotency middleware zapamiętujący pierwszą odpowiedź na żądanie z
+// nagłówkiem Idempotency-Key i odtwarzający ją przy ponowieniach w oknie ttl.
+// Żądania bez nagłówka przechodzą bez zmian
+type Idempotency struct {
+	storage storage.Storage
+	ttl     time.Duration
+}
+
+// NewIdempotency tworzy middleware z oknem powtórzeń ttl
+func NewIdempotency(s storage.Storage, ttl time.Duration) *Idempotency {
+	return &Idempotency{storage: s, ttl: ttl}
+}
+
+// LoadIdempotencyTTL wczytuje okno powtórzeń ze zmiennej IDEMPOTENCY_TTL
+// (czas w formacie Go, np. 24h lub 90m); domyślnie 24 godziny
+func LoadIdempotencyTTL() (time.Duration, error) {
+	value := os.Getenv("IDEMPOTENCY_TTL")
+	if value == "" {
+		return defaultIdempotencyTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("IDEMPOTENCY_TTL must be a positive duration (e.g. 24h), got %q", value)
+	}
+	return ttl, nil
+}
+
+// Handle rezerwuje klucz przed obsługą żądania i zapisuje odpowiedź po niej.
+// Ponowienie z tym samym kluczem dostaje zapisaną odpowiedź, a użycie klucza
+// z innym żądaniem - błąd 422. Odpowiedzi 5xx nie są zapamiętywane, więc
+// ponowienie po błędzie serwera wykonuje żądanie jeszcze raz
+func (i *Idempotency) Handle(c *gin.Context) {
+	key := c.GetHeader(IdempotencyKeyHeader)
+	if key == "" || !idempotentMethods[c.Request.Method] {
+		return
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		respondProblem(c, http.StatusBadRequest, fmt.Sprintf("%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength))
+		return
+	}
+
+	fingerprint, err := requestFingerprint(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	record := &models.IdempotencyRecord{Key: key, Fingerprint: fingerprint}
+	existing, err := i.reserve(record)
+	token := record.Token
+	switch {
+	case errors.Is(err, errIdempotencyKeyContended):
+		problem.Abort(c, problem.New(http.StatusConflict, problem.CodeIdempotencyInFlight, err.Error()))
+		return
+	case err != nil:
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	case existing != nil:
+		i.replay(c, existing, fingerprint)
+		return
+	}
+
+	// Klucz jest zarezerwowany - zwolnij go, jeśli obsługa się nie powiedzie
+	// (5xx albo panika), żeby ponowienie mogło wykonać żądanie od nowa
+	completed := false
+	defer func() {
+		if !completed {
+			if err := i.storage.DeleteIdempotencyRecord(key, token); err != nil && !errors.Is(err, storage.ErrNotFound) {
+				log.Printf("idempotency: failed to release key %s: %v", key, err)
+			}
+		}
+	}()
+
+	writer := &recordingWriter{ResponseWriter: c.Writer}
+	c.Writer = writer
+	c.Next()
+
+	status := writer.Status()
+	if status >= http.StatusInternalServerError {
+		return
+	}
+	record.Status = status
+	record.ContentType = writer.Header().Get("Content-Type")
+	record.ETag = writer.Header().Get("ETag")
+	record.Body = writer.body.Bytes()
+	if err := i.storage.CompleteIdempotencyRecord(record); err != nil {
+		// Odpowiedź już wysłano - ponowienie wykona żądanie jeszcze raz
+		log.Printf("idempotency: failed to store response for key %s: %v", key, err)
+		return
+	}
+	completed = true
+}
+
+// reserve zapisuje rekord w toku pod kluczem. Zwraca rekord istniejący w
+// oknie ttl albo nil, jeśli klucz został zarezerwowany dla tego żądania.
+// Rezerwację, która straciła dzierżawę, przejmuje
+func (i *Idempotency) reserve(record *models.IdempotencyRecord) (*models.IdempotencyRecord, error) {
+	for attempt := 0; attempt < idempotencyReserveRetries; attempt++ {
+		token, err := newReservationToken()
+		if err != nil {
+			return nil, err
+		}
+		record.Token, record.LockedUntil = token, time.Now().Add(idempotencyLease)
+		err = i.storage.CreateIdempotencyRecord(record)
+		if !errors.Is(err, storage.ErrDuplicateID) {
+			return nil, err
+		}
+
+		existing, err := i.storage.GetIdempotencyRecord(record.Key)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			// Klucz zwolniono w międzyczasie - spróbuj ponownie
+		case err != nil:
+			return nil, err
+		case !i.expired(existing) && !existing.Abandoned(time.Now()):
+			return existing, nil
+		default:
+			// Rekord sprzed okna nie jest odtwarzany, a porzuconej rezerwacji
+			// nikt nie dokończy - klucz można użyć ponownie
+			if err := i.storage.DeleteIdempotencyRecord(record.Key, existing.Token); err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return nil, err
+			}
+		}
+	}
+	return nil, errIdempotencyKeyContended
+}
+
+// newReservationToken losuje identyfikator rezerwacji klucza
+func newReservationToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// replay odsyła zapisaną odpowiedź, o ile klucz użyto z tym samym żądaniem
+func (i *Idempotency) replay(c *gin.Context, record *models.IdempotencyRecord, fingerprint string) {
+	if record.Fingerprint != fingerprint {
+		problem.Abort(c, problem.New(http.StatusUnprocessableEntity, problem.CodeIdempotencyKeyReused,
+			fmt.Sprintf("%s %s was already used with a different request", IdempotencyKeyHeader, record.Key)))
+		return
+	}
+	if !record.Completed() {
+		problem.Abort(c, problem.New(http.StatusConflict, problem.CodeIdempotencyInFlight,
+			fmt.Sprintf("request with %s %s is still being processed", IdempotencyKeyHeader, record.Key)))
+		return
+	}
+
+	if record.ETag != "" {
+		c.Header("ETag", record.ETag)
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	if len(record.Body) == 0 {
+		c.AbortWithStatus(record.Status)
+		return
+	}
+	c.Data(record.Status, record.ContentType, record.Body)
+	c.Abort()
+}
+
+// expired mówi czy rekord powstał przed oknem powtórzeń
+func (i *Idempotency) expired(record *models.IdempotencyRecord) bool {
+	return record.CreatedAt.Before(time.Now().Add(-i.ttl))
+}
+
+// Purge usuwa rekordy starsze niż okno powtórzeń
+func (i *Idempotency) Purge() (int64, error) {
+	return i.storage.DeleteIdempotencyRecordsBefore(time.Now().Add(-i.ttl))
+}
+
+// Run co godzinę usuwa przeterminowane rekordy, aż do anulowania ctx
+func (i *Idempotency) Run(ctx context.Context) {
+	ticker := time.NewTicker(idempotencyPurgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := i.Purge(); err != nil {
+				log.Printf("idempotency: failed to purge expired keys: %v", err)
+			}
+		}
+	}
+}
+
+// requestFingerprint opisuje żądanie metodą, ścieżką z zapytaniem i skrótem
+// treści; treść jest przywracana dla kolejnych handlerów
+func requestFingerprint(c *gin.Context) (string, error) {
+	var body []byte
+	if c.Request.Body != nil {
+		var err error
+		if body, err = io.ReadAll(c.Request.Body); err != nil {
+			return "", err
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	sum := sha256.Sum256(body)
+	return c.Request.Method + " " + c.Request.URL.RequestURI() + " " + hex.EncodeToString(sum[:]), nil
+}
+
+// recordingWriter kopiuje treść odpowiedzi, żeby można ją było zapisać
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
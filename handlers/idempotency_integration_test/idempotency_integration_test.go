@@ -0,0 +1,283 @@
+package idempotency_integration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"microservice_overview/handlers"
+	"microservice_overview/models"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupTestRouter(ttl time.Duration) (*gin.Engine, storage.Storage, *handlers.Idempotency) {
+	gin.SetMode(gin.TestMode)
+
+	// Ustaw tryb developerski dla testów
+	os.Setenv("DEV_MODE", "true")
+
+	// Utwórz storage z bazą w pamięci
+	s, err := storage.NewStorage()
+	if err != nil {
+		os.Unsetenv("DEV_MODE")
+		panic("failed to create storage: " + err.Error())
+	}
+
+	// Utwórz router
+	r := gin.New()
+	idempotency := handlers.NewIdempotency(s, ttl)
+	vertexHandler := handlers.NewVertexHandler(s)
+
+	api := r.Group("/api", idempotency.Handle)
+	{
+		api.GET("/vertices/:id", vertexHandler.GetVertexByID)
+		api.POST("/vertices", vertexHandler.CreateVertex)
+		api.DELETE("/vertices/:id", vertexHandler.DeleteVertex)
+	}
+
+	return r, s, idempotency
+}
+
+func doRequest(r *gin.Engine, method, path, key, body string, headers ...string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func problemCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var problem struct {
+		Code string `json:"code"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &problem)
+	return problem.Code
+}
+
+func TestIdempotency_ReplaysCreate_Integration(t *testing.T) {
+	r, s, _ := setupTestRouter(time.Hour)
+	body := `{"id": "payments", "name": "Payments"}`
+
+	first := doRequest(r, "POST", "/api/vertices", "deploy-1", body)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, first.Code, first.Body.String())
+	}
+	if first.Header().Get("Idempotent-Replayed") != "" {
+		t.Error("Expected the first response not to be marked as replayed")
+	}
+
+	retry := doRequest(r, "POST", "/api/vertices", "deploy-1", body)
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Fatalf("Expected the stored response, got %d %s", retry.Code, retry.Body.String())
+	}
+	if retry.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("Expected Idempotent-Replayed: true on the retry")
+	}
+	if retry.Header().Get("ETag") != first.Header().Get("ETag") || retry.Header().Get("Content-Type") != first.Header().Get("Content-Type") {
+		t.Errorf("Expected the stored headers, got ETag %q and Content-Type %q", retry.Header().Get("ETag"), retry.Header().Get("Content-Type"))
+	}
+
+	if vertices, _ := s.GetAllVertices(); len(vertices) != 1 {
+		t.Errorf("Expected the vertex to be created once, got %d", len(vertices))
+	}
+
+	// Bez klucza ponowienie trafia do handlera jak dotąd
+	if w := doRequest(r, "POST", "/api/vertices", "", body); w.Code != http.StatusConflict || problemCode(t, w) != "DUPLICATE_ID" {
+		t.Errorf("Expected 409 DUPLICATE_ID without a key, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestIdempotency_ReplaysDelete_Integration(t *testing.T) {
+	r, _, _ := setupTestRouter(time.Hour)
+	doRequest(r, "POST", "/api/vertices", "", `{"id": "legacy", "name": "Legacy"}`)
+
+	for i := 0; i < 2; i++ {
+		w := doRequest(r, "DELETE", "/api/vertices/legacy", "cleanup-1", "", "If-Match", `"1"`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Attempt %d: expected status code %d, got %d. Body: %s", i+1, http.StatusOK, w.Code, w.Body.String())
+		}
+	}
+
+	if w := doRequest(r, "DELETE", "/api/vertices/legacy", "cleanup-2", "", "If-Match", `"1"`); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a new key, got %d", w.Code)
+	}
+}
+
+func TestIdempotency_ReplaysClientErrors_Integration(t *testing.T) {
+	r, _, _ := setupTestRouter(time.Hour)
+	body := `{"name": "Orphan", "parent_id": "missing"}`
+
+	first := doRequest(r, "POST", "/api/vertices", "orphan-1", body)
+	if first.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusUnprocessableEntity, first.Code, first.Body.String())
+	}
+
+	// Odpowiedź 4xx jest zapamiętana tak samo jak sukces
+	doRequest(r, "POST", "/api/vertices", "", `{"id": "missing", "name": "Parent"}`)
+	retry := doRequest(r, "POST", "/api/vertices", "orphan-1", body)
+	if retry.Code != first.Code || retry.Body.String() != first.Body.String() || retry.Header().Get("Content-Type") != "application/problem+json" {
+		t.Errorf("Expected the stored error, got %d %s", retry.Code, retry.Body.String())
+	}
+}
+
+func TestIdempotency_KeyReuse_Integration(t *testing.T) {
+	r, _, _ := setupTestRouter(time.Hour)
+	doRequest(r, "POST", "/api/vertices", "deploy-1", `{"id": "payments", "name": "Payments"}`)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"different body", "POST", "/api/vertices", `{"id": "orders", "name": "Orders"}`},
+		{"different path", "POST", "/api/vertices?dry_run=true", `{"id": "payments", "name": "Payments"}`},
+		{"different method", "DELETE", "/api/vertices/payments", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doRequest(r, tt.method, tt.path, "deploy-1", tt.body)
+			if w.Code != http.StatusUnprocessableEntity || problemCode(t, w) != "IDEMPOTENCY_KEY_REUSED" {
+				t.Errorf("Expected 422 IDEMPOTENCY_KEY_REUSED, got %d %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestIdempotency_InFlight_Integration(t *testing.T) {
+	r, _, idempotency := setupTestRouter(time.Hour)
+	started, release := make(chan struct{}), make(chan struct{})
+	r.POST("/slow", idempotency.Handle, func(c *gin.Context) {
+		close(started)
+		<-release
+		c.JSON(http.StatusAccepted, gin.H{"done": true})
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- doRequest(r, "POST", "/slow", "job-1", `{}`) }()
+	<-started
+
+	if w := doRequest(r, "POST", "/slow", "job-1", `{}`); w.Code != http.StatusConflict || problemCode(t, w) != "IDEMPOTENCY_KEY_IN_USE" {
+		t.Errorf("Expected 409 IDEMPOTENCY_KEY_IN_USE while the first request runs, got %d %s", w.Code, w.Body.String())
+	}
+
+	close(release)
+	if first := <-done; first.Code != http.StatusAccepted {
+		t.Fatalf("Expected the first request to finish with %d, got %d", http.StatusAccepted, first.Code)
+	}
+	if w := doRequest(r, "POST", "/slow", "job-1", `{}`); w.Code != http.StatusAccepted || w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("Expected the stored response after completion, got %d", w.Code)
+	}
+}
+
+func TestIdempotency_AbandonedReservation_Integration(t *testing.T) {
+	r, s, _ := setupTestRouter(time.Hour)
+	body := `{"id": "payments", "name": "Payments"}`
+
+	// Rezerwacja serwera, który przestał działać w trakcie obsługi żądania
+	stale := &models.IdempotencyRecord{
+		Key: "deploy-7", Fingerprint: "crashed", Token: "crashed",
+		LockedUntil: time.Now().Add(-time.Second),
+	}
+	if err := s.CreateIdempotencyRecord(stale); err != nil {
+		t.Fatalf("CreateIdempotencyRecord() error = %v", err)
+	}
+
+	// Po utracie dzierżawy ponowienie przejmuje klucz zamiast czekać na koniec okna
+	w := doRequest(r, "POST", "/api/vertices", "deploy-7", body)
+	if w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("Expected the retry to take over the key, got %d %s", w.Code, w.Body.String())
+	}
+	if w := doRequest(r, "POST", "/api/vertices", "deploy-7", body); w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("Expected the new response to be stored, got %d %s", w.Code, w.Body.String())
+	}
+
+	// Porzucony właściciel nie nadpisze ani nie zwolni przejętego klucza
+	stale.Status = http.StatusAccepted
+	if err := s.CompleteIdempotencyRecord(stale); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("Expected the abandoned reservation to be fenced off, got %v", err)
+	}
+	if err := s.DeleteIdempotencyRecord("deploy-7", stale.Token); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("Expected the abandoned reservation not to release the key, got %v", err)
+	}
+}
+
+func TestIdempotency_ServerErrorsAreNotStored_Integration(t *testing.T) {
+	r, _, idempotency := setupTestRouter(time.Hour)
+	var calls atomic.Int32
+	r.POST("/flaky", idempotency.Handle, func(c *gin.Context) {
+		if calls.Add(1) == 1 {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "try again"})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"attempt": calls.Load()})
+	})
+
+	if w := doRequest(r, "POST", "/flaky", "flaky-1", `{}`); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected the first attempt to fail, got %d", w.Code)
+	}
+	if w := doRequest(r, "POST", "/flaky", "flaky-1", `{}`); w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "" {
+		t.Errorf("Expected the retry to run the handler again, got %d", w.Code)
+	}
+	if w := doRequest(r, "POST", "/flaky", "flaky-1", `{}`); w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("Expected the successful response to be stored, got %d", w.Code)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected the handler to run twice, got %d", calls.Load())
+	}
+}
+
+func TestIdempotency_Expiry_Integration(t *testing.T) {
+	r, s, idempotency := setupTestRouter(50 * time.Millisecond)
+	body := `{"id": "payments", "name": "Payments"}`
+
+	doRequest(r, "POST", "/api/vertices", "deploy-1", body)
+	time.Sleep(100 * time.Millisecond)
+
+	// Poza oknem klucz nie jest już pamiętany - żądanie trafia do handlera
+	if w := doRequest(r, "POST", "/api/vertices", "deploy-1", body); w.Code != http.StatusConflict || problemCode(t, w) != "DUPLICATE_ID" {
+		t.Errorf("Expected the expired key to be executed again, got %d %s", w.Code, w.Body.String())
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if purged, err := idempotency.Purge(); err != nil || purged != 1 {
+		t.Errorf("Expected one expired record to be purged, got %d %v", purged, err)
+	}
+	if _, err := s.GetIdempotencyRecord("deploy-1"); err == nil {
+		t.Error("Expected the purged record to be gone")
+	}
+}
+
+func TestIdempotency_IgnoredRequests_Integration(t *testing.T) {
+	r, s, _ := setupTestRouter(time.Hour)
+	doRequest(r, "POST", "/api/vertices", "", `{"id": "payments", "name": "Payments"}`)
+
+	if w := doRequest(r, "GET", "/api/vertices/payments", "read-1", ""); w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if _, err := s.GetIdempotencyRecord("read-1"); err == nil {
+		t.Error("Expected GET requests to ignore Idempotency-Key")
+	}
+
+	w := doRequest(r, "POST", "/api/vertices", strings.Repeat("k", 256), `{"name": "Orders"}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "at most 255 characters") {
+		t.Errorf("Expected 400 for a too long key, got %d %s", w.Code, w.Body.String())
+	}
+}
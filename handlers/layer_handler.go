@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+
+	"microservice_overview/models"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LayerHandler obsługuje żądania związane z modelem warstwowym
+type LayerHandler struct {
+	storage storage.Storage
+}
+
+// NewLayerHandler tworzy nowy LayerHandler
+func NewLayerHandler(s storage.Storage) *LayerHandler {
+	return &LayerHandler{storage: s}
+}
+
+// GetAllLayers zwraca warstwy od najwyższej
+func (h *LayerHandler) GetAllLayers(c *gin.Context) {
+	layers, err := h.storage.GetAllLayers()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, layers)
+}
+
+// GetLayer zwraca warstwę po nazwie
+func (h *LayerHandler) GetLayer(c *gin.Context) {
+	layer, err := h.storage.GetLayer(c.Param("name"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, layer)
+}
+
+// GetViolations zwraca relacje łamiące model warstwowy - te same, które
+// GET /api/graph podaje w layer_violations
+func (h *LayerHandler) GetViolations(c *gin.Context) {
+	graph, err := h.storage.GetGraph()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"violations": graph.LayerViolations})
+}
+
+// CreateLayer dodaje warstwę do modelu
+func (h *LayerHandler) CreateLayer(c *gin.Context) {
+	var layer models.Layer
+	if err := c.ShouldBindJSON(&layer); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if layer.Name == "" {
+		respondProblem(c, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	if err := h.storage.CreateLayer(&layer); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, layer)
+}
+
+// UpdateLayer aktualizuje warstwę, np. jej pozycję (nazwa jest niezmienna)
+func (h *LayerHandler) UpdateLayer(c *gin.Context) {
+	current, err := h.storage.GetLayer(c.Param("name"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	var layer models.Layer
+	if err := c.ShouldBindJSON(&layer); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	layer.Name = current.Name
+
+	if err := h.storage.UpdateLayer(&layer); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, layer)
+}
+
+// DeleteLayer usuwa warstwę, jeśli nie należą do niej wierzchołki
+func (h *LayerHandler) DeleteLayer(c *gin.Context) {
+	current, err := h.storage.GetLayer(c.Param("name"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := h.storage.DeleteLayer(current.Name); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "layer deleted"})
+}
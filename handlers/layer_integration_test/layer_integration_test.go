@@ -0,0 +1,177 @@
+package layer_integration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"microservice_overview/handlers"
+	"microservice_overview/models"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupTestRouter() (*gin.Engine, storage.Storage) {
+	gin.SetMode(gin.TestMode)
+
+	// Ustaw tryb developerski dla testów
+	os.Setenv("DEV_MODE", "true")
+
+	// Utwórz storage z bazą w pamięci
+	s, err := storage.NewStorage()
+	if err != nil {
+		os.Unsetenv("DEV_MODE")
+		panic("failed to create storage: " + err.Error())
+	}
+
+	// Utwórz router
+	r := gin.New()
+	layerHandler := handlers.NewLayerHandler(s)
+	edgeHandler := handlers.NewEdgeHandler(s)
+
+	api := r.Group("/api")
+	{
+		api.GET("/layers", layerHandler.GetAllLayers)
+		api.GET("/layers/violations", layerHandler.GetViolations)
+		api.GET("/layers/:name", layerHandler.GetLayer)
+		api.POST("/layers", layerHandler.CreateLayer)
+		api.PUT("/layers/:name", layerHandler.UpdateLayer)
+		api.DELETE("/layers/:name", layerHandler.DeleteLayer)
+		api.POST("/edges", edgeHandler.CreateEdge)
+	}
+
+	return r, s
+}
+
+func TestGetAllLayers_DefaultModel_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("GET", "/api/layers", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var layers []models.Layer
+	json.Unmarshal(w.Body.Bytes(), &layers)
+
+	var names []string
+	for _, layer := range layers {
+		names = append(names, layer.Name)
+	}
+	expected := []string{"edge", "bff", "domain", "platform", "data"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected layers %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Expected layers %v, got %v", expected, names)
+			break
+		}
+	}
+}
+
+func TestCreateAndUpdateLayer_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("POST", "/api/layers", bytes.NewBufferString(`{"name": "Integration", "rank": 35}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	// Zajęta pozycja
+	req, _ = http.NewRequest("PUT", "/api/layers/integration", bytes.NewBufferString(`{"rank": 30}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+
+	req, _ = http.NewRequest("PUT", "/api/layers/integration", bytes.NewBufferString(`{"rank": 45, "description": "Integracje"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+}
+
+func TestCreateEdge_LayerEnforcement_Integration(t *testing.T) {
+	t.Setenv("ENFORCE_LAYERS", "upward")
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "gateway", Name: "Gateway", Layer: "edge"})
+	s.CreateVertex(&models.Vertex{ID: "orders", Name: "Orders", Layer: "domain"})
+
+	jsonValue, _ := json.Marshal(models.Edge{From: "orders", To: "gateway", Type: "calls"})
+	req, _ := http.NewRequest("POST", "/api/edges", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d, got %d. Body: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	}
+}
+
+func TestDeleteLayer_InUse_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "gateway", Name: "Gateway", Layer: "edge"})
+
+	req, _ := http.NewRequest("DELETE", "/api/layers/edge", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", http.StatusConflict, w.Code)
+	}
+
+	req, _ = http.NewRequest("DELETE", "/api/layers/platform", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestGetLayerViolations_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "web-bff", Name: "Web BFF", Layer: "bff"})
+	s.CreateVertex(&models.Vertex{ID: "orders", Name: "Orders", Layer: "domain"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "web-bff", To: "orders", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "e2", From: "orders", To: "web-bff", Type: "calls"})
+
+	req, _ := http.NewRequest("GET", "/api/layers/violations", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response struct {
+		Violations []models.LayerViolation `json:"violations"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	if len(response.Violations) != 1 || response.Violations[0].EdgeID != "e2" {
+		t.Errorf("Expected only the upward edge e2, got %+v", response.Violations)
+	}
+}
@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"net/http"
+
+	"microservice_overview/models"
+	"microservice_overview/openapi"
+	"microservice_overview/query"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPIHandler udostępnia specyfikację OpenAPI zbudowaną z tras routera
+type OpenAPIHandler struct {
+	spec *openapi.Spec
+}
+
+// NewOpenAPIHandler tworzy nowy OpenAPIHandler
+func NewOpenAPIHandler(spec *openapi.Spec) *OpenAPIHandler {
+	return &OpenAPIHandler{spec: spec}
+}
+
+// GetSpec zwraca dokument OpenAPI 3
+func (h *OpenAPIHandler) GetSpec(c *gin.Context) {
+	doc := h.spec.Document()
+	if doc == nil {
+		respondProblem(c, http.StatusServiceUnavailable, "API specification is not built yet")
+		return
+	}
+	c.JSON(http.StatusOK, doc)
+}
+
+// APIInfo opis API w dokumencie OpenAPI
+var APIInfo = openapi.Info{
+	Title:       "Microservice Overview API",
+	Description: "Graf zależności między mikroserwisami: wierzchołki, relacje, katalogi typów, model warstwowy, reguły architektury i analizy grafu.",
+	Version:     "1.0.0",
+}
+
+// APITags grupy operacji w kolejności wyświetlania
+var APITags = []openapi.Tag{
+	{Name: "Vertices", Description: "Wierzchołki (mikroserwisy, bazy danych, kolejki, zespoły...)"},
+	{Name: "Edges", Description: "Relacje między wierzchołkami"},
+	{Name: "Edge Types", Description: "Katalog typów relacji"},
+	{Name: "Vertex Kinds", Description: "Rejestr rodzajów wierzchołków"},
+	{Name: "Layers", Description: "Model warstwowy"},
+	{Name: "Rules", Description: "Reguły architektury"},
+	{Name: "Graph", Description: "Cały graf, analizy i spójność danych"},
+	{Name: "Query", Description: "Zapytania o wzorce i GraphQL"},
+	{Name: "Batch", Description: "Wiele zmian wierzchołków i relacji w jednej transakcji"},
+	{Name: "Webhooks", Description: "Powiadomienia o zmianach grafu"},
+	{Name: "Meta", Description: "Specyfikacja API"},
+}
+
+// Schematy treści, których nie da się wyprowadzić z typów Go
+var (
+	messageResponse = struct {
+		Message string `json:"message"`
+	}{}
+
+	mergePatchBody = &openapi.Schema{
+		Type:                 "object",
+		Description:          "JSON Merge Patch (RFC 7396): podane pola są zastępowane, null usuwa wartość",
+		AdditionalProperties: true,
+	}
+
+	jsonPatchBody = &openapi.Schema{
+		Type:        "array",
+		Description: "JSON Patch (RFC 6902)",
+		Items: &openapi.Schema{
+			Type: "object",
+			Properties: map[string]*openapi.Schema{
+				"op":    {Type: "string", Enum: []interface{}{"add", "remove", "replace", "move", "copy", "test"}},
+				"path":  {Type: "string"},
+				"from":  {Type: "string"},
+				"value": {},
+			},
+			Required: []string{"op", "path"},
+		},
+	}
+
+	graphQLResponse = &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"data":   {Type: "object", Nullable: true, AdditionalProperties: true},
+			"errors": {Type: "array", Items: &openapi.Schema{Type: "object", AdditionalProperties: true}},
+		},
+	}
+
+	eventStream = &openapi.Schema{
+		Type:        "string",
+		Description: "Strumień text/event-stream; dane zdarzenia to JSON z polami id, type, time oraz vertex lub edge",
+	}
+)
+
+// Nagłówki kontroli współbieżności
+var (
+	ifMatchRequired = openapi.Param{Name: "If-Match", Description: "ETag aktualnej wersji (wymagany - brak zwraca 428)"}
+	ifMatchOptional = openapi.Param{Name: "If-Match", Description: "ETag aktualnej wersji (opcjonalny)"}
+	ifNoneMatch     = openapi.Param{Name: "If-None-Match", Description: "ETag z poprzedniej odpowiedzi - niezmieniona treść zwraca 304"}
+)
+
+// Operations opisy operacji API według nazw handlerów. Ścieżki i metody
+// pochodzą z tras zarejestrowanych w main.go
+var Operations = map[string]openapi.Operation{
+	// Wierzchołki
+	"VertexHandler.GetAllVertices": {Tag: "Vertices", Summary: "Lista wierzchołków", Response: []models.Vertex{}},
+	"VertexHandler.GetVertexByID":  {Tag: "Vertices", Summary: "Pobierz wierzchołek po ID lub slugu", Response: models.Vertex{}, Errors: []int{404}},
+	"VertexHandler.CreateVertex": {
+		Tag: "Vertices", Summary: "Utwórz wierzchołek", Description: "Bez id - identyfikator wygeneruje serwer.",
+		Body: models.Vertex{}, BodyRequired: []string{"name"},
+		Status: http.StatusCreated, Response: models.Vertex{}, Errors: []int{409, 422},
+	},
+	"VertexHandler.UpdateVertex": {
+		Tag: "Vertices", Summary: "Zastąp wierzchołek", Headers: []openapi.Param{ifMatchRequired},
+		Body: models.Vertex{}, Response: models.Vertex{}, Errors: []int{404, 409, 412, 422, 428},
+	},
+	"VertexHandler.PatchVertex": {
+		Tag: "Vertices", Summary: "Zmień wybrane pola wierzchołka", Headers: []openapi.Param{ifMatchOptional},
+		BodyTypes: map[string]interface{}{"application/merge-patch+json": mergePatchBody, "application/json-patch+json": jsonPatchBody},
+		Response:  models.Vertex{}, Errors: []int{404, 409, 412, 415, 422},
+	},
+	"VertexHandler.DeleteVertex": {
+		Tag: "Vertices", Summary: "Usuń wierzchołek bez relacji i dzieci", Headers: []openapi.Param{ifMatchRequired},
+		Response: messageResponse, Errors: []int{404, 409, 412, 428},
+	},
+	"VertexHandler.MoveVertex": {
+		Tag: "Vertices", Summary: "Przenieś wierzchołek z poddrzewem pod nowego rodzica", Description: "parent_id null lub brak - najwyższy poziom.",
+		Headers: []openapi.Param{ifMatchOptional}, Body: moveVertexRequest{}, Response: models.Vertex{}, Errors: []int{404, 409, 412, 422},
+	},
+
+	// Relacje
+	"EdgeHandler.GetAllEdges": {Tag: "Edges", Summary: "Lista relacji", Response: []models.Edge{}},
+	"EdgeHandler.GetEdgeByID": {Tag: "Edges", Summary: "Pobierz relację po ID", Response: models.Edge{}, Errors: []int{404}},
+	"EdgeHandler.CreateEdge": {
+		Tag: "Edges", Summary: "Utwórz relację", Description: "Naruszenie reguł architektury lub modelu warstwowego zwraca 422 z listą naruszeń.",
+		Body: models.Edge{}, BodyRequired: []string{"from", "to"},
+		Status: http.StatusCreated, Response: models.Edge{}, Errors: []int{409, 422},
+	},
+	"EdgeHandler.UpdateEdge": {
+		Tag: "Edges", Summary: "Zastąp relację", Headers: []openapi.Param{ifMatchRequired},
+		Body: models.Edge{}, Response: models.Edge{}, Errors: []int{404, 409, 412, 422, 428},
+	},
+	"EdgeHandler.PatchEdge": {
+		Tag: "Edges", Summary: "Zmień wybrane pola relacji", Headers: []openapi.Param{ifMatchOptional},
+		BodyTypes: map[string]interface{}{"application/merge-patch+json": mergePatchBody, "application/json-patch+json": jsonPatchBody},
+		Response:  models.Edge{}, Errors: []int{404, 409, 412, 415, 422},
+	},
+	"EdgeHandler.DeleteEdge": {
+		Tag: "Edges", Summary: "Usuń relację", Headers: []openapi.Param{ifMatchRequired},
+		Response: messageResponse, Errors: []int{404, 412, 428},
+	},
+
+	// Typy relacji
+	"EdgeTypeHandler.GetAllEdgeTypes": {Tag: "Edge Types", Summary: "Katalog typów relacji", Response: []models.EdgeType{}},
+	"EdgeTypeHandler.GetEdgeType":     {Tag: "Edge Types", Summary: "Pobierz typ relacji", Response: models.EdgeType{}, Errors: []int{404}},
+	"EdgeTypeHandler.CreateEdgeType": {
+		Tag: "Edge Types", Summary: "Dodaj typ relacji", Body: models.EdgeType{}, BodyRequired: []string{"name"},
+		Status: http.StatusCreated, Response: models.EdgeType{}, Errors: []int{409},
+	},
+	"EdgeTypeHandler.UpdateEdgeType": {Tag: "Edge Types", Summary: "Zaktualizuj typ relacji", Body: models.EdgeType{}, Response: models.EdgeType{}, Errors: []int{404}},
+	"EdgeTypeHandler.DeleteEdgeType": {Tag: "Edge Types", Summary: "Usuń nieużywany typ relacji", Response: messageResponse, Errors: []int{404, 409}},
+
+	// Rodzaje wierzchołków
+	"VertexKindHandler.GetAllVertexKinds": {Tag: "Vertex Kinds", Summary: "Rejestr rodzajów wierzchołków", Response: []models.VertexKind{}},
+	"VertexKindHandler.GetVertexKind":     {Tag: "Vertex Kinds", Summary: "Pobierz rodzaj wierzchołka", Response: models.VertexKind{}, Errors: []int{404}},
+	"VertexKindHandler.CreateVertexKind": {
+		Tag: "Vertex Kinds", Summary: "Dodaj rodzaj wierzchołka", Body: models.VertexKind{}, BodyRequired: []string{"name"},
+		Status: http.StatusCreated, Response: models.VertexKind{}, Errors: []int{409},
+	},
+	"VertexKindHandler.UpdateVertexKind": {Tag: "Vertex Kinds", Summary: "Zaktualizuj rodzaj wierzchołka", Description: "Metadane istniejących wierzchołków rodzaju muszą pasować do nowego schematu.", Body: models.VertexKind{}, Response: models.VertexKind{}, Errors: []int{404}},
+	"VertexKindHandler.DeleteVertexKind": {Tag: "Vertex Kinds", Summary: "Usuń nieużywany rodzaj wierzchołka", Response: messageResponse, Errors: []int{404, 409}},
+
+	// Warstwy
+	"LayerHandler.GetAllLayers": {Tag: "Layers", Summary: "Warstwy od najwyższej", Response: []models.Layer{}},
+	"LayerHandler.GetLayer":     {Tag: "Layers", Summary: "Pobierz warstwę", Response: models.Layer{}, Errors: []int{404}},
+	"LayerHandler.GetViolations": {
+		ID: "getLayerViolations", Tag: "Layers", Summary: "Relacje łamiące model warstwowy",
+		Response: struct {
+			Violations []models.LayerViolation `json:"violations"`
+		}{},
+	},
+	"LayerHandler.CreateLayer": {
+		Tag: "Layers", Summary: "Dodaj warstwę", Body: models.Layer{}, BodyRequired: []string{"name"},
+		Status: http.StatusCreated, Response: models.Layer{}, Errors: []int{409},
+	},
+	"LayerHandler.UpdateLayer": {Tag: "Layers", Summary: "Zmień pozycję lub opis warstwy", Body: models.Layer{}, Response: models.Layer{}, Errors: []int{404}},
+	"LayerHandler.DeleteLayer": {Tag: "Layers", Summary: "Usuń warstwę bez wierzchołków", Response: messageResponse, Errors: []int{404, 409}},
+
+	// Reguły architektury
+	"RuleHandler.GetAllRules":   {Tag: "Rules", Summary: "Lista reguł architektury", Response: []models.ArchitectureRule{}},
+	"RuleHandler.GetRuleByID":   {Tag: "Rules", Summary: "Pobierz regułę", Response: models.ArchitectureRule{}, Errors: []int{404}},
+	"RuleHandler.GetViolations": {ID: "getRuleViolations", Tag: "Rules", Summary: "Sprawdź graf względem włączonych reguł", Response: models.RuleReport{}},
+	"RuleHandler.CreateRule": {
+		Tag: "Rules", Summary: "Dodaj regułę", Body: models.ArchitectureRule{}, BodyRequired: []string{"name"},
+		Status: http.StatusCreated, Response: models.ArchitectureRule{}, Errors: []int{409},
+	},
+	"RuleHandler.UpdateRule": {Tag: "Rules", Summary: "Zastąp definicję reguły", Body: models.ArchitectureRule{}, Response: models.ArchitectureRule{}, Errors: []int{404}},
+	"RuleHandler.DeleteRule": {Tag: "Rules", Summary: "Usuń regułę", Response: messageResponse, Errors: []int{404}},
+
+	// Graf
+	"GraphHandler.GetGraph": {
+		Tag: "Graph", Summary: "Pełny graf z naruszeniami modelu warstwowego", Headers: []openapi.Param{ifNoneMatch},
+		Response: models.Graph{}, Errors: []int{304},
+	},
+	"GraphHandler.ValidateGraph": {Tag: "Graph", Summary: "Sprawdź spójność zapisanych danych", Response: models.IntegrityReport{}},
+	"GraphHandler.RepairGraph": {
+		Tag: "Graph", Summary: "Napraw naruszenia spójności",
+		Query: []openapi.Param{
+			{Name: "strategy", Description: "detach - przenieś osierocone wierzchołki na najwyższy poziom, delete - usuń je z poddrzewem", Enum: []string{models.RepairStrategyDetach, models.RepairStrategyDelete}, Default: models.RepairStrategyDetach},
+			{Name: "dry_run", Type: "boolean", Description: "Tylko pokaż planowane zmiany", Default: false},
+		},
+		Response: models.RepairReport{},
+	},
+	"GraphHandler.GetMetrics":    {Tag: "Graph", Summary: "Miary grafu zależności", Response: models.GraphMetrics{}},
+	"GraphHandler.GetResilience": {Tag: "Graph", Summary: "Pojedyncze punkty awarii", Response: models.ResilienceReport{}},
+	"GraphHandler.GetLatency": {
+		Tag: "Graph", Summary: "Ścieżka krytyczna i najgorsze opóźnienie od punktu wejścia",
+		Query: []openapi.Param{
+			{Name: "from", Description: "ID lub slug punktu wejścia", Required: true},
+			{Name: "budget_ms", Type: "number", Description: "Budżet opóźnienia (ms)", Minimum: openapi.Float(0)},
+		},
+		Response: models.LatencyReport{}, Errors: []int{404, 422},
+	},
+	"GraphHandler.GetAvailability": {
+		Tag: "Graph", Summary: "Teoretyczna dostępność i nieosiągalne SLO",
+		Query:    []openapi.Param{{Name: "from", Description: "ID lub slug punktu wejścia - zawęża raport"}},
+		Response: models.AvailabilityReport{}, Errors: []int{404},
+	},
+	"GraphHandler.GetOrphans": {Tag: "Graph", Summary: "Kandydaci na nieużywane serwisy", Response: models.OrphanReport{}},
+	"GraphHandler.GetNeighborhood": {
+		Tag: "Graph", Summary: "Podgraf w otoczeniu wierzchołka",
+		Query: []openapi.Param{
+			{Name: "vertex", Description: "ID lub slug wierzchołka", Required: true},
+			{Name: "radius", Type: "integer", Description: "Liczba relacji od wierzchołka", Default: 1, Minimum: openapi.Float(0)},
+			{Name: "direction", Description: "Kierunek relacji", Enum: []string{"out", "in", "both"}, Default: "both"},
+		},
+		Headers:  []openapi.Param{ifNoneMatch},
+		Response: models.Graph{}, Errors: []int{304, 404},
+	},
+	"EventsHandler.StreamGraphEvents": {
+		Tag: "Graph", Summary: "Strumień zmian wierzchołków i relacji (Server-Sent Events)",
+		Description: "Po ponownym połączeniu z Last-Event-ID pominięte zdarzenia są dosyłane albo wysyłane jest zdarzenie reset.",
+		Query:       []openapi.Param{{Name: "types", Description: "Lista typów zdarzeń po przecinku, np. edge.created,edge.deleted"}},
+		Headers:     []openapi.Param{{Name: "Last-Event-ID", Description: "ID ostatniego odebranego zdarzenia"}},
+		Response:    eventStream, ResponseType: "text/event-stream",
+	},
+	"OpenAPIHandler.GetSpec": {Tag: "Meta", Summary: "Specyfikacja OpenAPI 3 tego API", Response: &openapi.Schema{Type: "object", AdditionalProperties: true}},
+
+	// Zapytania
+	"QueryHandler.RunQuery": {
+		Tag: "Query", Summary: "Zapytanie o wzorzec ścieżki (MATCH ... RETURN)",
+		Body: queryRequest{}, BodyRequired: []string{"query"}, Response: query.Result{},
+		Errors: []int{422},
+	},
+	"GraphQLHandler.Execute": {
+		ID: "graphql", Tag: "Query", Summary: "Zapytanie lub mutacja GraphQL",
+		Body: graphQLRequest{}, BodyRequired: []string{"query"}, Response: graphQLResponse,
+	},
+
+	// Paczki zmian
+	"BatchHandler.ExecuteBatch": {
+		Tag: "Batch", Summary: "Wykonaj operacje na wierzchołkach i relacjach w jednej transakcji", Description: "Operacje wykonywane są po kolei; id, parent_id, from i to mogą wskazywać obiekt utworzony wcześniej w paczce (\"$\" i jego ref). Błąd dowolnej operacji wycofuje całą paczkę, a jej indeks zwracany jest w polu operation.",
+		Body: batchRequest{}, BodyRequired: []string{"operations"}, Response: batchResponse{}, Errors: []int{404, 409, 412, 422},
+	},
+
+	// Webhooki
+	"WebhookHandler.GetAllWebhooks": {Tag: "Webhooks", Summary: "Lista webhooków (bez sekretów)", Response: []models.Webhook{}},
+	"WebhookHandler.GetWebhookByID": {Tag: "Webhooks", Summary: "Pobierz webhook", Response: models.Webhook{}, Errors: []int{404}},
+	"WebhookHandler.CreateWebhook": {
+		Tag: "Webhooks", Summary: "Zarejestruj webhook", Body: models.Webhook{}, BodyRequired: []string{"url"},
+		Status: http.StatusCreated, Response: models.Webhook{}, Errors: []int{409},
+	},
+	"WebhookHandler.UpdateWebhook": {
+		Tag: "Webhooks", Summary: "Zastąp definicję webhooka", Description: "Pominięty secret pozostaje bez zmian.",
+		Body: models.Webhook{}, BodyRequired: []string{"url"}, Response: models.Webhook{}, Errors: []int{404},
+	},
+	"WebhookHandler.DeleteWebhook": {Tag: "Webhooks", Summary: "Usuń webhook z logiem dostarczeń", Response: messageResponse, Errors: []int{404}},
+	"WebhookHandler.GetDeliveries": {
+		Tag: "Webhooks", Summary: "Log prób dostarczenia, od najnowszych",
+		Query:    []openapi.Param{{Name: "limit", Type: "integer", Default: defaultDeliveriesLimit, Minimum: openapi.Float(1), Maximum: openapi.Float(maxDeliveriesLimit)}},
+		Response: []models.WebhookDelivery{}, Errors: []int{404},
+	},
+	"WebhookHandler.PingWebhook": {
+		Tag: "Webhooks", Summary: "Wyślij zdarzenie testowe ping",
+		Status: http.StatusAccepted,
+		Response: struct {
+			DeliveryID string `json:"delivery_id"`
+		}{},
+		Errors: []int{404},
+	},
+}
@@ -0,0 +1,388 @@
+package openapi_integration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"microservice_overview/events"
+	"microservice_overview/handlers"
+	"microservice_overview/openapi"
+	"microservice_overview/storage"
+	"microservice_overview/webhooks"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupTestRouter rejestruje trasy API wszystkich wersji przez handlers.RegisterAPI, tak jak main.go
+func setupTestRouter(v1 handlers.APIVersion) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	// Ustaw tryb developerski dla testów
+	os.Setenv("DEV_MODE", "true")
+
+	// Utwórz storage z bazą w pamięci
+	s, err := storage.NewStorage()
+	if err != nil {
+		os.Unsetenv("DEV_MODE")
+		panic("failed to create storage: " + err.Error())
+	}
+	bus := events.NewBus()
+
+	// Utwórz router z tymi samymi trasami co main.go
+	r := gin.New()
+	deps := handlers.Dependencies{
+		Storage:     s,
+		Bus:         bus,
+		Dispatcher:  webhooks.NewDispatcher(s, bus),
+		Idempotency: handlers.NewIdempotency(s, time.Hour),
+	}
+	if err := handlers.RegisterAPI(r, deps, v1, handlers.APIVersion{Name: "v2"}); err != nil {
+		panic("failed to register routes: " + err.Error())
+	}
+
+	return r
+}
+
+func doRequest(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestEveryRouteIsDocumented_Integration(t *testing.T) {
+	r := setupTestRouter(handlers.APIVersion{Name: "v1"})
+
+	// v2 i ścieżki bez wersji opisują handlers.Operations, v1 - handlers.OperationsV1
+	versions := []struct {
+		name       string
+		operations map[string]openapi.Operation
+		used       map[string]bool
+	}{
+		{"handlers.Operations", handlers.Operations, make(map[string]bool)},
+		{"handlers.OperationsV1", handlers.OperationsV1, make(map[string]bool)},
+	}
+	for _, route := range r.Routes() {
+		version := versions[0]
+		if strings.HasPrefix(route.Path, "/api/v1/") {
+			version = versions[1]
+		}
+		name := openapi.HandlerName(route.Handler)
+		version.used[name] = true
+		if _, ok := version.operations[name]; !ok {
+			t.Errorf("Route %s %s (%s) has no entry in %s", route.Method, route.Path, name, version.name)
+		}
+	}
+	for _, version := range versions {
+		for name := range version.operations {
+			if !version.used[name] {
+				t.Errorf("Operation %s in %s does not match any registered route", name, version.name)
+			}
+		}
+	}
+}
+
+func TestGetSpec_Integration(t *testing.T) {
+	r := setupTestRouter(handlers.APIVersion{Name: "v1"})
+
+	w := doRequest(r, "GET", "/api/v2/openapi.json", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var doc struct {
+		OpenAPI string                                       `json:"openapi"`
+		Info    openapi.Info                                 `json:"info"`
+		Paths   map[string]map[string]map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if doc.OpenAPI != openapi.Version || doc.Info.Version != "2.0.0" {
+		t.Errorf("Expected openapi %s for API 2.0.0, got %s %s", openapi.Version, doc.OpenAPI, doc.Info.Version)
+	}
+
+	operations := 0
+	ids := make(map[string]bool)
+	for path, item := range doc.Paths {
+		if strings.Contains(path, ":") {
+			t.Errorf("Expected OpenAPI path templates, got %s", path)
+		}
+		for method, operation := range item {
+			operations++
+			id, _ := operation["operationId"].(string)
+			if ids[id] {
+				t.Errorf("Duplicate operationId %s (%s %s)", id, method, path)
+			}
+			ids[id] = true
+		}
+	}
+	if operations != len(handlers.Operations) {
+		t.Errorf("Expected %d operations, got %d", len(handlers.Operations), operations)
+	}
+
+	vertex := doc.Paths["/api/v2/vertices/{id}"]["get"]
+	if vertex == nil || vertex["operationId"] != "getVertexByID" {
+		t.Errorf("Expected the vertex lookup operation, got %v", vertex)
+	}
+
+	// Ścieżka bez wersji zwraca specyfikację bieżącej wersji, /api/v1 - zamrożoną v1
+	for path, expected := range map[string]string{"/api/openapi.json": "2.0.0", "/api/v1/openapi.json": "1.0.0"} {
+		var spec struct {
+			Info  openapi.Info           `json:"info"`
+			Paths map[string]interface{} `json:"paths"`
+		}
+		w := doRequest(r, "GET", path, "")
+		if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+			t.Fatalf("%s: failed to parse response: %v", path, err)
+		}
+		if spec.Info.Version != expected {
+			t.Errorf("%s: expected specification %s, got %s", path, expected, spec.Info.Version)
+		}
+	}
+}
+
+func TestValidation_Integration(t *testing.T) {
+	r := setupTestRouter(handlers.APIVersion{Name: "v1"})
+
+	cases := []struct {
+		method, path, body string
+		problem            string
+	}{
+		{"POST", "/api/v2/vertices", `{"type": "service"}`, "name is required"},
+		{"POST", "/api/v2/vertices", `{"name": ""}`, "name must not be empty"},
+		{"POST", "/api/v2/vertices", `{"name": 5}`, "name must be a string"},
+		{"POST", "/api/v2/edges", `{"from": "a"}`, "to is required"},
+		{"GET", "/api/v2/webhooks/x/deliveries?limit=0", "", "limit must be at least 1"},
+		{"GET", "/api/v2/graph/neighborhood?vertex=a&direction=sideways", "", "direction must be one of"},
+		{"POST", "/api/v2/graph/repair?dry_run=maybe", "", "dry_run must be true or false"},
+		{"POST", "/api/v1/vertices", `{"name": ""}`, "name must not be empty"},
+		{"POST", "/api/vertices", `{"name": ""}`, "name must not be empty"},
+		{"POST", "/api/edges", `{"from": "a"}`, "to is required"},
+	}
+	for _, tc := range cases {
+		w := doRequest(r, tc.method, tc.path, tc.body)
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), tc.problem) {
+			t.Errorf("%s %s: expected 400 with %q, got %d %s", tc.method, tc.path, tc.problem, w.Code, w.Body.String())
+		}
+	}
+
+	// Poprawne żądanie przechodzi do handlera bez zmian
+	w := doRequest(r, "POST", "/api/v2/vertices", `{"name": "billing", "type": "service"}`)
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+}
+
+func TestVersionHeaders_Integration(t *testing.T) {
+	deprecation := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 5, 1, 0, 0, 0, 0, time.UTC)
+	r := setupTestRouter(handlers.APIVersion{Name: "v1", Deprecation: deprecation, Sunset: sunset, Successor: "/api/v2"})
+
+	// Wersje współdzielą dane
+	w := doRequest(r, "POST", "/api/vertices", `{"id": "billing", "name": "Billing"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	cases := []struct {
+		path       string
+		version    string
+		deprecated bool
+	}{
+		{"/api/v2/vertices/billing", "v2", false},
+		{"/api/v1/vertices/billing", "v1", true},
+		{"/api/vertices/billing", "v2", false},
+		{"/api/v1/vertices/missing", "v1", true},
+	}
+	for _, tc := range cases {
+		w := doRequest(r, "GET", tc.path, "")
+		if w.Code == http.StatusInternalServerError {
+			t.Fatalf("%s: unexpected error %s", tc.path, w.Body.String())
+		}
+		if got := w.Header().Get("API-Version"); got != tc.version {
+			t.Errorf("%s: expected API-Version %s, got %q", tc.path, tc.version, got)
+		}
+		if !tc.deprecated {
+			for _, header := range []string{"Deprecation", "Sunset", "Link"} {
+				if w.Header().Get(header) != "" {
+					t.Errorf("%s: expected no %s header, got %q", tc.path, header, w.Header().Get(header))
+				}
+			}
+			continue
+		}
+		if got := w.Header().Get("Deprecation"); got != "@1793491200" {
+			t.Errorf("%s: expected Deprecation @1793491200, got %q", tc.path, got)
+		}
+		if got := w.Header().Get("Sunset"); got != "Sat, 01 May 2027 00:00:00 GMT" {
+			t.Errorf("%s: expected Sunset date, got %q", tc.path, got)
+		}
+		if got := w.Header().Get("Link"); got != `</api/v2>; rel="successor-version"` {
+			t.Errorf("%s: expected a successor-version link, got %q", tc.path, got)
+		}
+	}
+}
+
+func TestLoadAPIVersion_Integration(t *testing.T) {
+	t.Setenv("API_V1_DEPRECATION", "2026-11-01")
+	t.Setenv("API_V1_SUNSET", "2027-05-01T12:00:00+02:00")
+	v, err := handlers.LoadAPIVersion("v1", "/api/v2", handlers.V1Deprecation)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !v.Deprecated() || !v.Deprecation.Equal(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)) || !v.Sunset.Equal(time.Date(2027, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected version %+v", v)
+	}
+
+	invalid := map[string][2]string{
+		"not a date":             {"soon", ""},
+		"sunset before":          {"2026-11-01", "2026-10-01"},
+		"sunset without warning": {"", "2027-05-01"},
+	}
+	for name, dates := range invalid {
+		t.Setenv("API_V1_DEPRECATION", dates[0])
+		t.Setenv("API_V1_SUNSET", dates[1])
+		if _, err := handlers.LoadAPIVersion("v1", "/api/v2", time.Time{}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	// Bez konfiguracji obowiązuje domyślna data wycofania
+	t.Setenv("API_V1_DEPRECATION", "")
+	t.Setenv("API_V1_SUNSET", "")
+	if v, err := handlers.LoadAPIVersion("v1", "/api/v2", handlers.V1Deprecation); err != nil || !v.Deprecation.Equal(handlers.V1Deprecation) || !v.Sunset.IsZero() {
+		t.Errorf("Expected the default deprecation without configuration, got %+v %v", v, err)
+	}
+	if v, err := handlers.LoadAPIVersion("v2", "", time.Time{}); err != nil || v.Deprecated() {
+		t.Errorf("Expected a supported version without a default deprecation, got %+v %v", v, err)
+	}
+
+	// Termin wyłączenia może uzupełnić domyślną datę wycofania
+	t.Setenv("API_V1_SUNSET", "2027-05-01")
+	if v, err := handlers.LoadAPIVersion("v1", "/api/v2", handlers.V1Deprecation); err != nil || v.Sunset.IsZero() {
+		t.Errorf("Expected a sunset after the default deprecation, got %+v %v", v, err)
+	}
+}
+
+func TestV1Contract_Integration(t *testing.T) {
+	r := setupTestRouter(handlers.APIVersion{Name: "v1"})
+
+	w := doRequest(r, "POST", "/api/v2/vertices", `{"id": "billing", "name": "Billing", "description": "old", "processing_ms": 12}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	doRequest(r, "POST", "/api/v2/vertices", `{"id": "payments", "name": "Payments"}`)
+
+	// Odpowiedzi v1 zawierają tylko pola sprzed wersjonowania
+	v1Only := func(path string, body []byte, allowed ...string) {
+		t.Helper()
+		var fields map[string]interface{}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			t.Fatalf("%s: failed to parse response: %v", path, err)
+		}
+		for field := range fields {
+			known := false
+			for _, name := range allowed {
+				known = known || field == name
+			}
+			if !known {
+				t.Errorf("%s: unexpected field %q in the v1 contract", path, field)
+			}
+		}
+	}
+	vertexFields := []string{"id", "name", "description", "parent_id", "created_at", "updated_at"}
+	edgeFields := []string{"id", "from", "to", "type", "created_at", "updated_at"}
+
+	w = doRequest(r, "GET", "/api/v1/vertices/billing", "")
+	if w.Code != http.StatusOK || w.Header().Get("ETag") != "" {
+		t.Fatalf("Expected the vertex without an ETag, got %d %v", w.Code, w.Header())
+	}
+	v1Only("/api/v1/vertices/billing", w.Body.Bytes(), vertexFields...)
+
+	// PUT i DELETE w v1 nie wymagają If-Match, ale go sprawdzają
+	w = doRequest(r, "PUT", "/api/v1/vertices/billing", `{"name": "Billing v1", "description": "new"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	v1Only("PUT /api/v1/vertices/billing", w.Body.Bytes(), vertexFields...)
+	req, _ := http.NewRequest("PUT", "/api/v1/vertices/billing", strings.NewReader(`{"name": "Stale"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+	stale := httptest.NewRecorder()
+	r.ServeHTTP(stale, req)
+	if stale.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected status code %d for a stale If-Match, got %d", http.StatusPreconditionFailed, stale.Code)
+	}
+
+	// Pola ustawione przez v2 przetrwały zapis przez v1
+	var vertex struct {
+		Name         string  `json:"name"`
+		ProcessingMs float64 `json:"processing_ms"`
+	}
+	w = doRequest(r, "GET", "/api/v2/vertices/billing", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &vertex); err != nil || vertex.Name != "Billing v1" || vertex.ProcessingMs != 12 {
+		t.Errorf("Expected the v1 update to keep v2 fields, got %s", w.Body.String())
+	}
+
+	w = doRequest(r, "POST", "/api/v1/edges", `{"id": "e1", "from": "billing", "to": "payments", "type": "calls"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	v1Only("POST /api/v1/edges", w.Body.Bytes(), edgeFields...)
+
+	w = doRequest(r, "GET", "/api/v1/graph", "")
+	var graph struct {
+		Vertices []json.RawMessage `json:"vertices"`
+		Edges    []json.RawMessage `json:"edges"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &graph); err != nil || len(graph.Vertices) != 2 || len(graph.Edges) != 1 {
+		t.Fatalf("Expected the graph with 2 vertices and 1 edge, got %s", w.Body.String())
+	}
+	v1Only("/api/v1/graph", w.Body.Bytes(), "vertices", "edges")
+	v1Only("/api/v1/graph edge", graph.Edges[0], edgeFields...)
+
+	for _, path := range []string{"/api/v1/edges/e1", "/api/v1/vertices/billing"} {
+		if w := doRequest(r, "DELETE", path, ""); w.Code != http.StatusOK {
+			t.Errorf("DELETE %s: expected status code %d, got %d. Body: %s", path, http.StatusOK, w.Code, w.Body.String())
+		}
+	}
+
+	// Nowe funkcje nie trafiają do zamrożonej v1
+	for _, path := range []string{"/api/v1/edge-types", "/api/v1/graph/metrics", "/api/v1/webhooks"} {
+		if w := doRequest(r, "GET", path, ""); w.Code != http.StatusNotFound {
+			t.Errorf("GET %s: expected status code %d, got %d", path, http.StatusNotFound, w.Code)
+		}
+	}
+}
+
+func TestUnversionedAPI_Integration(t *testing.T) {
+	r := setupTestRouter(handlers.APIVersion{Name: "v1"})
+
+	// Ścieżki bez wersji obsługują wszystkie endpointy bieżącej wersji
+	for _, tc := range []struct{ method, path, body string }{
+		{"GET", "/api/edge-types", ""},
+		{"GET", "/api/graph/validate", ""},
+		{"GET", "/api/graph/metrics", ""},
+		{"GET", "/api/webhooks", ""},
+		{"POST", "/api/batch", `{"operations": [{"op": "create", "type": "vertex", "vertex": {"id": "billing", "name": "Billing"}}]}`},
+	} {
+		if w := doRequest(r, tc.method, tc.path, tc.body); w.Code != http.StatusOK {
+			t.Errorf("%s %s: expected status code %d, got %d. Body: %s", tc.method, tc.path, http.StatusOK, w.Code, w.Body.String())
+		}
+	}
+
+	// i wymagają If-Match przy PUT i DELETE
+	if w := doRequest(r, "PUT", "/api/vertices/billing", `{"name": "Billing"}`); w.Code != http.StatusPreconditionRequired {
+		t.Errorf("PUT /api/vertices/billing: expected status code %d, got %d", http.StatusPreconditionRequired, w.Code)
+	}
+	if w := doRequest(r, "DELETE", "/api/vertices/billing", ""); w.Code != http.StatusPreconditionRequired {
+		t.Errorf("DELETE /api/vertices/billing: expected status code %d, got %d", http.StatusPreconditionRequired, w.Code)
+	}
+}
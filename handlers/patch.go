@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"io"
+	"mime"
+	"net/http"
+
+	"microservice_overview/patch"
+
+	"github.com/gin-gonic/gin"
+)
+
+// applyRequestPatch stosuje treść żądania PATCH do dokumentu JSON zgodnie z Content-Type
+// (merge patch lub JSON Patch). Zwraca false (i wysyła odpowiedź) w przypadku błędu.
+func applyRequestPatch(c *gin.Context, doc []byte) ([]byte, bool) {
+	contentType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+
+	var apply func(doc, patch []byte) ([]byte, error)
+	switch contentType {
+	case patch.MergePatchContentType:
+		apply = patch.MergePatch
+	case patch.JSONPatchContentType:
+		apply = patch.ApplyJSONPatch
+	default:
+		c.Header("Accept-Patch", patch.MergePatchContentType+", "+patch.JSONPatchContentType)
+		respondProblem(c, http.StatusUnsupportedMediaType, "Content-Type must be "+patch.MergePatchContentType+" or "+patch.JSONPatchContentType)
+		return nil, false
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	patched, err := apply(doc, body)
+	if err != nil {
+		// Niespełniona operacja test - 409
+		respondError(c, http.StatusBadRequest, err)
+		return nil, false
+	}
+	return patched, true
+}
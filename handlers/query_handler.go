@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+
+	"microservice_overview/query"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryHandler obsługuje zapytania o wzorce w grafie
+type QueryHandler struct {
+	storage storage.Storage
+}
+
+// NewQueryHandler tworzy nowy QueryHandler
+func NewQueryHandler(s storage.Storage) *QueryHandler {
+	return &QueryHandler{storage: s}
+}
+
+// queryRequest treść żądania POST /api/query
+type queryRequest struct {
+	Query string `json:"query" binding:"required"`
+}
+
+// RunQuery wykonuje zapytanie MATCH ... RETURN na aktualnym grafie
+func (h *QueryHandler) RunQuery(c *gin.Context) {
+	var req queryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	graph, err := h.storage.GetGraph()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	result, err := query.Run(req.Query, graph)
+	if err != nil {
+		// Błąd składni - 400 z pozycją w zapytaniu, zbyt kosztowne wyszukiwanie - 422
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,135 @@
+package query_integration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"microservice_overview/events"
+	"microservice_overview/handlers"
+	"microservice_overview/models"
+	"microservice_overview/query"
+	"microservice_overview/storage"
+	"microservice_overview/webhooks"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupTestRouter rejestruje trasy API przez handlers.RegisterAPI, tak jak main.go
+func setupTestRouter() (*gin.Engine, storage.Storage) {
+	gin.SetMode(gin.TestMode)
+
+	// Ustaw tryb developerski dla testów
+	os.Setenv("DEV_MODE", "true")
+
+	// Utwórz storage z bazą w pamięci
+	s, err := storage.NewStorage()
+	if err != nil {
+		os.Unsetenv("DEV_MODE")
+		panic("failed to create storage: " + err.Error())
+	}
+	bus := events.NewBus()
+
+	// Utwórz router z tymi samymi trasami co main.go
+	r := gin.New()
+	deps := handlers.Dependencies{
+		Storage:     s,
+		Bus:         bus,
+		Dispatcher:  webhooks.NewDispatcher(s, bus),
+		Idempotency: handlers.NewIdempotency(s, time.Hour),
+	}
+	if err := handlers.RegisterAPI(r, deps, handlers.APIVersion{Name: "v1"}, handlers.APIVersion{Name: "v2"}); err != nil {
+		panic("failed to register routes: " + err.Error())
+	}
+
+	return r, s
+}
+
+func postQuery(r *gin.Engine, text string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]string{"query": text})
+	req, _ := http.NewRequest("POST", "/api/query", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRunQuery_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "payments", Name: "Payments", Kind: "team"})
+	s.CreateVertex(&models.Vertex{ID: "checkout", Name: "Checkout", ParentID: stringPtr("payments")})
+	s.CreateVertex(&models.Vertex{ID: "ledger", Name: "Ledger", ParentID: stringPtr("payments")})
+	s.CreateVertex(&models.Vertex{ID: "ledger-db", Name: "Ledger DB", Kind: "database", Metadata: models.JSONMap{"engine": "postgres"}})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "checkout", To: "ledger", Type: "calls"})
+	s.CreateEdge(&models.Edge{ID: "e2", From: "ledger", To: "ledger-db", Type: "calls"})
+
+	w := postQuery(r, `MATCH (a {team:"payments"})-[:calls*1..3]->(b {kind:"database"}) RETURN a.name, b.name`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var result query.Result
+	json.Unmarshal(w.Body.Bytes(), &result)
+
+	if result.Count != 2 || len(result.Columns) != 2 {
+		t.Errorf("Expected checkout and ledger to reach the database, got %+v", result)
+	}
+}
+
+func TestRunQuery_Errors_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	w := postQuery(r, `MATCH (a)-[:calls]->(b RETURN a`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	var response map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response["position"] == nil {
+		t.Errorf("Expected error position, got %v", response)
+	}
+
+	req, _ := http.NewRequest("POST", "/api/query", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d for missing query, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestRunQuery_TooExpensive_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	// Graf pełny - liczba ścieżek o zmiennej długości rośnie wykładniczo
+	const n = 8
+	for i := 0; i < n; i++ {
+		s.CreateVertex(&models.Vertex{ID: fmt.Sprintf("svc-%d", i), Name: fmt.Sprintf("Service %d", i)})
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			s.CreateEdge(&models.Edge{From: fmt.Sprintf("svc-%d", i), To: fmt.Sprintf("svc-%d", j), Type: "calls"})
+		}
+	}
+
+	w := postQuery(r, `MATCH (a)-[*]-(b) WHERE b.name = "nope" RETURN a`)
+	if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), "QUERY_TOO_EXPENSIVE") {
+		t.Errorf("Expected 422 QUERY_TOO_EXPENSIVE, got %d %s", w.Code, w.Body.String())
+	}
+
+	w = postQuery(r, `MATCH (a)-[*]-(b) WHERE a.name = "nope" RETURN a`)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected a condition on the start vertex to prune the search, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"microservice_overview/events"
+	"microservice_overview/openapi"
+	"microservice_overview/storage"
+	"microservice_overview/webhooks"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Dependencies zależności handlerów API
+type Dependencies struct {
+	Storage     storage.Storage
+	Bus         *events.Bus
+	Dispatcher  *webhooks.Dispatcher
+	Idempotency *Idempotency
+}
+
+// RegisterAPI rejestruje trasy wszystkich wersji API i buduje ich specyfikacje
+// OpenAPI. Żądania są sprawdzane względem specyfikacji wersji, a poprawne
+// żądania zapisu z Idempotency-Key - zapamiętywane. Ścieżki bez wersji
+// (/api/...) są aliasem bieżącej wersji (v2), więc istniejące skrypty zachowują
+// wszystkie endpointy i wymaganie If-Match; zamrożony jest tylko jawny prefiks
+// /api/v1 (zob. RegisterRoutesV1)
+func RegisterAPI(r *gin.Engine, deps Dependencies, v1, v2 APIVersion) error {
+	specV1, specV2 := v1.Spec(OperationsV1), v2.Spec(Operations)
+
+	for _, prefix := range []string{"/api/v2", "/api"} {
+		if err := RegisterRoutes(r.Group(prefix, v2.Headers, specV2.Validate, deps.Idempotency.Handle), deps, specV2); err != nil {
+			return err
+		}
+	}
+	RegisterRoutesV1(r.Group("/api/v1", v1.Headers, specV1.Validate, deps.Idempotency.Handle), deps, specV1)
+
+	// Specyfikacje powstają z tras zarejestrowanych powyżej
+	specV2.Build(r.Routes(), "/api/v2", "/api")
+	specV1.Build(r.Routes(), "/api/v1")
+	return nil
+}
+
+// RegisterRoutes rejestruje tabelę tras API w grupie; spec to specyfikacja
+// wersji udostępniana pod /openapi.json
+func RegisterRoutes(api *gin.RouterGroup, deps Dependencies, spec *openapi.Spec) error {
+	s := deps.Storage
+	vertexHandler := NewVertexHandler(s)
+	edgeHandler := NewEdgeHandler(s)
+	graphHandler := NewGraphHandler(s)
+	edgeTypeHandler := NewEdgeTypeHandler(s)
+	vertexKindHandler := NewVertexKindHandler(s)
+	ruleHandler := NewRuleHandler(s)
+	layerHandler := NewLayerHandler(s)
+	queryHandler := NewQueryHandler(s)
+	eventsHandler := NewEventsHandler(deps.Bus)
+	batchHandler := NewBatchHandler(s)
+	webhookHandler := NewWebhookHandler(s, deps.Dispatcher)
+	openAPIHandler := NewOpenAPIHandler(spec)
+	graphQLHandler, err := NewGraphQLHandler(s)
+	if err != nil {
+		return err
+	}
+
+	// Wierzchołki
+	api.GET("/vertices", vertexHandler.GetAllVertices)
+	api.GET("/vertices/:id", vertexHandler.GetVertexByID)
+	api.POST("/vertices", vertexHandler.CreateVertex)
+	api.PUT("/vertices/:id", vertexHandler.UpdateVertex)
+	api.PATCH("/vertices/:id", vertexHandler.PatchVertex)
+	api.DELETE("/vertices/:id", vertexHandler.DeleteVertex)
+	api.POST("/vertices/:id/move", vertexHandler.MoveVertex)
+
+	// Relacje
+	api.GET("/edges", edgeHandler.GetAllEdges)
+	api.GET("/edges/:id", edgeHandler.GetEdgeByID)
+	api.POST("/edges", edgeHandler.CreateEdge)
+	api.PUT("/edges/:id", edgeHandler.UpdateEdge)
+	api.PATCH("/edges/:id", edgeHandler.PatchEdge)
+	api.DELETE("/edges/:id", edgeHandler.DeleteEdge)
+
+	// Katalog typów relacji
+	api.GET("/edge-types", edgeTypeHandler.GetAllEdgeTypes)
+	api.GET("/edge-types/:name", edgeTypeHandler.GetEdgeType)
+	api.POST("/edge-types", edgeTypeHandler.CreateEdgeType)
+	api.PUT("/edge-types/:name", edgeTypeHandler.UpdateEdgeType)
+	api.DELETE("/edge-types/:name", edgeTypeHandler.DeleteEdgeType)
+
+	// Rejestr rodzajów wierzchołków
+	api.GET("/vertex-kinds", vertexKindHandler.GetAllVertexKinds)
+	api.GET("/vertex-kinds/:name", vertexKindHandler.GetVertexKind)
+	api.POST("/vertex-kinds", vertexKindHandler.CreateVertexKind)
+	api.PUT("/vertex-kinds/:name", vertexKindHandler.UpdateVertexKind)
+	api.DELETE("/vertex-kinds/:name", vertexKindHandler.DeleteVertexKind)
+
+	// Model warstwowy
+	api.GET("/layers", layerHandler.GetAllLayers)
+	api.GET("/layers/violations", layerHandler.GetViolations)
+	api.GET("/layers/:name", layerHandler.GetLayer)
+	api.POST("/layers", layerHandler.CreateLayer)
+	api.PUT("/layers/:name", layerHandler.UpdateLayer)
+	api.DELETE("/layers/:name", layerHandler.DeleteLayer)
+
+	// Reguły architektury
+	api.GET("/rules", ruleHandler.GetAllRules)
+	api.GET("/rules/violations", ruleHandler.GetViolations)
+	api.GET("/rules/:id", ruleHandler.GetRuleByID)
+	api.POST("/rules", ruleHandler.CreateRule)
+	api.PUT("/rules/:id", ruleHandler.UpdateRule)
+	api.DELETE("/rules/:id", ruleHandler.DeleteRule)
+
+	// Graf
+	api.GET("/graph", graphHandler.GetGraph)
+	api.GET("/graph/validate", graphHandler.ValidateGraph)
+	api.GET("/graph/metrics", graphHandler.GetMetrics)
+	api.GET("/graph/resilience", graphHandler.GetResilience)
+	api.GET("/graph/latency", graphHandler.GetLatency)
+	api.GET("/graph/availability", graphHandler.GetAvailability)
+	api.GET("/graph/orphans", graphHandler.GetOrphans)
+	api.GET("/graph/neighborhood", graphHandler.GetNeighborhood)
+	api.GET("/graph/events", eventsHandler.StreamGraphEvents)
+	api.POST("/graph/repair", graphHandler.RepairGraph)
+
+	// Webhooki
+	api.GET("/webhooks", webhookHandler.GetAllWebhooks)
+	api.GET("/webhooks/:id", webhookHandler.GetWebhookByID)
+	api.POST("/webhooks", webhookHandler.CreateWebhook)
+	api.PUT("/webhooks/:id", webhookHandler.UpdateWebhook)
+	api.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)
+	api.GET("/webhooks/:id/deliveries", webhookHandler.GetDeliveries)
+	api.POST("/webhooks/:id/ping", webhookHandler.PingWebhook)
+
+	// Zapytania o wzorce w grafie
+	api.POST("/query", queryHandler.RunQuery)
+
+	// GraphQL
+	api.POST("/graphql", graphQLHandler.Execute)
+
+	// Paczki zmian w jednej transakcji
+	api.POST("/batch", batchHandler.ExecuteBatch)
+
+	// Specyfikacja OpenAPI
+	api.GET("/openapi.json", openAPIHandler.GetSpec)
+	return nil
+}
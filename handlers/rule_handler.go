@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+
+	"microservice_overview/models"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RuleHandler obsługuje żądania związane z regułami architektury
+type RuleHandler struct {
+	storage storage.Storage
+}
+
+// NewRuleHandler tworzy nowy RuleHandler
+func NewRuleHandler(s storage.Storage) *RuleHandler {
+	return &RuleHandler{storage: s}
+}
+
+// GetAllRules zwraca wszystkie reguły architektury
+func (h *RuleHandler) GetAllRules(c *gin.Context) {
+	all, err := h.storage.GetAllRules()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, all)
+}
+
+// GetRuleByID zwraca regułę po ID
+func (h *RuleHandler) GetRuleByID(c *gin.Context) {
+	rule, err := h.storage.GetRuleByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+// CreateRule dodaje regułę architektury. Reguła nie jest sprawdzana wstecz -
+// istniejące naruszenia pokazuje GET /api/rules/violations.
+func (h *RuleHandler) CreateRule(c *gin.Context) {
+	var rule models.ArchitectureRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.storage.CreateRule(&rule); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateRule zastępuje definicję reguły
+func (h *RuleHandler) UpdateRule(c *gin.Context) {
+	current, err := h.storage.GetRuleByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	var rule models.ArchitectureRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	rule.ID = current.ID
+
+	if err := h.storage.UpdateRule(&rule); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule usuwa regułę
+func (h *RuleHandler) DeleteRule(c *gin.Context) {
+	current, err := h.storage.GetRuleByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := h.storage.DeleteRule(current.ID); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "rule deleted"})
+}
+
+// GetViolations sprawdza cały graf względem reguł i zwraca listę naruszeń
+func (h *RuleHandler) GetViolations(c *gin.Context) {
+	report, err := h.storage.GetRuleViolations()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,236 @@
+package rule_integration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"microservice_overview/handlers"
+	"microservice_overview/models"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupTestRouter() (*gin.Engine, storage.Storage) {
+	gin.SetMode(gin.TestMode)
+
+	// Ustaw tryb developerski dla testów
+	os.Setenv("DEV_MODE", "true")
+
+	// Utwórz storage z bazą w pamięci
+	s, err := storage.NewStorage()
+	if err != nil {
+		os.Unsetenv("DEV_MODE")
+		panic("failed to create storage: " + err.Error())
+	}
+
+	// Utwórz router
+	r := gin.New()
+	ruleHandler := handlers.NewRuleHandler(s)
+	edgeHandler := handlers.NewEdgeHandler(s)
+
+	api := r.Group("/api")
+	{
+		api.GET("/rules", ruleHandler.GetAllRules)
+		api.GET("/rules/violations", ruleHandler.GetViolations)
+		api.GET("/rules/:id", ruleHandler.GetRuleByID)
+		api.POST("/rules", ruleHandler.CreateRule)
+		api.PUT("/rules/:id", ruleHandler.UpdateRule)
+		api.DELETE("/rules/:id", ruleHandler.DeleteRule)
+		api.POST("/edges", edgeHandler.CreateEdge)
+	}
+
+	return r, s
+}
+
+// seedDomains tworzy domeny billing i marketing z jednym serwisem w każdej
+func seedDomains(s storage.Storage) {
+	s.CreateVertex(&models.Vertex{ID: "billing", Name: "Billing", Kind: "team"})
+	s.CreateVertex(&models.Vertex{ID: "marketing", Name: "Marketing", Kind: "team"})
+	s.CreateVertex(&models.Vertex{ID: "invoices", Name: "Invoices", ParentID: stringPtr("billing")})
+	s.CreateVertex(&models.Vertex{ID: "campaigns", Name: "Campaigns", ParentID: stringPtr("marketing")})
+}
+
+func createRule(t *testing.T, r *gin.Engine, body string) models.ArchitectureRule {
+	t.Helper()
+
+	req, _ := http.NewRequest("POST", "/api/rules", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var rule models.ArchitectureRule
+	json.Unmarshal(w.Body.Bytes(), &rule)
+	return rule
+}
+
+func TestCreateRule_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	rule := createRule(t, r, `{
+		"name": "No deprecated dependencies",
+		"effect": "deny",
+		"target": {"metadata": {"deprecated": true}}
+	}`)
+
+	if rule.ID == "" || rule.Severity != models.RuleSeverityError {
+		t.Errorf("Expected generated ID and default severity, got %+v", rule)
+	}
+
+	req, _ := http.NewRequest("GET", "/api/rules/"+rule.ID, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var fetched models.ArchitectureRule
+	json.Unmarshal(w.Body.Bytes(), &fetched)
+	if fetched.Target.Metadata["deprecated"] != true {
+		t.Errorf("Expected target selector to be stored, got %+v", fetched.Target)
+	}
+}
+
+func TestCreateRule_Validation_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "missing name", body: `{"effect": "deny"}`},
+		{name: "invalid effect", body: `{"name": "r", "effect": "forbid"}`},
+		{name: "invalid severity", body: `{"name": "r", "effect": "deny", "severity": "fatal"}`},
+		{name: "unknown domain", body: `{"name": "r", "effect": "deny", "source": {"domain": "billing"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/api/rules", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status code %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCreateEdge_RuleViolation_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+	seedDomains(s)
+
+	createRule(t, r, `{
+		"name": "Billing must not call marketing",
+		"effect": "deny",
+		"source": {"domain": "billing"},
+		"target": {"domain": "marketing"},
+		"edge_types": ["calls"]
+	}`)
+
+	jsonValue, _ := json.Marshal(models.Edge{From: "invoices", To: "campaigns", Type: "calls"})
+	req, _ := http.NewRequest("POST", "/api/edges", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Violations []models.RuleViolation `json:"violations"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if len(response.Violations) != 1 || response.Violations[0].Dependency != "campaigns" {
+		t.Errorf("Unexpected violations: %+v", response.Violations)
+	}
+
+	// Odwrotny kierunek nie łamie reguły
+	jsonValue, _ = json.Marshal(models.Edge{From: "campaigns", To: "invoices", Type: "calls"})
+	req, _ = http.NewRequest("POST", "/api/edges", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+}
+
+func TestGetViolations_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+	seedDomains(s)
+
+	// Relacja istnieje przed dodaniem reguły - reguła nie jest sprawdzana wstecz
+	s.CreateEdge(&models.Edge{ID: "e1", From: "invoices", To: "campaigns", Type: "calls"})
+
+	createRule(t, r, `{
+		"name": "Billing must not call marketing",
+		"effect": "deny",
+		"severity": "warning",
+		"source": {"domain": "billing"},
+		"target": {"domain": "marketing"}
+	}`)
+
+	req, _ := http.NewRequest("GET", "/api/rules/violations", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var report models.RuleReport
+	json.Unmarshal(w.Body.Bytes(), &report)
+	if !report.Valid || report.Warnings != 1 || len(report.Violations) != 1 || report.Violations[0].EdgeID != "e1" {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+}
+
+func TestUpdateAndDeleteRule_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+	seedDomains(s)
+
+	rule := createRule(t, r, `{"name": "Nothing depends on marketing", "effect": "deny", "target": {"domain": "marketing"}}`)
+
+	req, _ := http.NewRequest("PUT", "/api/rules/"+rule.ID, bytes.NewBufferString(`{"name": "Nothing depends on marketing", "effect": "deny", "target": {"domain": "marketing"}, "disabled": true}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// Wyłączona reguła nie blokuje zapisu
+	if err := s.CreateEdge(&models.Edge{From: "invoices", To: "campaigns", Type: "calls"}); err != nil {
+		t.Errorf("Disabled rule should not block edge: %v", err)
+	}
+
+	req, _ = http.NewRequest("DELETE", "/api/rules/"+rule.ID, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if _, err := s.GetRuleByID(rule.ID); err == nil {
+		t.Error("Rule should be deleted")
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
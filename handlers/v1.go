@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"microservice_overview/models"
+	"microservice_overview/openapi"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// V1Deprecation data wydania v2, od której v1 jest wycofywana; zmienna
+// API_V1_DEPRECATION może ją zmienić (zob. LoadAPIVersion)
+var V1Deprecation = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+// v1Vertex wierzchołek w kontrakcie v1 - tylko pola sprzed wersjonowania API.
+// Rodzaj, warstwa, metadane, slug i wersja są dostępne w v2
+type v1Vertex struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	ParentID    *string   `json:"parent_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// v1Edge relacja w kontrakcie v1 (bez opóźnień, fallbacku i wersji)
+type v1Edge struct {
+	ID        string    `json:"id"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Type      string    `json:"type,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// v1Graph graf w kontrakcie v1
+type v1Graph struct {
+	Vertices []v1Vertex `json:"vertices"`
+	Edges    []v1Edge   `json:"edges"`
+}
+
+func toV1Vertex(v *models.Vertex) v1Vertex {
+	return v1Vertex{ID: v.ID, Name: v.Name, Description: v.Description, ParentID: v.ParentID, CreatedAt: v.CreatedAt, UpdatedAt: v.UpdatedAt}
+}
+
+func toV1Edge(e *models.Edge) v1Edge {
+	return v1Edge{ID: e.ID, From: e.From, To: e.To, Type: e.Type, CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt}
+}
+
+func toV1Vertices(vertices []models.Vertex) []v1Vertex {
+	result := make([]v1Vertex, len(vertices))
+	for i := range vertices {
+		result[i] = toV1Vertex(&vertices[i])
+	}
+	return result
+}
+
+func toV1Edges(edges []models.Edge) []v1Edge {
+	result := make([]v1Edge, len(edges))
+	for i := range edges {
+		result[i] = toV1Edge(&edges[i])
+	}
+	return result
+}
+
+// V1Handler obsługuje zamrożony kontrakt v1: CRUD wierzchołków i relacji oraz
+// graf w kształcie sprzed wersjonowania. Zapisy nie wymagają If-Match (wygrywa
+// ostatni zapis), a PUT zmienia tylko pola v1 - pozostałe pola ustawione przez
+// v2 są zachowywane
+type V1Handler struct {
+	storage storage.Storage
+}
+
+// NewV1Handler tworzy nowy V1Handler
+func NewV1Handler(s storage.Storage) *V1Handler {
+	return &V1Handler{storage: s}
+}
+
+// GetAllVertices zwraca listę wszystkich wierzchołków
+func (h *V1Handler) GetAllVertices(c *gin.Context) {
+	vertices, err := h.storage.GetAllVertices()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, toV1Vertices(vertices))
+}
+
+// GetVertexByID zwraca wierzchołek po ID
+func (h *V1Handler) GetVertexByID(c *gin.Context) {
+	vertex, err := h.storage.GetVertexByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, toV1Vertex(vertex))
+}
+
+// CreateVertex tworzy nowy wierzchołek
+func (h *V1Handler) CreateVertex(c *gin.Context) {
+	var req v1Vertex
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	vertex := models.Vertex{ID: req.ID, Name: req.Name, Description: req.Description, ParentID: req.ParentID}
+	if err := h.storage.CreateVertex(&vertex); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toV1Vertex(&vertex))
+}
+
+// UpdateVertex zastępuje nazwę, opis i rodzica wierzchołka
+func (h *V1Handler) UpdateVertex(c *gin.Context) {
+	var req v1Vertex
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	vertex, err := h.storage.GetVertexByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !checkOptionalIfMatch(c, vertex.Version) {
+		return
+	}
+	// Zapis z odczytaną wersją - równoległa zmiana kończy się konfliktem, nie nadpisaniem
+	vertex.Name, vertex.Description, vertex.ParentID = req.Name, req.Description, req.ParentID
+	if err := h.storage.UpdateVertex(vertex); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, toV1Vertex(vertex))
+}
+
+// DeleteVertex usuwa wierzchołek
+func (h *V1Handler) DeleteVertex(c *gin.Context) {
+	vertex, err := h.storage.GetVertexByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !checkOptionalIfMatch(c, vertex.Version) {
+		return
+	}
+	if err := h.storage.DeleteVertex(vertex.ID, vertex.Version); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "vertex deleted"})
+}
+
+// GetAllEdges zwraca listę wszystkich relacji
+func (h *V1Handler) GetAllEdges(c *gin.Context) {
+	edges, err := h.storage.GetAllEdges()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, toV1Edges(edges))
+}
+
+// GetEdgeByID zwraca relację po ID
+func (h *V1Handler) GetEdgeByID(c *gin.Context) {
+	edge, err := h.storage.GetEdgeByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, toV1Edge(edge))
+}
+
+// CreateEdge tworzy nową relację
+func (h *V1Handler) CreateEdge(c *gin.Context) {
+	var req v1Edge
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	edge := models.Edge{ID: req.ID, From: req.From, To: req.To, Type: req.Type}
+	if err := h.storage.CreateEdge(&edge); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toV1Edge(&edge))
+}
+
+// UpdateEdge zastępuje końce i typ relacji
+func (h *V1Handler) UpdateEdge(c *gin.Context) {
+	var req v1Edge
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	edge, err := h.storage.GetEdgeByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !checkOptionalIfMatch(c, edge.Version) {
+		return
+	}
+	edge.From, edge.To, edge.Type = req.From, req.To, req.Type
+	if err := h.storage.UpdateEdge(edge); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, toV1Edge(edge))
+}
+
+// DeleteEdge usuwa relację
+func (h *V1Handler) DeleteEdge(c *gin.Context) {
+	edge, err := h.storage.GetEdgeByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !checkOptionalIfMatch(c, edge.Version) {
+		return
+	}
+	if err := h.storage.DeleteEdge(edge.ID, edge.Version); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "edge deleted"})
+}
+
+// GetGraph zwraca wszystkie wierzchołki i relacje
+func (h *V1Handler) GetGraph(c *gin.Context) {
+	graph, err := h.storage.GetGraph()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1Graph{Vertices: toV1Vertices(graph.Vertices), Edges: toV1Edges(graph.Edges)})
+}
+
+// RegisterRoutesV1 rejestruje trasy zamrożonego kontraktu v1. Nowe funkcje
+// (katalogi, reguły, analizy, zapytania, webhooki...) są dostępne tylko w v2
+func RegisterRoutesV1(api *gin.RouterGroup, deps Dependencies, spec *openapi.Spec) {
+	v1Handler := NewV1Handler(deps.Storage)
+	openAPIHandler := NewOpenAPIHandler(spec)
+
+	// Wierzchołki
+	api.GET("/vertices", v1Handler.GetAllVertices)
+	api.GET("/vertices/:id", v1Handler.GetVertexByID)
+	api.POST("/vertices", v1Handler.CreateVertex)
+	api.PUT("/vertices/:id", v1Handler.UpdateVertex)
+	api.DELETE("/vertices/:id", v1Handler.DeleteVertex)
+
+	// Relacje
+	api.GET("/edges", v1Handler.GetAllEdges)
+	api.GET("/edges/:id", v1Handler.GetEdgeByID)
+	api.POST("/edges", v1Handler.CreateEdge)
+	api.PUT("/edges/:id", v1Handler.UpdateEdge)
+	api.DELETE("/edges/:id", v1Handler.DeleteEdge)
+
+	// Graf
+	api.GET("/graph", v1Handler.GetGraph)
+
+	// Specyfikacja OpenAPI
+	api.GET("/openapi.json", openAPIHandler.GetSpec)
+}
+
+// OperationsV1 opisy operacji kontraktu v1 (zob. RegisterRoutesV1)
+var OperationsV1 = map[string]openapi.Operation{
+	// Wierzchołki
+	"V1Handler.GetAllVertices": {Tag: "Vertices", Summary: "Lista wierzchołków", Response: []v1Vertex{}},
+	"V1Handler.GetVertexByID":  {Tag: "Vertices", Summary: "Pobierz wierzchołek po ID", Response: v1Vertex{}, Errors: []int{404}},
+	"V1Handler.CreateVertex": {
+		Tag: "Vertices", Summary: "Utwórz wierzchołek", Description: "Bez id - identyfikator wygeneruje serwer.",
+		Body: v1Vertex{}, BodyRequired: []string{"name"},
+		Status: http.StatusCreated, Response: v1Vertex{}, Errors: []int{409, 422},
+	},
+	"V1Handler.UpdateVertex": {
+		Tag: "Vertices", Summary: "Zastąp nazwę, opis i rodzica wierzchołka", Description: "Pola dodane w v2 pozostają bez zmian.",
+		Headers: []openapi.Param{ifMatchOptional}, Body: v1Vertex{}, BodyRequired: []string{"name"}, Response: v1Vertex{}, Errors: []int{404, 409, 412, 422},
+	},
+	"V1Handler.DeleteVertex": {Tag: "Vertices", Summary: "Usuń wierzchołek", Headers: []openapi.Param{ifMatchOptional}, Response: messageResponse, Errors: []int{404, 409, 412}},
+
+	// Relacje
+	"V1Handler.GetAllEdges": {Tag: "Edges", Summary: "Lista relacji", Response: []v1Edge{}},
+	"V1Handler.GetEdgeByID": {Tag: "Edges", Summary: "Pobierz relację po ID", Response: v1Edge{}, Errors: []int{404}},
+	"V1Handler.CreateEdge": {
+		Tag: "Edges", Summary: "Utwórz relację",
+		Body: v1Edge{}, BodyRequired: []string{"from", "to"},
+		Status: http.StatusCreated, Response: v1Edge{}, Errors: []int{409, 422},
+	},
+	"V1Handler.UpdateEdge": {
+		Tag: "Edges", Summary: "Zastąp końce i typ relacji", Description: "Pola dodane w v2 pozostają bez zmian.",
+		Headers: []openapi.Param{ifMatchOptional}, Body: v1Edge{}, BodyRequired: []string{"from", "to"}, Response: v1Edge{}, Errors: []int{404, 409, 412, 422},
+	},
+	"V1Handler.DeleteEdge": {Tag: "Edges", Summary: "Usuń relację", Headers: []openapi.Param{ifMatchOptional}, Response: messageResponse, Errors: []int{404, 412}},
+
+	// Graf
+	"V1Handler.GetGraph": {Tag: "Graph", Summary: "Pełny graf", Response: v1Graph{}},
+
+	// Meta
+	"OpenAPIHandler.GetSpec": Operations["OpenAPIHandler.GetSpec"],
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"microservice_overview/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersion wersja REST API wraz z datami wycofania
+type APIVersion struct {
+	Name        string    // np. "v1"
+	Deprecation time.Time // od kiedy wersja jest wycofywana (zero - wersja wspierana)
+	Sunset      time.Time // po jakim czasie wersja może przestać działać (zero - bez terminu)
+	Successor   string    // prefiks wersji, na którą należy przejść, np. "/api/v2"
+}
+
+// LoadAPIVersion tworzy wersję wycofywaną od deprecation (zero - wspieraną).
+// Zmienne środowiskowe API_<WERSJA>_DEPRECATION i API_<WERSJA>_SUNSET
+// (RFC 3339 lub RRRR-MM-DD) zmieniają datę wycofania i ustawiają termin wyłączenia
+func LoadAPIVersion(name, successor string, deprecation time.Time) (APIVersion, error) {
+	v := APIVersion{Name: name, Successor: successor, Deprecation: deprecation}
+	prefix := "API_" + strings.ToUpper(name) + "_"
+
+	configured, err := parseVersionDate(prefix + "DEPRECATION")
+	if err != nil {
+		return v, err
+	}
+	if !configured.IsZero() {
+		v.Deprecation = configured
+	}
+	if v.Sunset, err = parseVersionDate(prefix + "SUNSET"); err != nil {
+		return v, err
+	}
+	if !v.Sunset.IsZero() && v.Deprecation.IsZero() {
+		return v, fmt.Errorf("%sSUNSET requires %sDEPRECATION", prefix, prefix)
+	}
+	if !v.Sunset.IsZero() && v.Sunset.Before(v.Deprecation) {
+		return v, fmt.Errorf("%sSUNSET must not be earlier than %sDEPRECATION", prefix, prefix)
+	}
+	return v, nil
+}
+
+// parseVersionDate odczytuje datę ze zmiennej środowiskowej (pusta - zero)
+func parseVersionDate(key string) (time.Time, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%s must be a date (RFC 3339 or YYYY-MM-DD), got %q", key, value)
+}
+
+// Deprecated mówi czy wersja jest wycofywana
+func (v APIVersion) Deprecated() bool {
+	return !v.Deprecation.IsZero()
+}
+
+// Headers oznacza odpowiedzi nagłówkiem API-Version, a dla wycofywanej wersji
+// dodaje Deprecation (RFC 9745), Sunset (RFC 8594) i Link do następnej wersji
+func (v APIVersion) Headers(c *gin.Context) {
+	header := c.Writer.Header()
+	header.Set("API-Version", v.Name)
+	if !v.Deprecated() {
+		return
+	}
+	header.Set("Deprecation", "@"+strconv.FormatInt(v.Deprecation.Unix(), 10))
+	if !v.Sunset.IsZero() {
+		header.Set("Sunset", v.Sunset.Format(http.TimeFormat))
+	}
+	if v.Successor != "" {
+		header.Add("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", v.Successor))
+	}
+}
+
+// Spec tworzy specyfikację OpenAPI wersji z opisów jej operacji (Operations
+// dla v2, OperationsV1 dla v1); dokument zawiera tylko używane grupy
+func (v APIVersion) Spec(operations map[string]openapi.Operation) *openapi.Spec {
+	info := APIInfo
+	info.Version = strings.TrimPrefix(v.Name, "v") + ".0.0"
+	used := make(map[string]bool)
+	for _, op := range operations {
+		used[op.Tag] = true
+	}
+	var tags []openapi.Tag
+	for _, tag := range APITags {
+		if used[tag.Name] {
+			tags = append(tags, tag)
+		}
+	}
+	spec := openapi.New(info, tags, operations)
+	spec.AddHeader(idempotencyKeyParam, http.MethodPost, http.MethodPut, http.MethodDelete)
+	return spec
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"microservice_overview/models"
@@ -23,80 +24,178 @@ func NewVertexHandler(s storage.Storage) *VertexHandler {
 func (h *VertexHandler) GetAllVertices(c *gin.Context) {
 	vertices, err := h.storage.GetAllVertices()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, err)
 		return
 	}
 	c.JSON(http.StatusOK, vertices)
 }
 
-// GetVertexByID zwraca wierzchołek po ID
+// GetVertexByID zwraca wierzchołek po ID lub slugu
 func (h *VertexHandler) GetVertexByID(c *gin.Context) {
 	id := c.Param("id")
-	vertex, err := h.storage.GetVertexByID(id)
+	vertex, err := h.storage.GetVertexByIDOrSlug(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "vertex not found"})
+		respondError(c, http.StatusInternalServerError, err)
 		return
 	}
+	c.Header("ETag", versionETag(vertex.Version))
 	c.JSON(http.StatusOK, vertex)
 }
 
-// CreateVertex tworzy nowy wierzchołek
+// CreateVertex tworzy nowy wierzchołek (bez id - ID generuje serwer)
 func (h *VertexHandler) CreateVertex(c *gin.Context) {
 	var vertex models.Vertex
 	if err := c.ShouldBindJSON(&vertex); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	if vertex.ID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "id is required"})
+		respondError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	if vertex.Name == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		respondProblem(c, http.StatusBadRequest, "name is required")
 		return
 	}
 
 	if err := h.storage.CreateVertex(&vertex); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	c.Header("ETag", versionETag(vertex.Version))
 	c.JSON(http.StatusCreated, vertex)
 }
 
-// UpdateVertex aktualizuje istniejący wierzchołek
+// UpdateVertex aktualizuje istniejący wierzchołek (wymaga nagłówka If-Match)
 func (h *VertexHandler) UpdateVertex(c *gin.Context) {
 	id := c.Param("id")
 
 	var vertex models.Vertex
 	if err := c.ShouldBindJSON(&vertex); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	current, err := h.storage.GetVertexByIDOrSlug(id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !checkIfMatch(c, current.Version) {
 		return
 	}
 
-	vertex.ID = id
+	vertex.ID = current.ID
+	vertex.Version = current.Version
 
 	if err := h.storage.UpdateVertex(&vertex); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	c.Header("ETag", versionETag(vertex.Version))
 	c.JSON(http.StatusOK, vertex)
 }
 
-// DeleteVertex usuwa wierzchołek
+// PatchVertex częściowo aktualizuje wierzchołek (merge patch lub JSON Patch, If-Match opcjonalny)
+func (h *VertexHandler) PatchVertex(c *gin.Context) {
+	id := c.Param("id")
+
+	current, err := h.storage.GetVertexByIDOrSlug(id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !checkOptionalIfMatch(c, current.Version) {
+		return
+	}
+
+	doc, err := json.Marshal(current)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	patched, ok := applyRequestPatch(c, doc)
+	if !ok {
+		return
+	}
+
+	var vertex models.Vertex
+	if err := json.Unmarshal(patched, &vertex); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if vertex.ID != current.ID {
+		respondProblem(c, http.StatusBadRequest, "id cannot be changed")
+		return
+	}
+
+	if vertex.Name == "" {
+		respondProblem(c, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	vertex.Version = current.Version
+
+	if err := h.storage.UpdateVertex(&vertex); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("ETag", versionETag(vertex.Version))
+	c.JSON(http.StatusOK, vertex)
+}
+
+// DeleteVertex usuwa wierzchołek (wymaga nagłówka If-Match)
 func (h *VertexHandler) DeleteVertex(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.storage.DeleteVertex(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	current, err := h.storage.GetVertexByIDOrSlug(id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !checkIfMatch(c, current.Version) {
+		return
+	}
+
+	if err := h.storage.DeleteVertex(current.ID, current.Version); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "vertex deleted"})
 }
 
+// moveVertexRequest opisuje docelowe miejsce przenoszonego wierzchołka
+type moveVertexRequest struct {
+	ParentID *string `json:"parent_id"` // null lub brak = najwyższy poziom
+}
+
+// MoveVertex przenosi wierzchołek (razem z poddrzewem) pod nowego rodzica (If-Match opcjonalny)
+func (h *VertexHandler) MoveVertex(c *gin.Context) {
+	id := c.Param("id")
+
+	var req moveVertexRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	current, err := h.storage.GetVertexByIDOrSlug(id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !checkOptionalIfMatch(c, current.Version) {
+		return
+	}
 
+	vertex, err := h.storage.MoveVertex(current.ID, req.ParentID, current.Version)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
 
+	c.Header("ETag", versionETag(vertex.Version))
+	c.JSON(http.StatusOK, vertex)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"microservice_overview/handlers"
@@ -38,7 +39,9 @@ func setupTestRouter() (*gin.Engine, storage.Storage) {
 		api.GET("/vertices/:id", vertexHandler.GetVertexByID)
 		api.POST("/vertices", vertexHandler.CreateVertex)
 		api.PUT("/vertices/:id", vertexHandler.UpdateVertex)
+		api.PATCH("/vertices/:id", vertexHandler.PatchVertex)
 		api.DELETE("/vertices/:id", vertexHandler.DeleteVertex)
+		api.POST("/vertices/:id/move", vertexHandler.MoveVertex)
 	}
 
 	return r, s
@@ -150,6 +153,7 @@ func TestUpdateVertex_Integration(t *testing.T) {
 	jsonValue, _ := json.Marshal(updated)
 	req, _ := http.NewRequest("PUT", "/api/vertices/update-test", bytes.NewBuffer(jsonValue))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
 
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
@@ -173,6 +177,7 @@ func TestDeleteVertex_Integration(t *testing.T) {
 	s.CreateVertex(vertex)
 
 	req, _ := http.NewRequest("DELETE", "/api/vertices/delete-test", nil)
+	req.Header.Set("If-Match", `"1"`)
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 
@@ -196,15 +201,35 @@ func TestCreateVertex_Validation_Integration(t *testing.T) {
 		expectedStatus int
 	}{
 		{
-			name:           "missing ID",
+			name:           "missing ID is generated",
 			vertex:         models.Vertex{Name: "Test"},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusCreated,
 		},
 		{
 			name:           "missing Name",
 			vertex:         models.Vertex{ID: "test-id"},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name:           "unknown kind",
+			vertex:         models.Vertex{Name: "Test", Kind: "lambda"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing required metadata",
+			vertex:         models.Vertex{Name: "Orders DB", Kind: "database"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "metadata of wrong type",
+			vertex:         models.Vertex{Name: "Orders DB", Kind: "database", Metadata: models.JSONMap{"engine": 16}},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown metadata field",
+			vertex:         models.Vertex{Name: "Orders DB", Kind: "database", Metadata: models.JSONMap{"engine": "postgres", "size": "large"}},
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -223,3 +248,588 @@ func TestCreateVertex_Validation_Integration(t *testing.T) {
 	}
 }
 
+func TestCreateVertex_Problems_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+	s.CreateVertex(&models.Vertex{ID: "existing", Name: "Existing"})
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"unknown parent", `{"name": "Orphan", "parent_id": "missing"}`, http.StatusUnprocessableEntity, "PARENT_NOT_FOUND"},
+		{"duplicate ID", `{"id": "existing", "name": "Again"}`, http.StatusConflict, "DUPLICATE_ID"},
+		{"unknown kind", `{"name": "Test", "kind": "lambda"}`, http.StatusBadRequest, "INVALID_REQUEST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/api/vertices", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status code %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if contentType := w.Header().Get("Content-Type"); contentType != "application/problem+json" {
+				t.Errorf("Expected application/problem+json, got %s", contentType)
+			}
+			var body map[string]interface{}
+			json.Unmarshal(w.Body.Bytes(), &body)
+			if body["code"] != tt.expectedCode || body["status"] != float64(tt.expectedStatus) ||
+				body["instance"] != "/api/vertices" || body["detail"] == "" {
+				t.Errorf("Unexpected problem %v", body)
+			}
+		})
+	}
+}
+
+func TestMoveVertex_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	// Utwórz dwa kontenery i wierzchołek w pierwszym z nich
+	s.CreateVertex(&models.Vertex{ID: "domain-a", Name: "Domain A"})
+	s.CreateVertex(&models.Vertex{ID: "domain-b", Name: "Domain B"})
+	s.CreateVertex(&models.Vertex{ID: "service", Name: "Service", ParentID: stringPtr("domain-a")})
+	s.CreateVertex(&models.Vertex{ID: "worker", Name: "Worker", ParentID: stringPtr("service")})
+
+	req, _ := http.NewRequest("POST", "/api/vertices/service/move", bytes.NewBufferString(`{"parent_id": "domain-b"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response models.Vertex
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	if response.ParentID == nil || *response.ParentID != "domain-b" {
+		t.Errorf("Expected parent domain-b, got %v", response.ParentID)
+	}
+
+	// Poddrzewo przenosi się razem z wierzchołkiem
+	worker, _ := s.GetVertexByID("worker")
+	if worker.ParentID == nil || *worker.ParentID != "service" {
+		t.Errorf("Expected worker to stay under service, got %v", worker.ParentID)
+	}
+}
+
+func TestMoveVertex_ToRoot_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "domain", Name: "Domain"})
+	s.CreateVertex(&models.Vertex{ID: "service", Name: "Service", ParentID: stringPtr("domain")})
+
+	req, _ := http.NewRequest("POST", "/api/vertices/service/move", bytes.NewBufferString(`{"parent_id": null}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	moved, _ := s.GetVertexByID("service")
+	if moved.ParentID != nil {
+		t.Errorf("Expected vertex at root level, got parent %s", *moved.ParentID)
+	}
+}
+
+func TestMoveVertex_Validation_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "domain", Name: "Domain"})
+	s.CreateVertex(&models.Vertex{ID: "service", Name: "Service", ParentID: stringPtr("domain")})
+	s.CreateVertex(&models.Vertex{ID: "leaf-a", Name: "Leaf A"})
+	s.CreateVertex(&models.Vertex{ID: "leaf-b", Name: "Leaf B"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "leaf-a", To: "leaf-b", Type: "calls"})
+
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "unknown vertex",
+			id:             "missing",
+			body:           `{"parent_id": "domain"}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "unknown parent",
+			id:             "service",
+			body:           `{"parent_id": "missing"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedCode:   "PARENT_NOT_FOUND",
+		},
+		{
+			name:           "cycle in hierarchy",
+			id:             "domain",
+			body:           `{"parent_id": "service"}`,
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "HIERARCHY_CYCLE",
+		},
+		{
+			name:           "parent with edges",
+			id:             "service",
+			body:           `{"parent_id": "leaf-a"}`,
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "EDGE_ON_NON_LEAF",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/api/vertices/"+tt.id+"/move", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			var body map[string]interface{}
+			json.Unmarshal(w.Body.Bytes(), &body)
+			if tt.expectedCode != "" && body["code"] != tt.expectedCode {
+				t.Errorf("Expected code %s, got %v", tt.expectedCode, body["code"])
+			}
+		})
+	}
+
+	// Nieudane przeniesienie nie może zmienić hierarchii
+	service, _ := s.GetVertexByID("service")
+	if service.ParentID == nil || *service.ParentID != "domain" {
+		t.Errorf("Expected service to stay under domain, got %v", service.ParentID)
+	}
+}
+
+func TestUpdateVertex_ParentWithEdges_ShouldFail_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "leaf-a", Name: "Leaf A"})
+	s.CreateVertex(&models.Vertex{ID: "leaf-b", Name: "Leaf B"})
+	s.CreateVertex(&models.Vertex{ID: "newcomer", Name: "Newcomer"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "leaf-a", To: "leaf-b", Type: "calls"})
+
+	// Nadanie dziecka liściowi z relacjami złamałoby regułę relacji między liśćmi
+	updated := models.Vertex{Name: "Newcomer", ParentID: stringPtr("leaf-a")}
+	jsonValue, _ := json.Marshal(updated)
+	req, _ := http.NewRequest("PUT", "/api/vertices/newcomer", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code == http.StatusOK {
+		t.Errorf("Expected update to fail, got %d", w.Code)
+	}
+}
+
+func TestUpdateVertex_ConcurrentEdits_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "shared", Name: "Shared"})
+
+	// Obaj użytkownicy odczytali wersję 1
+	req, _ := http.NewRequest("GET", "/api/vertices/shared", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	etag := w.Header().Get("ETag")
+	if etag != `"1"` {
+		t.Fatalf("Expected ETag \"1\", got %s", etag)
+	}
+
+	update := func(name string) *httptest.ResponseRecorder {
+		jsonValue, _ := json.Marshal(models.Vertex{Name: name})
+		req, _ := http.NewRequest("PUT", "/api/vertices/shared", bytes.NewBuffer(jsonValue))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", etag)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := update("First edit"); w.Code != http.StatusOK {
+		t.Fatalf("Expected first edit to succeed, got %d", w.Code)
+	}
+	if w := update("Second edit"); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected second edit to fail with %d, got %d", http.StatusPreconditionFailed, w.Code)
+	}
+
+	vertex, _ := s.GetVertexByID("shared")
+	if vertex.Name != "First edit" {
+		t.Errorf("Expected first edit to win, got %s", vertex.Name)
+	}
+}
+
+func TestDeleteVertex_WithoutIfMatch_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "keep", Name: "Keep"})
+
+	req, _ := http.NewRequest("DELETE", "/api/vertices/keep", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusPreconditionRequired {
+		t.Errorf("Expected status code %d, got %d", http.StatusPreconditionRequired, w.Code)
+	}
+	if _, err := s.GetVertexByID("keep"); err != nil {
+		t.Error("Vertex should not be deleted")
+	}
+}
+
+func TestDeleteVertex_InUse_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "domain", Name: "Domain"})
+	s.CreateVertex(&models.Vertex{ID: "orders", Name: "Orders", ParentID: stringPtr("domain")})
+	s.CreateVertex(&models.Vertex{ID: "billing", Name: "Billing"})
+	s.CreateEdge(&models.Edge{From: "orders", To: "billing", Type: "calls"})
+
+	// Wierzchołek z dziećmi lub relacjami zostaje - usunięcie osierociłoby dane
+	for _, id := range []string{"domain", "billing"} {
+		req, _ := http.NewRequest("DELETE", "/api/vertices/"+id, nil)
+		req.Header.Set("If-Match", `"1"`)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "RESOURCE_IN_USE") {
+			t.Errorf("%s: expected 409 RESOURCE_IN_USE, got %d %s", id, w.Code, w.Body.String())
+		}
+		if _, err := s.GetVertexByID(id); err != nil {
+			t.Errorf("%s: vertex should not be deleted", id)
+		}
+	}
+}
+
+func TestPatchVertex_MergePatch_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "domain", Name: "Domain"})
+	s.CreateVertex(&models.Vertex{ID: "service", Name: "Service", Description: "Keeps description", ParentID: stringPtr("domain")})
+
+	req, _ := http.NewRequest("PATCH", "/api/vertices/service", bytes.NewBufferString(`{"name": "Renamed"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// Pominięte pola zostają bez zmian
+	vertex, _ := s.GetVertexByID("service")
+	if vertex.Name != "Renamed" {
+		t.Errorf("Expected Name Renamed, got %s", vertex.Name)
+	}
+	if vertex.Description != "Keeps description" {
+		t.Errorf("Expected description to be kept, got %s", vertex.Description)
+	}
+	if vertex.ParentID == nil || *vertex.ParentID != "domain" {
+		t.Errorf("Expected parent to be kept, got %v", vertex.ParentID)
+	}
+	if w.Header().Get("ETag") != `"2"` {
+		t.Errorf("Expected ETag \"2\", got %s", w.Header().Get("ETag"))
+	}
+
+	// null usuwa pole
+	req, _ = http.NewRequest("PATCH", "/api/vertices/service", bytes.NewBufferString(`{"parent_id": null}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	vertex, _ = s.GetVertexByID("service")
+	if vertex.ParentID != nil {
+		t.Errorf("Expected parent to be removed, got %s", *vertex.ParentID)
+	}
+}
+
+func TestPatchVertex_JSONPatch_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "service", Name: "Service", Description: "Old"})
+
+	body := `[{"op": "test", "path": "/version", "value": 1}, {"op": "replace", "path": "/description", "value": "New"}]`
+	req, _ := http.NewRequest("PATCH", "/api/vertices/service", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	vertex, _ := s.GetVertexByID("service")
+	if vertex.Description != "New" || vertex.Name != "Service" {
+		t.Errorf("Unexpected vertex after patch: %+v", vertex)
+	}
+}
+
+func TestPatchVertex_Validation_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "service", Name: "Service"})
+	s.CreateVertex(&models.Vertex{ID: "leaf-a", Name: "Leaf A"})
+	s.CreateVertex(&models.Vertex{ID: "leaf-b", Name: "Leaf B"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "leaf-a", To: "leaf-b", Type: "calls"})
+
+	tests := []struct {
+		name           string
+		id             string
+		contentType    string
+		ifMatch        string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "unsupported content type",
+			id:             "service",
+			contentType:    "application/json",
+			body:           `{"name": "X"}`,
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "unknown vertex",
+			id:             "missing",
+			contentType:    "application/merge-patch+json",
+			body:           `{"name": "X"}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "id change",
+			id:             "service",
+			contentType:    "application/merge-patch+json",
+			body:           `{"id": "other"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "name removed",
+			id:             "service",
+			contentType:    "application/merge-patch+json",
+			body:           `{"name": null}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "failed test operation",
+			id:             "service",
+			contentType:    "application/json-patch+json",
+			body:           `[{"op": "test", "path": "/name", "value": "Other"}]`,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "stale If-Match",
+			id:             "service",
+			contentType:    "application/merge-patch+json",
+			ifMatch:        `"9"`,
+			body:           `{"name": "X"}`,
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "storage rules still apply",
+			id:             "service",
+			contentType:    "application/merge-patch+json",
+			body:           `{"parent_id": "leaf-a"}`,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("PATCH", "/api/vertices/"+tt.id, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	vertex, _ := s.GetVertexByID("service")
+	if vertex.Name != "Service" || vertex.Version != 1 {
+		t.Errorf("Expected vertex to stay unchanged, got %+v", vertex)
+	}
+}
+
+func TestCreateVertex_GeneratedIDAndSlug_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	create := func(name string) models.Vertex {
+		jsonValue, _ := json.Marshal(models.Vertex{Name: name})
+		req, _ := http.NewRequest("POST", "/api/vertices", bytes.NewBuffer(jsonValue))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+		var response models.Vertex
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response
+	}
+
+	first := create("Płatności / Billing API")
+	second := create("Płatności / Billing API")
+
+	if len(first.ID) != 36 || first.ID[14] != '7' {
+		t.Errorf("Expected UUIDv7 ID, got %s", first.ID)
+	}
+	if first.ID == second.ID {
+		t.Error("Expected unique IDs")
+	}
+	if first.Slug != "platnosci-billing-api" {
+		t.Errorf("Expected slug platnosci-billing-api, got %s", first.Slug)
+	}
+	if second.Slug != "platnosci-billing-api-2" {
+		t.Errorf("Expected slug platnosci-billing-api-2, got %s", second.Slug)
+	}
+
+	// Wierzchołek można pobrać zarówno po ID, jak i po slugu
+	for _, ref := range []string{first.ID, first.Slug} {
+		req, _ := http.NewRequest("GET", "/api/vertices/"+ref, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		var response models.Vertex
+		json.Unmarshal(w.Body.Bytes(), &response)
+
+		if w.Code != http.StatusOK || response.ID != first.ID {
+			t.Errorf("Lookup by %s: expected vertex %s, got %d %s", ref, first.ID, w.Code, response.ID)
+		}
+	}
+}
+
+func TestUpdateVertex_KeepsSlug_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "orders", Name: "Order Service"})
+
+	// Zmiana nazwy nie zmienia sluga - linki pozostają stabilne
+	jsonValue, _ := json.Marshal(models.Vertex{Name: "Orders v2"})
+	req, _ := http.NewRequest("PUT", "/api/vertices/order-service", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	vertex, _ := s.GetVertexByID("orders")
+	if vertex.Name != "Orders v2" || vertex.Slug != "order-service" {
+		t.Errorf("Expected renamed vertex with original slug, got %+v", vertex)
+	}
+}
+
+func TestCreateVertex_Kind_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	body := `{"name": "Orders DB", "kind": "Database", "metadata": {"engine": "postgres", "version": "16"}}`
+	req, _ := http.NewRequest("POST", "/api/vertices", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var response models.Vertex
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response.Kind != "database" || response.Metadata["engine"] != "postgres" {
+		t.Errorf("Unexpected vertex: %+v", response)
+	}
+
+	// Wierzchołek bez rodzaju jest serwisem
+	jsonValue, _ := json.Marshal(models.Vertex{Name: "Order Service"})
+	req, _ = http.NewRequest("POST", "/api/vertices", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response.Kind != models.KindService {
+		t.Errorf("Expected default kind %s, got %s", models.KindService, response.Kind)
+	}
+}
+
+func TestUpdateVertex_KeepsKind_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "orders-db", Name: "Orders DB", Kind: "database", Metadata: models.JSONMap{"engine": "postgres"}})
+
+	// PUT bez rodzaju zachowuje dotychczasowy rodzaj
+	body := `{"name": "Orders DB", "metadata": {"engine": "mysql"}}`
+	req, _ := http.NewRequest("PUT", "/api/vertices/orders-db", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	vertex, _ := s.GetVertexByID("orders-db")
+	if vertex.Kind != "database" || vertex.Metadata["engine"] != "mysql" {
+		t.Errorf("Unexpected vertex after update: %+v", vertex)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestCreateVertex_NegativeProcessingTime_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("POST", "/api/vertices", bytes.NewBufferString(`{"name": "Orders", "processing_ms": -5}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+}
+
+func TestCreateVertex_InvalidSLO_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("POST", "/api/vertices", bytes.NewBufferString(`{"name": "Orders", "availability_slo": 100.5}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+
+	"microservice_overview/models"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VertexKindHandler obsługuje żądania związane z rejestrem rodzajów wierzchołków
+type VertexKindHandler struct {
+	storage storage.Storage
+}
+
+// NewVertexKindHandler tworzy nowy VertexKindHandler
+func NewVertexKindHandler(s storage.Storage) *VertexKindHandler {
+	return &VertexKindHandler{storage: s}
+}
+
+// GetAllVertexKinds zwraca rejestr rodzajów wierzchołków
+func (h *VertexKindHandler) GetAllVertexKinds(c *gin.Context) {
+	kinds, err := h.storage.GetAllVertexKinds()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, kinds)
+}
+
+// GetVertexKind zwraca rodzaj wierzchołka po nazwie
+func (h *VertexKindHandler) GetVertexKind(c *gin.Context) {
+	kind, err := h.storage.GetVertexKind(c.Param("name"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, kind)
+}
+
+// CreateVertexKind dodaje rodzaj wierzchołka do rejestru
+func (h *VertexKindHandler) CreateVertexKind(c *gin.Context) {
+	var kind models.VertexKind
+	if err := c.ShouldBindJSON(&kind); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if kind.Name == "" {
+		respondProblem(c, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	if err := h.storage.CreateVertexKind(&kind); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, kind)
+}
+
+// UpdateVertexKind aktualizuje rodzaj wierzchołka (nazwa jest niezmienna)
+func (h *VertexKindHandler) UpdateVertexKind(c *gin.Context) {
+	current, err := h.storage.GetVertexKind(c.Param("name"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	var kind models.VertexKind
+	if err := c.ShouldBindJSON(&kind); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	kind.Name = current.Name
+
+	if err := h.storage.UpdateVertexKind(&kind); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, kind)
+}
+
+// DeleteVertexKind usuwa rodzaj wierzchołka, jeśli żaden wierzchołek go nie używa
+func (h *VertexKindHandler) DeleteVertexKind(c *gin.Context) {
+	current, err := h.storage.GetVertexKind(c.Param("name"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := h.storage.DeleteVertexKind(current.Name); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "vertex kind deleted"})
+}
@@ -0,0 +1,177 @@
+package vertex_kind_integration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"microservice_overview/handlers"
+	"microservice_overview/models"
+	"microservice_overview/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupTestRouter() (*gin.Engine, storage.Storage) {
+	gin.SetMode(gin.TestMode)
+
+	// Ustaw tryb developerski dla testów
+	os.Setenv("DEV_MODE", "true")
+
+	// Utwórz storage z bazą w pamięci
+	s, err := storage.NewStorage()
+	if err != nil {
+		os.Unsetenv("DEV_MODE")
+		panic("failed to create storage: " + err.Error())
+	}
+
+	// Utwórz router
+	r := gin.New()
+	vertexKindHandler := handlers.NewVertexKindHandler(s)
+
+	api := r.Group("/api")
+	{
+		api.GET("/vertex-kinds", vertexKindHandler.GetAllVertexKinds)
+		api.GET("/vertex-kinds/:name", vertexKindHandler.GetVertexKind)
+		api.POST("/vertex-kinds", vertexKindHandler.CreateVertexKind)
+		api.PUT("/vertex-kinds/:name", vertexKindHandler.UpdateVertexKind)
+		api.DELETE("/vertex-kinds/:name", vertexKindHandler.DeleteVertexKind)
+	}
+
+	return r, s
+}
+
+func TestGetAllVertexKinds_DefaultRegistry_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("GET", "/api/vertex-kinds", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var kinds []models.VertexKind
+	json.Unmarshal(w.Body.Bytes(), &kinds)
+
+	byName := make(map[string]models.VertexKind)
+	for _, kind := range kinds {
+		byName[kind.Name] = kind
+	}
+	for _, name := range []string{"service", "database", "queue", "external_api", "team"} {
+		if _, ok := byName[name]; !ok {
+			t.Errorf("Expected default kind %s", name)
+		}
+	}
+	if !byName["team"].Container {
+		t.Error("Expected team to be a container kind")
+	}
+	if byName["database"].Shape != "database" {
+		t.Errorf("Expected database shape, got %s", byName["database"].Shape)
+	}
+}
+
+func TestCreateVertexKind_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	body := `{
+		"name": "Cache",
+		"description": "Pamięć podręczna",
+		"shape": "circle",
+		"color": "#AF7AC5",
+		"metadata_schema": [{"name": "engine", "type": "string", "required": true}]
+	}`
+	req, _ := http.NewRequest("POST", "/api/vertex-kinds", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	req, _ = http.NewRequest("GET", "/api/vertex-kinds/cache", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var kind models.VertexKind
+	json.Unmarshal(w.Body.Bytes(), &kind)
+
+	if kind.Name != "cache" || len(kind.MetadataSchema) != 1 || !kind.MetadataSchema[0].Required {
+		t.Errorf("Unexpected vertex kind: %+v", kind)
+	}
+}
+
+func TestCreateVertexKind_Validation_Integration(t *testing.T) {
+	r, _ := setupTestRouter()
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "missing name", body: `{"description": "x"}`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid field type", body: `{"name": "x", "metadata_schema": [{"name": "a", "type": "date"}]}`, expectedStatus: http.StatusBadRequest},
+		{name: "duplicate field", body: `{"name": "x", "metadata_schema": [{"name": "a", "type": "string"}, {"name": "a", "type": "number"}]}`, expectedStatus: http.StatusBadRequest},
+		{name: "duplicate", body: `{"name": "Database"}`, expectedStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/api/vertex-kinds", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpdateVertexKind_ContainerWithEdges_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "v1", Name: "Vertex 1"})
+	s.CreateVertex(&models.Vertex{ID: "v2", Name: "Vertex 2"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "v1", To: "v2", Type: "calls"})
+
+	req, _ := http.NewRequest("PUT", "/api/vertex-kinds/service", bytes.NewBufferString(`{"container": true}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+}
+
+func TestDeleteVertexKind_InUse_Integration(t *testing.T) {
+	r, s := setupTestRouter()
+
+	s.CreateVertex(&models.Vertex{ID: "q1", Name: "Orders topic", Kind: "queue"})
+
+	req, _ := http.NewRequest("DELETE", "/api/vertex-kinds/queue", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", http.StatusConflict, w.Code)
+	}
+
+	req, _ = http.NewRequest("DELETE", "/api/vertex-kinds/team", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+}
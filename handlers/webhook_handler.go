@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"microservice_overview/models"
+	"microservice_overview/storage"
+	"microservice_overview/webhooks"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Parametry logu dostarczeń
+const (
+	defaultDeliveriesLimit = 50
+	maxDeliveriesLimit     = 500
+)
+
+// WebhookHandler obsługuje żądania związane z webhookami
+type WebhookHandler struct {
+	storage    storage.Storage
+	dispatcher *webhooks.Dispatcher
+}
+
+// NewWebhookHandler tworzy nowy WebhookHandler
+func NewWebhookHandler(s storage.Storage, dispatcher *webhooks.Dispatcher) *WebhookHandler {
+	return &WebhookHandler{storage: s, dispatcher: dispatcher}
+}
+
+// GetAllWebhooks zwraca wszystkie webhooki (bez sekretów)
+func (h *WebhookHandler) GetAllWebhooks(c *gin.Context) {
+	all, err := h.storage.GetAllWebhooks()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	redacted := make([]models.Webhook, len(all))
+	for i := range all {
+		redacted[i] = all[i].Redacted()
+	}
+	c.JSON(http.StatusOK, redacted)
+}
+
+// GetWebhookByID zwraca webhook po ID (bez sekretu)
+func (h *WebhookHandler) GetWebhookByID(c *gin.Context) {
+	webhook, err := h.storage.GetWebhookByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, webhook.Redacted())
+}
+
+// CreateWebhook rejestruje webhook
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var webhook models.Webhook
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.dispatcher.Validate(&webhook); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.storage.CreateWebhook(&webhook); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	h.dispatcher.Invalidate()
+
+	c.JSON(http.StatusCreated, webhook.Redacted())
+}
+
+// UpdateWebhook zastępuje definicję webhooka. Pominięty sekret pozostaje
+// bez zmian
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	current, err := h.storage.GetWebhookByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	var webhook models.Webhook
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	webhook.ID = current.ID
+
+	if err := h.dispatcher.Validate(&webhook); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.storage.UpdateWebhook(&webhook); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	h.dispatcher.Invalidate()
+
+	c.JSON(http.StatusOK, webhook.Redacted())
+}
+
+// DeleteWebhook usuwa webhook wraz z logiem dostarczeń
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	current, err := h.storage.GetWebhookByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := h.storage.DeleteWebhook(current.ID); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	h.dispatcher.Invalidate()
+
+	c.JSON(http.StatusOK, gin.H{"message": "webhook deleted"})
+}
+
+// GetDeliveries zwraca log prób dostarczenia, od najnowszych
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	current, err := h.storage.GetWebhookByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	limit := defaultDeliveriesLimit
+	if raw := c.Query("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxDeliveriesLimit {
+			respondProblem(c, http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(maxDeliveriesLimit))
+			return
+		}
+	}
+
+	deliveries, err := h.storage.GetWebhookDeliveries(current.ID, limit)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// PingWebhook wysyła zdarzenie testowe; wynik pojawia się w logu dostarczeń
+// pod zwróconym delivery_id. Działa także dla wyłączonego webhooka
+func (h *WebhookHandler) PingWebhook(c *gin.Context) {
+	current, err := h.storage.GetWebhookByID(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	deliveryID := h.dispatcher.Ping(*current)
+	c.JSON(http.StatusAccepted, gin.H{"delivery_id": deliveryID})
+}
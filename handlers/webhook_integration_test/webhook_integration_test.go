@@ -0,0 +1,202 @@
+package webhook_integration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"microservice_overview/events"
+	"microservice_overview/handlers"
+	"microservice_overview/models"
+	"microservice_overview/storage"
+	"microservice_overview/webhooks"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupTestRouter() (*gin.Engine, storage.Storage, *webhooks.Dispatcher) {
+	gin.SetMode(gin.TestMode)
+
+	// Ustaw tryb developerski dla testów
+	os.Setenv("DEV_MODE", "true")
+
+	// Utwórz storage z bazą w pamięci
+	s, err := storage.NewStorage()
+	if err != nil {
+		os.Unsetenv("DEV_MODE")
+		panic("failed to create storage: " + err.Error())
+	}
+
+	dispatcher := webhooks.NewDispatcher(s, events.NewBus())
+	dispatcher.Backoff = time.Millisecond
+	dispatcher.AllowLocalTargets = true // Odbiorcy testowi działają na 127.0.0.1
+
+	// Utwórz router
+	r := gin.New()
+	webhookHandler := handlers.NewWebhookHandler(s, dispatcher)
+
+	api := r.Group("/api")
+	{
+		api.GET("/webhooks", webhookHandler.GetAllWebhooks)
+		api.GET("/webhooks/:id", webhookHandler.GetWebhookByID)
+		api.POST("/webhooks", webhookHandler.CreateWebhook)
+		api.PUT("/webhooks/:id", webhookHandler.UpdateWebhook)
+		api.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)
+		api.GET("/webhooks/:id/deliveries", webhookHandler.GetDeliveries)
+		api.POST("/webhooks/:id/ping", webhookHandler.PingWebhook)
+	}
+
+	return r, s, dispatcher
+}
+
+func doRequest(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateWebhook_Integration(t *testing.T) {
+	r, s, _ := setupTestRouter()
+
+	w := doRequest(r, "POST", "/api/webhooks", `{
+		"url": "https://hooks.example.com/overview",
+		"description": "Notify the catalog",
+		"events": ["edge.*", "vertex.deleted"],
+		"secret": "s3cret"
+	}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var created map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &created)
+	if created["id"] == "" || created["has_secret"] != true {
+		t.Errorf("Expected an ID and has_secret=true, got %v", created)
+	}
+	if _, ok := created["secret"]; ok {
+		t.Error("Expected the secret not to be returned")
+	}
+
+	stored, _ := s.GetWebhookByID(created["id"].(string))
+	if stored == nil || stored.Secret != "s3cret" {
+		t.Errorf("Expected the secret to be stored, got %+v", stored)
+	}
+}
+
+func TestCreateWebhook_Invalid(t *testing.T) {
+	r, _, _ := setupTestRouter()
+
+	for _, body := range []string{
+		`{"url": "not a url"}`,
+		`{"url": "ftp://example.com"}`,
+		`{"url": "https://example.com", "events": ["edge.renamed"]}`,
+	} {
+		if w := doRequest(r, "POST", "/api/webhooks", body); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for %s, got %d", http.StatusBadRequest, body, w.Code)
+		}
+	}
+}
+
+func TestUpdateWebhook_KeepsSecret(t *testing.T) {
+	r, s, _ := setupTestRouter()
+	s.CreateWebhook(&models.Webhook{ID: "hook", URL: "https://example.com/a", Secret: "s3cret"})
+
+	w := doRequest(r, "PUT", "/api/webhooks/hook", `{"url": "https://example.com/b", "disabled": true}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	stored, _ := s.GetWebhookByID("hook")
+	if stored.URL != "https://example.com/b" || !stored.Disabled || stored.Secret != "s3cret" {
+		t.Errorf("Expected the URL to change and the secret to stay, got %+v", stored)
+	}
+
+	if w := doRequest(r, "PUT", "/api/webhooks/missing", `{"url": "https://example.com"}`); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestGetAllWebhooks_Redacted(t *testing.T) {
+	r, s, _ := setupTestRouter()
+	s.CreateWebhook(&models.Webhook{URL: "https://example.com/a", Secret: "s3cret"})
+	s.CreateWebhook(&models.Webhook{URL: "https://example.com/b"})
+
+	w := doRequest(r, "GET", "/api/webhooks", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if bytes.Contains(w.Body.Bytes(), []byte("s3cret")) {
+		t.Error("Expected secrets to be redacted")
+	}
+
+	var all []models.Webhook
+	json.Unmarshal(w.Body.Bytes(), &all)
+	if len(all) != 2 || !all[0].HasSecret || all[1].HasSecret {
+		t.Errorf("Expected two webhooks with has_secret set accordingly, got %+v", all)
+	}
+}
+
+func TestPingWebhook_RecordsDeliveries(t *testing.T) {
+	r, s, dispatcher := setupTestRouter()
+
+	received := make(chan *http.Request, 4)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		received <- req
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+	s.CreateWebhook(&models.Webhook{ID: "hook", URL: receiver.URL, Secret: "s3cret"})
+
+	w := doRequest(r, "POST", "/api/webhooks/hook/ping", "")
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusAccepted, w.Code, w.Body.String())
+	}
+	var response map[string]string
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	select {
+	case req := <-received:
+		if req.Header.Get(webhooks.HeaderEvent) != webhooks.EventPing || req.Header.Get(webhooks.HeaderSignature) == "" {
+			t.Errorf("Expected a signed ping, got headers %v", req.Header)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the ping")
+	}
+	dispatcher.Wait()
+
+	w = doRequest(r, "GET", "/api/webhooks/hook/deliveries", "")
+	var deliveries []models.WebhookDelivery
+	json.Unmarshal(w.Body.Bytes(), &deliveries)
+	if len(deliveries) != 1 || deliveries[0].DeliveryID != response["delivery_id"] || !deliveries[0].Success || deliveries[0].StatusCode != http.StatusNoContent {
+		t.Errorf("Expected the ping in the delivery log, got %+v", deliveries)
+	}
+
+	if w := doRequest(r, "GET", "/api/webhooks/hook/deliveries?limit=0", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d for an invalid limit, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := doRequest(r, "POST", "/api/webhooks/missing/ping", ""); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestDeleteWebhook_Integration(t *testing.T) {
+	r, s, _ := setupTestRouter()
+	s.CreateWebhook(&models.Webhook{ID: "hook", URL: "https://example.com"})
+	s.RecordWebhookDelivery(&models.WebhookDelivery{WebhookID: "hook", EventType: "ping", Attempt: 1})
+
+	if w := doRequest(r, "DELETE", "/api/webhooks/hook", ""); w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+	if w := doRequest(r, "GET", "/api/webhooks/hook", ""); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d after delete, got %d", http.StatusNotFound, w.Code)
+	}
+	if deliveries, _ := s.GetWebhookDeliveries("hook", 10); len(deliveries) != 0 {
+		t.Errorf("Expected the delivery log to be deleted, got %+v", deliveries)
+	}
+}
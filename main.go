@@ -1,10 +1,16 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net"
+	"os"
 
+	"microservice_overview/events"
+	"microservice_overview/grpcapi"
 	"microservice_overview/handlers"
 	"microservice_overview/storage"
+	"microservice_overview/webhooks"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,6 +22,37 @@ func main() {
 		log.Fatalf("Failed to initialize storage: %v", err)
 	}
 
+	// Zmiany wierzchołków i relacji trafiają na szynę zdarzeń niezależnie od API.
+	// Zdarzenia przechodzą przez tabelę w bazie, więc każda replika widzi też
+	// zapisy pozostałych (EVENTS_POLL_INTERVAL, EVENTS_RETENTION)
+	relayConfig, err := events.LoadRelayConfig()
+	if err != nil {
+		log.Fatalf("Invalid event relay configuration: %v", err)
+	}
+	bus := events.NewBus()
+	relay := events.NewRelay(s, bus)
+	relay.RelayConfig = relayConfig
+	s = events.NewOutboxStorage(s, relay)
+	go relay.Run(context.Background())
+
+	// Dostarczanie zdarzeń do zarejestrowanych webhooków (WEBHOOK_WORKERS,
+	// WEBHOOK_DELIVERY_RETENTION, WEBHOOK_ALLOW_LOCAL_TARGETS)
+	webhookConfig, err := webhooks.LoadConfig()
+	if err != nil {
+		log.Fatalf("Invalid webhook configuration: %v", err)
+	}
+	dispatcher := webhooks.NewDispatcher(s, bus)
+	dispatcher.Config = webhookConfig
+	go dispatcher.Run(context.Background())
+
+	// Powtórzenia żądań zapisu z nagłówkiem Idempotency-Key w oknie IDEMPOTENCY_TTL
+	idempotencyTTL, err := handlers.LoadIdempotencyTTL()
+	if err != nil {
+		log.Fatalf("Invalid idempotency configuration: %v", err)
+	}
+	idempotency := handlers.NewIdempotency(s, idempotencyTTL)
+	go idempotency.Run(context.Background())
+
 	// Inicjalizacja routera
 	r := gin.Default()
 
@@ -23,8 +60,9 @@ func main() {
 	r.Use(func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, If-Match, If-None-Match, Last-Event-ID, Idempotency-Key")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag, API-Version, Deprecation, Sunset, Link, Idempotent-Replayed")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -43,31 +81,40 @@ func main() {
 		c.HTML(200, "index.html", nil)
 	})
 
-	// Inicjalizacja handlerów
-	vertexHandler := handlers.NewVertexHandler(s)
-	edgeHandler := handlers.NewEdgeHandler(s)
-	graphHandler := handlers.NewGraphHandler(s)
+	// Dokumentacja API (Swagger UI na podstawie /api/v2/openapi.json)
+	r.GET("/docs", func(c *gin.Context) {
+		c.HTML(200, "swagger.html", nil)
+	})
+
+	// Wersje API - v1 to zamrożony kontrakt sprzed wersjonowania, wycofywany od
+	// wydania v2; API_V1_DEPRECATION i API_V1_SUNSET zmieniają daty wycofania
+	v1, err := handlers.LoadAPIVersion("v1", "/api/v2", handlers.V1Deprecation)
+	if err != nil {
+		log.Fatalf("Invalid API version configuration: %v", err)
+	}
+	v2 := handlers.APIVersion{Name: "v2"}
 
 	// API routes
-	api := r.Group("/api")
-	{
-		// Wierzchołki
-		api.GET("/vertices", vertexHandler.GetAllVertices)
-		api.GET("/vertices/:id", vertexHandler.GetVertexByID)
-		api.POST("/vertices", vertexHandler.CreateVertex)
-		api.PUT("/vertices/:id", vertexHandler.UpdateVertex)
-		api.DELETE("/vertices/:id", vertexHandler.DeleteVertex)
-
-		// Relacje
-		api.GET("/edges", edgeHandler.GetAllEdges)
-		api.GET("/edges/:id", edgeHandler.GetEdgeByID)
-		api.POST("/edges", edgeHandler.CreateEdge)
-		api.PUT("/edges/:id", edgeHandler.UpdateEdge)
-		api.DELETE("/edges/:id", edgeHandler.DeleteEdge)
-
-		// Graf
-		api.GET("/graph", graphHandler.GetGraph)
+	deps := handlers.Dependencies{Storage: s, Bus: bus, Dispatcher: dispatcher, Idempotency: idempotency}
+	if err := handlers.RegisterAPI(r, deps, v1, v2); err != nil {
+		log.Fatalf("Failed to register API routes: %v", err)
+	}
+
+	// Serwer gRPC
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+	listener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("Failed to listen on gRPC port: %v", err)
 	}
+	go func() {
+		log.Printf("gRPC server starting on :%s", grpcPort)
+		if err := grpcapi.NewGRPCServer(s, bus).Serve(listener); err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+	}()
 
 	// Uruchomienie serwera
 	log.Println("Server starting on :8080")
@@ -75,6 +122,3 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
-
-
-
@@ -0,0 +1,22 @@
+package models
+
+// VertexAvailability teoretyczna dostępność wierzchołka wynikająca z jego zależności
+type VertexAvailability struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	SLO              *float64 `json:"slo,omitempty"`         // Docelowa dostępność wierzchołka (%)
+	Composed         float64  `json:"composed"`              // Dostępność wierzchołka razem z twardymi zależnościami (%)
+	Achievable       float64  `json:"achievable"`            // Dostępność, jaką zapewniają same twarde zależności (%)
+	HardDependencies []string `json:"hard_dependencies"`     // Zależności, których awaria wyłącza wierzchołek (przechodnio)
+	SoftDependencies []string `json:"soft_dependencies"`     // Zależności osiągalne tylko przez relacje asynchroniczne lub z fallbackiem
+	UnknownSLO       []string `json:"unknown_slo,omitempty"` // Twarde zależności bez SLO - liczone jako 100%
+	Weakest          string   `json:"weakest,omitempty"`     // Twarda zależność o najniższym SLO
+	Unachievable     bool     `json:"unachievable"`          // SLO wyższe niż dostępność zapewniana przez zależności
+}
+
+// AvailabilityReport dostępność wszystkich wierzchołków; najpierw te z
+// nieosiągalnym SLO, potem od najniższej dostępności
+type AvailabilityReport struct {
+	Vertices     []VertexAvailability `json:"vertices"`
+	Unachievable []string             `json:"unachievable"` // ID wierzchołków z nieosiągalnym SLO
+}
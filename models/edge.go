@@ -8,12 +8,15 @@ import (
 
 // Edge reprezentuje relację między wierzchołkami
 type Edge struct {
-	ID        string    `json:"id" gorm:"primaryKey"`
-	From      string    `json:"from" gorm:"not null;index"`
-	To        string    `json:"to" gorm:"not null;index"`
-	Type      string    `json:"type,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string         `json:"id" gorm:"primaryKey"`
+	From      string         `json:"from" gorm:"not null;index"`
+	To        string         `json:"to" gorm:"not null;index"`
+	Type      string         `json:"type,omitempty"`
+	LatencyMs float64        `json:"latency_ms,omitempty"`              // Opóźnienie samego wywołania, np. sieć (ms)
+	Fallback  bool           `json:"fallback,omitempty"`                // Źródło obsługuje awarię celu - zależność jest miękka
+	Version   int64          `json:"version" gorm:"not null;default:1"` // Wersja do optymistycznej kontroli współbieżności (ETag)
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
@@ -21,5 +24,3 @@ type Edge struct {
 func (Edge) TableName() string {
 	return "edges"
 }
-
-
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// Kierunki zależności wyrażane przez typ relacji
+const (
+	DirectionForward       = "forward"       // from zależy od to (np. calls)
+	DirectionReverse       = "reverse"       // to zależy od from (np. publishes - konsument zależy od producenta)
+	DirectionBidirectional = "bidirectional" // zależność w obie strony
+)
+
+// Style linii używane przy rysowaniu relacji
+const (
+	StyleSolid  = "solid"
+	StyleDashed = "dashed"
+	StyleDotted = "dotted"
+)
+
+// EdgeType opisuje dozwolony typ relacji z katalogu
+type EdgeType struct {
+	Name               string     `json:"name" gorm:"primaryKey"`
+	Description        string     `json:"description,omitempty"`
+	Direction          string     `json:"direction" gorm:"not null;default:forward"`
+	Synchronous        bool       `json:"synchronous"`                                     // Czy wywołanie blokuje źródło (wpływa na opóźnienia i dostępność)
+	Color              string     `json:"color,omitempty"`                                 // Kolor linii, np. #2B7CE9
+	Style              string     `json:"style,omitempty"`                                 // solid, dashed lub dotted
+	AllowedSourceKinds StringList `json:"allowed_source_kinds,omitempty" gorm:"type:text"` // Pusta lista = dowolny rodzaj wierzchołka
+	AllowedTargetKinds StringList `json:"allowed_target_kinds,omitempty" gorm:"type:text"` // Pusta lista = dowolny rodzaj wierzchołka
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// TableName określa nazwę tabeli w bazie danych
+func (EdgeType) TableName() string {
+	return "edge_types"
+}
+
+// Dependency para: wierzchołek zależny i wierzchołek, od którego zależy
+type Dependency struct {
+	Dependent  string
+	Dependency string
+}
+
+// Dependencies zamienia relację na zależności zgodnie z kierunkiem typu.
+// Relacja bez typu (nil) traktowana jest jak forward.
+func (t *EdgeType) Dependencies(edge Edge) []Dependency {
+	direction := DirectionForward
+	if t != nil {
+		direction = t.Direction
+	}
+	switch direction {
+	case DirectionReverse:
+		return []Dependency{{Dependent: edge.To, Dependency: edge.From}}
+	case DirectionBidirectional:
+		return []Dependency{{Dependent: edge.From, Dependency: edge.To}, {Dependent: edge.To, Dependency: edge.From}}
+	default:
+		return []Dependency{{Dependent: edge.From, Dependency: edge.To}}
+	}
+}
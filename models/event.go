@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// EventRecord zdarzenie zmiany zapisane we wspólnej tabeli zdarzeń, z której
+// każda replika serwisu przekazuje zmiany swoim subskrybentom
+type EventRecord struct {
+	ID        int64     `json:"id" gorm:"primaryKey;autoIncrement"` // Numer zdarzenia, wspólny dla replik
+	Origin    string    `json:"origin" gorm:"not null"`             // Replika, która wykonała zapis
+	Payload   []byte    `json:"-" gorm:"not null"`                  // Zdarzenie w formacie JSON
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+// TableName określa nazwę tabeli w bazie danych
+func (EventRecord) TableName() string {
+	return "events"
+}
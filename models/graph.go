@@ -4,7 +4,6 @@ package models
 type Graph struct {
 	Vertices []Vertex `json:"vertices"`
 	Edges    []Edge   `json:"edges"`
-}
-
-
 
+	LayerViolations []LayerViolation `json:"layer_violations"` // Relacje łamiące model warstwowy
+}
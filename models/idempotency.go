@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// IdempotencyRecord zapamiętana odpowiedź na żądanie z nagłówkiem Idempotency-Key.
+// Rekord powstaje przed obsługą żądania (Status 0) i jest uzupełniany odpowiedzią.
+// Rezerwacja w toku blokuje klucz do LockedUntil - po awarii serwera klucz może
+// przejąć ponowienie
+type IdempotencyRecord struct {
+	Key         string    `json:"key" gorm:"primaryKey;column:idempotency_key"`
+	Fingerprint string    `json:"fingerprint" gorm:"not null"` // Metoda, ścieżka i skrót treści żądania
+	Status      int       `json:"status"`                      // Status odpowiedzi; 0 - żądanie w trakcie obsługi
+	ContentType string    `json:"content_type,omitempty"`
+	ETag        string    `json:"etag,omitempty"`
+	Body        []byte    `json:"-"`
+	Token       string    `json:"-"`            // Identyfikator rezerwacji - zapis odpowiedzi i zwolnienie dotyczą tylko własnej rezerwacji
+	LockedUntil time.Time `json:"locked_until"` // Koniec dzierżawy rezerwacji w toku
+	CreatedAt   time.Time `json:"created_at" gorm:"index"`
+}
+
+// TableName określa nazwę tabeli w bazie danych
+func (IdempotencyRecord) TableName() string {
+	return "idempotency_records"
+}
+
+// Completed mówi czy odpowiedź na żądanie została już zapisana
+func (r IdempotencyRecord) Completed() bool {
+	return r.Status != 0
+}
+
+// Abandoned mówi czy rezerwacja w toku straciła dzierżawę (np. serwer
+// obsługujący żądanie przestał działać)
+func (r IdempotencyRecord) Abandoned(now time.Time) bool {
+	return !r.Completed() && r.LockedUntil.Before(now)
+}
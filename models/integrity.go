@@ -0,0 +1,56 @@
+package models
+
+// Typy naruszeń spójności grafu
+const (
+	IssueDanglingEdge   = "dangling_edge"          // relacja wskazuje na nieistniejący wierzchołek
+	IssueEdgeToDeleted  = "edge_to_deleted_vertex" // relacja wskazuje na usunięty (soft delete) wierzchołek
+	IssueEdgeOnNonLeaf  = "edge_on_non_leaf"       // relacja podpięta do wierzchołka, który ma dzieci
+	IssueOrphanedParent = "orphaned_parent"        // parent_id wskazuje na nieistniejący lub usunięty wierzchołek
+	IssueHierarchyCycle = "hierarchy_cycle"        // cykl w hierarchii parent_id
+	IssueDuplicateEdge  = "duplicate_edge"         // kolejna relacja o tych samych from, to i type
+)
+
+// Strategie naprawy grafu. Błędne relacje są zawsze usuwane, strategia
+// decyduje o tym, co dzieje się z osieroconymi wierzchołkami.
+const (
+	RepairStrategyDetach = "detach" // osierocone wierzchołki trafiają na najwyższy poziom
+	RepairStrategyDelete = "delete" // osierocone wierzchołki są usuwane razem z poddrzewem
+)
+
+// Rodzaje zmian wykonywanych podczas naprawy
+const (
+	RepairActionDeleteEdge   = "delete_edge"
+	RepairActionDetachVertex = "detach_vertex"
+	RepairActionDeleteVertex = "delete_vertex"
+)
+
+// IntegrityIssue opisuje pojedyncze naruszenie reguł spójności grafu
+type IntegrityIssue struct {
+	Type     string   `json:"type"`
+	VertexID string   `json:"vertex_id,omitempty"`
+	EdgeID   string   `json:"edge_id,omitempty"`
+	Related  []string `json:"related,omitempty"` // np. wierzchołki tworzące cykl lub ID duplikowanej relacji
+	Message  string   `json:"message"`
+}
+
+// IntegrityReport wynik sprawdzenia spójności grafu
+type IntegrityReport struct {
+	Valid  bool             `json:"valid"`
+	Issues []IntegrityIssue `json:"issues"`
+}
+
+// RepairAction pojedyncza zmiana wykonana podczas naprawy grafu
+type RepairAction struct {
+	Action   string         `json:"action"`
+	VertexID string         `json:"vertex_id,omitempty"`
+	EdgeID   string         `json:"edge_id,omitempty"`
+	Issue    IntegrityIssue `json:"issue"`
+}
+
+// RepairReport wynik naprawy grafu
+type RepairReport struct {
+	Strategy  string           `json:"strategy"`
+	DryRun    bool             `json:"dry_run"`
+	Actions   []RepairAction   `json:"actions"`
+	Remaining []IntegrityIssue `json:"remaining"`
+}
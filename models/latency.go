@@ -0,0 +1,34 @@
+package models
+
+// PathStep krok ścieżki krytycznej: wierzchołek i relacja, którą do niego dotarto
+type PathStep struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	EdgeID       string  `json:"edge_id,omitempty"` // Relacja prowadząca do wierzchołka (pusta dla punktu wejścia)
+	LatencyMs    float64 `json:"latency_ms"`        // Opóźnienie tej relacji
+	ProcessingMs float64 `json:"processing_ms"`     // Czas obsługi w wierzchołku
+	ArrivalMs    float64 `json:"arrival_ms"`        // Czas od wejścia do dotarcia żądania do wierzchołka
+}
+
+// VertexLatency opóźnienia wierzchołka osiągalnego synchronicznie z punktu wejścia
+type VertexLatency struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	ProcessingMs float64  `json:"processing_ms"`
+	ArrivalMs    float64  `json:"arrival_ms"`         // Najgorszy czas dotarcia żądania do wierzchołka
+	LatencyMs    float64  `json:"latency_ms"`         // Najgorszy czas odpowiedzi wierzchołka wraz z jego wywołaniami
+	Critical     bool     `json:"critical"`           // Czy leży na ścieżce krytycznej
+	SlackMs      *float64 `json:"slack_ms,omitempty"` // Opóźnienie, które można dodać w poddrzewie wierzchołka bez przekroczenia budżetu
+}
+
+// LatencyReport najgorsze opóźnienie synchroniczne od punktu wejścia
+type LatencyReport struct {
+	Entrypoint    string          `json:"entrypoint"`
+	TotalMs       float64         `json:"total_ms"`
+	BudgetMs      *float64        `json:"budget_ms,omitempty"`
+	WithinBudget  *bool           `json:"within_budget,omitempty"`
+	RemainingMs   *float64        `json:"remaining_ms,omitempty"` // Budżet minus total_ms
+	CriticalPath  []PathStep      `json:"critical_path"`
+	CriticalEdges []string        `json:"critical_edges"`
+	Vertices      []VertexLatency `json:"vertices"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Rodzaje naruszeń architektury warstwowej
+const (
+	LayerViolationUpward = "upward" // warstwa zależy od warstwy położonej wyżej
+	LayerViolationSkip   = "skip"   // zależność pomija co najmniej jedną warstwę pośrednią
+)
+
+// Layer warstwa architektury. Warstwy są uporządkowane rosnąco po Rank -
+// najwyżej (najbliżej klienta) jest warstwa o najmniejszym Rank.
+type Layer struct {
+	Name        string    `json:"name" gorm:"primaryKey"`
+	Rank        int       `json:"rank" gorm:"not null;uniqueIndex"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName określa nazwę tabeli w bazie danych
+func (Layer) TableName() string {
+	return "layers"
+}
+
+// LayerViolation zależność łamiąca model warstwowy
+type LayerViolation struct {
+	Type       string `json:"type"` // upward lub skip
+	EdgeID     string `json:"edge_id"`
+	Dependent  string `json:"dependent"`  // ID wierzchołka zależnego
+	Dependency string `json:"dependency"` // ID wierzchołka, od którego zależy
+	FromLayer  string `json:"from_layer"`
+	ToLayer    string `json:"to_layer"`
+	Message    string `json:"message"`
+}
@@ -0,0 +1,31 @@
+package models
+
+// VertexMetrics miary pojedynczego wierzchołka w grafie zależności
+type VertexMetrics struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	FanIn       int     `json:"fan_in"`      // Ca - liczba wierzchołków zależnych od tego wierzchołka
+	FanOut      int     `json:"fan_out"`     // Ce - liczba wierzchołków, od których ten wierzchołek zależy
+	Betweenness float64 `json:"betweenness"` // Znormalizowana centralność pośrednictwa (0-1)
+	PageRank    float64 `json:"pagerank"`    // Ważność wg PageRank na grafie zależności (suma = 1)
+	Instability float64 `json:"instability"` // Ce / (Ca + Ce); 0 = stabilny, 1 = niestabilny
+	Depth       int     `json:"depth"`       // Długość najdłuższego łańcucha zależnych prowadzącego do wierzchołka (0 = nikt nie zależy)
+	InCycle     bool    `json:"in_cycle"`    // Czy wierzchołek leży na cyklu zależności
+}
+
+// GraphStats statystyki całego grafu zależności
+type GraphStats struct {
+	Vertices     int      `json:"vertices"`     // Liczba wierzchołków (liści hierarchii)
+	Dependencies int      `json:"dependencies"` // Liczba różnych zależności
+	Density      float64  `json:"density"`      // Dependencies / (n * (n - 1))
+	LongestPath  int      `json:"longest_path"` // Długość (w zależnościach) najdłuższego łańcucha; cykle liczone jako jeden węzeł
+	LongestChain []string `json:"longest_chain"`
+	Components   int      `json:"components"` // Liczba słabo spójnych składowych
+	Cycles       int      `json:"cycles"`     // Liczba cykli zależności (silnie spójnych składowych z więcej niż jednym wierzchołkiem)
+}
+
+// GraphMetrics raport miar grafu; wierzchołki posortowane malejąco po centralności
+type GraphMetrics struct {
+	Stats    GraphStats      `json:"stats"`
+	Vertices []VertexMetrics `json:"vertices"`
+}
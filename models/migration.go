@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// Migration wykonana migracja danych (zob. storage.migrations)
+type Migration struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// TableName określa nazwę tabeli w bazie danych
+func (Migration) TableName() string {
+	return "schema_migrations"
+}
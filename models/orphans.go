@@ -0,0 +1,17 @@
+package models
+
+// OrphanVertex wierzchołek zgłoszony w raporcie osieroconych
+type OrphanVertex struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Kind     string  `json:"kind"`
+	ParentID *string `json:"parent_id,omitempty"`
+}
+
+// OrphanReport kandydaci na "zombie" - wierzchołki, które nie biorą udziału w ruchu
+type OrphanReport struct {
+	Entrypoints     []string       `json:"entrypoints"`      // Liście uznane za punkty wejścia
+	Isolated        []OrphanVertex `json:"isolated"`         // Liście bez żadnych relacji
+	Unreachable     []OrphanVertex `json:"unreachable"`      // Liście z relacjami, nieosiągalne z punktów wejścia
+	EmptyContainers []OrphanVertex `json:"empty_containers"` // Wierzchołki rodzajów grupujących bez dzieci
+}
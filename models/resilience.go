@@ -0,0 +1,24 @@
+package models
+
+// ArticulationPoint wierzchołek, którego awaria rozspójnia graf zależności
+type ArticulationPoint struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Separated   [][]string `json:"separated"`   // Grupy wierzchołków odcięte od reszty systemu po awarii
+	Unreachable int        `json:"unreachable"` // Łączna liczba odciętych wierzchołków
+}
+
+// Bridge zależność, której zerwanie rozspójnia graf zależności
+type Bridge struct {
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	EdgeIDs   []string `json:"edge_ids"`  // Relacje wyrażające tę zależność
+	Separated []string `json:"separated"` // Wierzchołki odcięte od reszty systemu po zerwaniu
+}
+
+// ResilienceReport pojedyncze punkty awarii w grafie zależności; najpierw
+// te, których awaria odcina najwięcej wierzchołków
+type ResilienceReport struct {
+	ArticulationPoints []ArticulationPoint `json:"articulation_points"`
+	Bridges            []Bridge            `json:"bridges"`
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Efekty reguł architektury
+const (
+	RuleEffectDeny      = "deny"       // zależność od wierzchołków pasujących do target jest zabroniona
+	RuleEffectAllowOnly = "allow_only" // wierzchołki pasujące do source mogą zależeć tylko od pasujących do target
+)
+
+// Poziomy ważności reguł
+const (
+	RuleSeverityError   = "error"   // naruszenie blokuje zapis relacji
+	RuleSeverityWarning = "warning" // naruszenie jest tylko raportowane
+)
+
+// RuleSelector wybiera wierzchołki, których dotyczy reguła. Puste pola nie
+// zawężają wyboru - pusty selektor pasuje do każdego wierzchołka.
+type RuleSelector struct {
+	Kind     string  `json:"kind,omitempty"`     // Rodzaj wierzchołka
+	Domain   string  `json:"domain,omitempty"`   // ID lub slug wierzchołka-domeny; pasuje on sam i całe jego poddrzewo
+	Metadata JSONMap `json:"metadata,omitempty"` // Wymagane wartości metadanych, np. {"tier": 1}
+}
+
+// Value zapisuje selektor jako JSON
+func (s RuleSelector) Value() (driver.Value, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan odczytuje selektor zapisany jako JSON
+func (s *RuleSelector) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*s = RuleSelector{}
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("cannot scan %T into RuleSelector", value)
+	}
+	if len(data) == 0 {
+		*s = RuleSelector{}
+		return nil
+	}
+	return json.Unmarshal(data, s)
+}
+
+// ArchitectureRule reguła architektury (fitness function) sprawdzana dla
+// każdej zależności: source to wierzchołek zależny, target - ten, od którego zależy
+type ArchitectureRule struct {
+	ID          string       `json:"id" gorm:"primaryKey"`
+	Name        string       `json:"name" gorm:"not null"`
+	Description string       `json:"description,omitempty"`
+	Effect      string       `json:"effect" gorm:"not null"`   // deny lub allow_only
+	Severity    string       `json:"severity" gorm:"not null"` // error lub warning
+	Source      RuleSelector `json:"source" gorm:"type:text"`
+	Target      RuleSelector `json:"target" gorm:"type:text"`
+	EdgeTypes   StringList   `json:"edge_types,omitempty" gorm:"type:text"` // Pusta lista = wszystkie typy relacji
+	Disabled    bool         `json:"disabled"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// TableName określa nazwę tabeli w bazie danych
+func (ArchitectureRule) TableName() string {
+	return "architecture_rules"
+}
+
+// RuleViolation pojedyncze naruszenie reguły przez zależność wyrażoną relacją
+type RuleViolation struct {
+	RuleID     string `json:"rule_id"`
+	RuleName   string `json:"rule_name"`
+	Severity   string `json:"severity"`
+	EdgeID     string `json:"edge_id,omitempty"`
+	Dependent  string `json:"dependent"`  // ID wierzchołka zależnego
+	Dependency string `json:"dependency"` // ID wierzchołka, od którego zależy
+	Message    string `json:"message"`
+}
+
+// RuleReport wynik sprawdzenia reguł architektury na całym grafie
+type RuleReport struct {
+	Valid      bool            `json:"valid"` // brak naruszeń o ważności error
+	Errors     int             `json:"errors"`
+	Warnings   int             `json:"warnings"`
+	Violations []RuleViolation `json:"violations"`
+}
@@ -6,15 +6,23 @@ import (
 	"gorm.io/gorm"
 )
 
-// Vertex reprezentuje wierzchołek grafu (mikroserwis)
+// Vertex reprezentuje wierzchołek grafu (mikroserwis, bazę danych, kolejkę, zespół...)
 type Vertex struct {
-	ID          string         `json:"id" gorm:"primaryKey"`
-	Name        string         `json:"name" gorm:"not null"`
-	Description string         `json:"description,omitempty"`
-	ParentID    *string        `json:"parent_id,omitempty" gorm:"index"` // ID rodzica (null = root)
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID              string         `json:"id" gorm:"primaryKey"`
+	Name            string         `json:"name" gorm:"not null"`
+	Slug            string         `json:"slug" gorm:"index"` // Unikalny (indeks zakłada migracja danych), bezpieczny w URL identyfikator wyprowadzony z nazwy
+	Description     string         `json:"description,omitempty"`
+	Kind            string         `json:"kind" gorm:"not null;default:service;index"` // Rodzaj z rejestru /api/vertex-kinds
+	Metadata        JSONMap        `json:"metadata,omitempty" gorm:"type:text"`        // Dane zgodne ze schematem metadanych rodzaju
+	Layer           string         `json:"layer,omitempty" gorm:"index"`               // Warstwa architektury; pusta = dziedziczona po rodzicu
+	ParentID        *string        `json:"parent_id,omitempty" gorm:"index"`           // ID rodzica (null = root)
+	Entrypoint      bool           `json:"entrypoint,omitempty"`                       // Punkt wejścia ruchu (ingress, gateway); dotyczy też całego poddrzewa
+	AvailabilitySLO float64        `json:"availability_slo,omitempty"`                 // Docelowa dostępność w procentach, np. 99.95 (0 = nieokreślona)
+	ProcessingMs    float64        `json:"processing_ms,omitempty"`                    // Czas obsługi żądania w samym wierzchołku (ms)
+	Version         int64          `json:"version" gorm:"not null;default:1"`          // Wersja do optymistycznej kontroli współbieżności (ETag)
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 // TableName określa nazwę tabeli w bazie danych
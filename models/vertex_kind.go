@@ -0,0 +1,79 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Typy wartości dopuszczalne w schemacie metadanych
+const (
+	FieldTypeString  = "string"
+	FieldTypeNumber  = "number"
+	FieldTypeBoolean = "boolean"
+)
+
+// KindService rodzaj nadawany wierzchołkom bez jawnie podanego rodzaju
+const KindService = "service"
+
+// MetadataField opis pojedynczego pola metadanych wierzchołka danego rodzaju
+type MetadataField struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"` // string, number lub boolean
+	Required    bool   `json:"required,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// MetadataSchema lista pól metadanych przechowywana w bazie jako tablica JSON
+type MetadataSchema []MetadataField
+
+// Value zapisuje schemat jako JSON
+func (s MetadataSchema) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]MetadataField(s))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan odczytuje schemat zapisany jako JSON
+func (s *MetadataSchema) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("cannot scan %T into MetadataSchema", value)
+	}
+	if len(data) == 0 {
+		*s = nil
+		return nil
+	}
+	return json.Unmarshal(data, (*[]MetadataField)(s))
+}
+
+// VertexKind opisuje rodzaj wierzchołka z rejestru (serwis, baza danych, kolejka...)
+type VertexKind struct {
+	Name           string         `json:"name" gorm:"primaryKey"`
+	Description    string         `json:"description,omitempty"`
+	Shape          string         `json:"shape,omitempty"`                            // Kształt na wizualizacji (nazwa kształtu vis.js, np. box, database)
+	Color          string         `json:"color,omitempty"`                            // Kolor wypełnienia, np. #97C2FC
+	Container      bool           `json:"container"`                                  // Logiczne grupowanie (np. zespół) - nie może mieć relacji
+	MetadataSchema MetadataSchema `json:"metadata_schema,omitempty" gorm:"type:text"` // Dozwolone pola metadanych
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// TableName określa nazwę tabeli w bazie danych
+func (VertexKind) TableName() string {
+	return "vertex_kinds"
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Webhook subskrypcja zmian grafu wysyłanych na zewnętrzny adres
+type Webhook struct {
+	ID          string     `json:"id" gorm:"primaryKey"`
+	URL         string     `json:"url" gorm:"not null"`
+	Description string     `json:"description,omitempty"`
+	Events      StringList `json:"events,omitempty" gorm:"type:text"` // Np. edge.created, vertex.*; pusta lista = wszystkie zdarzenia
+	Secret      string     `json:"secret,omitempty"`                  // Klucz podpisu HMAC; nigdy nie jest zwracany przez API
+	HasSecret   bool       `json:"has_secret" gorm:"-"`
+	Disabled    bool       `json:"disabled"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName określa nazwę tabeli w bazie danych
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// Redacted zwraca kopię bez sekretu, do wysłania w odpowiedzi API
+func (w Webhook) Redacted() Webhook {
+	w.HasSecret = w.Secret != ""
+	w.Secret = ""
+	return w
+}
+
+// WebhookDelivery pojedyncza próba dostarczenia zdarzenia do webhooka
+type WebhookDelivery struct {
+	ID         string    `json:"id" gorm:"primaryKey"`
+	WebhookID  string    `json:"webhook_id" gorm:"not null;index"`
+	DeliveryID string    `json:"delivery_id" gorm:"index"` // Wspólny dla kolejnych prób tego samego zdarzenia
+	EventID    int64     `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	Attempt    int       `json:"attempt"` // Numer próby, od 1
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Success    bool      `json:"success"`
+	DurationMs float64   `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName określa nazwę tabeli w bazie danych
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
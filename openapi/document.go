@@ -0,0 +1,103 @@
+// Package openapi buduje specyfikację OpenAPI 3 z tras zarejestrowanych
+// w routerze gin i sprawdza zgodność przychodzących żądań ze specyfikacją.
+package openapi
+
+// Version wersja OpenAPI, w której opisywane jest API
+const Version = "3.0.3"
+
+// Document dokument OpenAPI (podzbiór pól używanych przez to API)
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Tags       []Tag                `json:"tags,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Info metadane API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Tag grupa operacji (w Swagger UI - sekcja)
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Components schematy współdzielone przez operacje
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// PathItem operacje dostępne pod jedną ścieżką
+type PathItem struct {
+	Get    *OperationObject `json:"get,omitempty"`
+	Put    *OperationObject `json:"put,omitempty"`
+	Post   *OperationObject `json:"post,omitempty"`
+	Delete *OperationObject `json:"delete,omitempty"`
+	Patch  *OperationObject `json:"patch,omitempty"`
+}
+
+// set przypisuje operację do metody HTTP
+func (p *PathItem) set(method string, op *OperationObject) {
+	switch method {
+	case "GET":
+		p.Get = op
+	case "PUT":
+		p.Put = op
+	case "POST":
+		p.Post = op
+	case "DELETE":
+		p.Delete = op
+	case "PATCH":
+		p.Patch = op
+	}
+}
+
+// OperationObject opis operacji w dokumencie OpenAPI
+type OperationObject struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter parametr ścieżki, zapytania lub nagłówek
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path, query lub header
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody treść żądania
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// Response opis odpowiedzi o danym kodzie
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// Header nagłówek odpowiedzi
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType schemat treści danego typu
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
@@ -0,0 +1,297 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"microservice_overview/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Item struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Count    int               `json:"count,omitempty"`
+	Ratio    float64           `json:"ratio"`
+	Parent   *string           `json:"parent_id"`
+	Tags     []string          `json:"tags,omitempty"`
+	Labels   map[string]string `json:"labels"`
+	Extra    map[string]interface{}
+	Child    *Item     `json:"child,omitempty"`
+	Created  time.Time `json:"created_at"`
+	internal string
+	Hidden   string `json:"-"`
+}
+
+type testHandler struct{}
+
+func (testHandler) List(c *gin.Context)   { c.JSON(http.StatusOK, []Item{}) }
+func (testHandler) Create(c *gin.Context) { c.JSON(http.StatusCreated, Item{}) }
+func (testHandler) Other(c *gin.Context)  { c.Status(http.StatusNoContent) }
+
+var testOperations = map[string]Operation{
+	"testHandler.List": {
+		Tag: "Items", Summary: "List items",
+		Query: []Param{
+			{Name: "limit", Type: "integer", Minimum: Float(1), Maximum: Float(10)},
+			{Name: "order", Enum: []string{"asc", "desc"}},
+			{Name: "filter", Required: true},
+		},
+		Response: []Item{},
+	},
+	"testHandler.Create": {
+		Tag: "Items", Summary: "Create item",
+		Body: Item{}, BodyRequired: []string{"name"},
+		Status: http.StatusCreated, Response: Item{}, Errors: []int{409},
+	},
+}
+
+func setupRouter() (*gin.Engine, *Spec) {
+	gin.SetMode(gin.TestMode)
+	spec := New(Info{Title: "Test", Version: "1"}, nil, testOperations)
+	r := gin.New()
+	h := testHandler{}
+	api := r.Group("/api", spec.Validate)
+	api.GET("/items", h.List)
+	api.POST("/items/:group", h.Create)
+	api.DELETE("/items/:group/*rest", h.Other)
+	r.GET("/health", h.Other)
+	spec.Build(r.Routes(), "/api")
+	return r, spec
+}
+
+func TestHandlerName(t *testing.T) {
+	cases := map[string]string{
+		"microservice_overview/handlers.(*VertexHandler).GetAllVertices-fm": "VertexHandler.GetAllVertices",
+		"microservice_overview/openapi.testHandler.List-fm":                 "testHandler.List",
+		"main.main.func1": "main.func1",
+	}
+	for in, expected := range cases {
+		if got := HandlerName(in); got != expected {
+			t.Errorf("HandlerName(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+func TestSchemaGeneration(t *testing.T) {
+	gen := newSchemaGenerator()
+	ref := gen.schemaOf(Item{})
+	if ref.Ref != "#/components/schemas/OpenapiItem" {
+		t.Fatalf("expected a reference to the component, got %+v", ref)
+	}
+	schema := gen.components["OpenapiItem"]
+
+	var names []string
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	for _, expected := range []string{"id", "name", "count", "ratio", "parent_id", "tags", "labels", "Extra", "child", "created_at"} {
+		if schema.Properties[expected] == nil {
+			t.Errorf("expected property %s, got %v", expected, names)
+		}
+	}
+	if len(schema.Properties) != 10 {
+		t.Errorf("expected unexported and json:\"-\" fields to be skipped, got %v", names)
+	}
+
+	expectations := map[string]Schema{
+		"count":      {Type: "integer", Format: "int32"},
+		"ratio":      {Type: "number"},
+		"parent_id":  {Type: "string", Nullable: true},
+		"created_at": {Type: "string", Format: "date-time"},
+	}
+	for name, expected := range expectations {
+		if got := *schema.Properties[name]; !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: expected %+v, got %+v", name, expected, got)
+		}
+	}
+	if tags := schema.Properties["tags"]; tags.Type != "array" || tags.Items.Type != "string" {
+		t.Errorf("expected an array of strings, got %+v", tags)
+	}
+	if labels := schema.Properties["labels"]; labels.AdditionalProperties.(*Schema).Type != "string" {
+		t.Errorf("expected a map of strings, got %+v", labels)
+	}
+	// Typ rekurencyjny wskazuje na samego siebie
+	if child := schema.Properties["child"]; !child.Nullable || child.AllOf[0].Ref != ref.Ref {
+		t.Errorf("expected a nullable self reference, got %+v", child)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	_, spec := setupRouter()
+	doc := spec.Document()
+
+	if _, ok := doc.Paths["/health"]; ok {
+		t.Error("expected routes outside the prefix to be skipped")
+	}
+	list := doc.Paths["/api/items"].Get
+	if list == nil || list.OperationID != "list" || list.Tags[0] != "Items" || len(list.Parameters) != 3 {
+		t.Fatalf("unexpected list operation %+v", list)
+	}
+
+	create := doc.Paths["/api/items/{group}"].Post
+	if create == nil || create.Parameters[0].In != "path" || create.Parameters[0].Name != "group" {
+		t.Fatalf("expected the path parameter to be derived from the route, got %+v", create)
+	}
+	for _, code := range []string{"201", "400", "409"} {
+		if create.Responses[code] == nil {
+			t.Errorf("expected response %s, got %v", code, create.Responses)
+		}
+	}
+	if conflict := create.Responses["409"].Content[problem.ContentType]; conflict == nil || conflict.Schema.Ref != refPrefix+problemName {
+		t.Errorf("expected errors to be described as problem+json, got %+v", create.Responses["409"])
+	}
+	if create.RequestBody == nil || create.RequestBody.Content["application/json"].Schema.AllOf == nil {
+		t.Errorf("expected a JSON request body with required fields, got %+v", create.RequestBody)
+	}
+
+	// Trasa bez opisu też jest w dokumencie
+	other := doc.Paths["/api/items/{group}/{rest}"].Delete
+	if other == nil || other.Summary != "testHandler.Other" {
+		t.Errorf("expected the undocumented route with the handler name as summary, got %+v", other)
+	}
+
+	if _, err := json.Marshal(doc); err != nil {
+		t.Errorf("failed to encode the document: %v", err)
+	}
+}
+
+func TestValidate_Query(t *testing.T) {
+	r, _ := setupRouter()
+
+	cases := []struct {
+		query   string
+		status  int
+		problem string
+	}{
+		{"filter=x&limit=5&order=asc", http.StatusOK, ""},
+		{"limit=5", http.StatusBadRequest, "filter query parameter is required"},
+		{"filter=x&limit=abc", http.StatusBadRequest, "limit must be an integer"},
+		{"filter=x&limit=11", http.StatusBadRequest, "limit must be at most 10"},
+		{"filter=x&order=random", http.StatusBadRequest, "order must be one of: asc, desc"},
+	}
+	for _, tc := range cases {
+		req, _ := http.NewRequest("GET", "/api/items?"+tc.query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tc.status || !strings.Contains(w.Body.String(), tc.problem) {
+			t.Errorf("%s: expected %d with %q, got %d %s", tc.query, tc.status, tc.problem, w.Code, w.Body.String())
+		}
+		if tc.status == http.StatusBadRequest && w.Header().Get("Content-Type") != problem.ContentType {
+			t.Errorf("%s: expected a problem+json response, got %s", tc.query, w.Header().Get("Content-Type"))
+		}
+	}
+}
+
+func TestValidate_Body(t *testing.T) {
+	r, _ := setupRouter()
+
+	cases := []struct {
+		body     string
+		status   int
+		problems []string
+	}{
+		{`{"name": "a", "parent_id": null, "tags": ["x"], "Extra": {"any": [1, true]}}`, http.StatusCreated, nil},
+		{`{"name": "a", "unknown": 1}`, http.StatusCreated, nil},
+		{``, http.StatusBadRequest, []string{"request body is required"}},
+		{`[1]`, http.StatusBadRequest, []string{"request body must be an object"}},
+		{`{"name": ""}`, http.StatusBadRequest, []string{"name must not be empty"}},
+		{`{"count": 1.5, "ratio": "x"}`, http.StatusBadRequest, []string{"name is required", "count must be an integer", "ratio must be a number"}},
+		{`{"name": "a", "tags": [1], "labels": {"k": false}, "child": {"name": 2}}`, http.StatusBadRequest, []string{"tags[0] must be a string", "labels.k must be a string", "child.name must be a string"}},
+		{`{"name": "a", "id": null}`, http.StatusBadRequest, []string{"id must not be null"}},
+		{`{"name":`, http.StatusBadRequest, []string{"request body is not valid JSON"}},
+	}
+	for _, tc := range cases {
+		req, _ := http.NewRequest("POST", "/api/items/g", bytes.NewBufferString(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tc.status {
+			t.Errorf("%s: expected status %d, got %d %s", tc.body, tc.status, w.Code, w.Body.String())
+			continue
+		}
+		for _, problem := range tc.problems {
+			if !strings.Contains(w.Body.String(), problem) {
+				t.Errorf("%s: expected %q in %s", tc.body, problem, w.Body.String())
+			}
+		}
+	}
+}
+
+func TestValidate_BodyIsPreservedForHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	spec := New(Info{Title: "Test", Version: "1"}, nil, map[string]Operation{
+		"openapi.TestValidate_BodyIsPreservedForHandler.func1": {Body: Item{}},
+	})
+	r := gin.New()
+	var received Item
+	r.POST("/api/items", spec.Validate, func(c *gin.Context) {
+		c.ShouldBindJSON(&received)
+	})
+	spec.Build(r.Routes(), "/api")
+
+	req, _ := http.NewRequest("POST", "/api/items", bytes.NewBufferString(`{"name": "kept"}`))
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	if received.Name != "kept" {
+		t.Errorf("expected the handler to read the validated body, got %+v", received)
+	}
+}
+
+func TestBuild_Aliases(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	spec := New(Info{Title: "Test", Version: "1"}, nil, testOperations)
+	r := gin.New()
+	h := testHandler{}
+	r.GET("/api/v1/items", spec.Validate, h.List)
+	r.GET("/api/items", spec.Validate, h.List)
+	r.GET("/api/v10/items", spec.Validate, h.List)
+	spec.Build(r.Routes(), "/api/v1", "/api")
+
+	doc := spec.Document()
+	if len(doc.Paths) != 1 || doc.Paths["/api/v1/items"] == nil {
+		t.Errorf("expected only the routes under the prefix to be documented, got %v", doc.Paths)
+	}
+	for path, status := range map[string]int{"/api/v1/items": http.StatusBadRequest, "/api/items": http.StatusBadRequest, "/api/v10/items": http.StatusOK} {
+		req, _ := http.NewRequest("GET", path+"?limit=0", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != status {
+			t.Errorf("%s: expected %d, got %d %s", path, status, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestAddHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	spec := New(Info{Title: "Test", Version: "1"}, nil, testOperations)
+	spec.AddHeader(Param{Name: "Idempotency-Key", Description: "Retry key"}, "POST", "DELETE")
+	r := gin.New()
+	h := testHandler{}
+	r.GET("/api/items", h.List)
+	r.POST("/api/items/:group", h.Create)
+	r.DELETE("/api/items/:group/*rest", h.Other)
+	spec.Build(r.Routes(), "/api")
+
+	doc := spec.Document()
+	hasHeader := func(op *OperationObject) bool {
+		for _, param := range op.Parameters {
+			if param.In == "header" && param.Name == "Idempotency-Key" {
+				return true
+			}
+		}
+		return false
+	}
+	if !hasHeader(doc.Paths["/api/items/{group}"].Post) || !hasHeader(doc.Paths["/api/items/{group}/{rest}"].Delete) {
+		t.Error("expected the header on POST and DELETE operations")
+	}
+	if hasHeader(doc.Paths["/api/items"].Get) {
+		t.Error("expected no header on GET operations")
+	}
+}
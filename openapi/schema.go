@@ -0,0 +1,215 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Schema schemat wartości JSON (podzbiór OpenAPI 3.0 Schema Object)
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            int                `json:"minLength,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"` // true lub *Schema
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+// Float zwraca wskaźnik do liczby - do pól Minimum i Maximum
+func Float(v float64) *float64 {
+	return &v
+}
+
+// Ref zwraca odwołanie do schematu z components
+func Ref(name string) *Schema {
+	return &Schema{Ref: refPrefix + name}
+}
+
+const refPrefix = "#/components/schemas/"
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaGenerator wyprowadza schematy z typów Go na podstawie tagów json.
+// Nazwane, eksportowane struktury trafiają do components i są wskazywane przez $ref
+type schemaGenerator struct {
+	components map[string]*Schema
+	types      map[string]reflect.Type // Typ, z którego powstał schemat o danej nazwie
+	inlining   map[reflect.Type]bool   // Struktury opisywane w miejscu użycia (wykrywanie rekurencji)
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		components: make(map[string]*Schema),
+		types:      make(map[string]reflect.Type),
+		inlining:   make(map[reflect.Type]bool),
+	}
+}
+
+// schemaOf zwraca schemat wartości: *Schema jest używany wprost, nil oznacza
+// brak treści, pozostałe wartości opisuje ich typ
+func (g *schemaGenerator) schemaOf(v interface{}) *Schema {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case *Schema:
+		return v
+	}
+	return g.schemaFor(reflect.TypeOf(v))
+}
+
+func (g *schemaGenerator) schemaFor(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := g.schemaFor(t.Elem())
+		if schema.Ref != "" {
+			return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+		}
+		nullable := *schema
+		nullable.Nullable = true
+		return &nullable
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		// encoding/json zapisuje pusty (nil) wycinek i mapę jako null
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem()), Nullable: t.Kind() == reflect.Slice}
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Interface {
+			return &Schema{Type: "object", AdditionalProperties: true, Nullable: true}
+		}
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem()), Nullable: true}
+	case reflect.Struct:
+		// Struktury anonimowe i nieeksportowane są opisywane w miejscu użycia,
+		// chyba że odwołują się do siebie - wtedy też trafiają do components
+		if (t.Name() == "" || !isExported(t.Name())) && !g.inlining[t] {
+			g.inlining[t] = true
+			defer delete(g.inlining, t)
+			return g.structSchema(t)
+		}
+		return g.component(t)
+	}
+	// interface{} i typy bez odpowiednika w JSON - dowolna wartość
+	return &Schema{}
+}
+
+// component rejestruje strukturę w components i zwraca odwołanie do niej
+func (g *schemaGenerator) component(t reflect.Type) *Schema {
+	// Typy spoza pakietu models dostają przedrostek pakietu (query.Result →
+	// QueryResult), podobnie jak typy o powtórzonej nazwie
+	name := upperFirst(t.Name())
+	if pkg := pathBase(t.PkgPath()); pkg != "models" && !strings.HasPrefix(name, upperFirst(pkg)) {
+		name = upperFirst(pkg) + name
+	}
+	if existing, ok := g.types[name]; ok && existing != t {
+		name = upperFirst(pathBase(t.PkgPath())) + name
+	}
+	if _, ok := g.types[name]; !ok {
+		g.types[name] = t
+		g.components[name] = &Schema{} // zaślepka na wypadek typów rekurencyjnych
+		*g.components[name] = *g.structSchema(t)
+	}
+	return Ref(name)
+}
+
+// structSchema opisuje pola struktury według tagów json; pola osadzone są spłaszczane
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, property := range g.structSchema(embedded).Properties {
+					schema.Properties[key] = property
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = g.schemaFor(field.Type)
+	}
+	return schema
+}
+
+// jsonName zwraca nazwę pola w JSON; skip = true dla pól pomijanych przez encoding/json
+func jsonName(field reflect.StructField) (name string, skip bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", true
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ = strings.Cut(tag, ",")
+	return name, false
+}
+
+func isExported(name string) bool {
+	return name != "" && unicode.IsUpper([]rune(name)[0])
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func pathBase(path string) string {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
@@ -0,0 +1,274 @@
+package openapi
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"microservice_overview/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Operation opis operacji przypisany do metody handlera. Ścieżka i metoda
+// HTTP pochodzą z routera, więc opis nie może rozjechać się z trasami
+type Operation struct {
+	ID          string // operationId; domyślnie nazwa metody handlera
+	Tag         string
+	Summary     string
+	Description string
+
+	Query   []Param // Parametry zapytania (sprawdzane przy walidacji)
+	Headers []Param // Nagłówki żądania (tylko dokumentacja)
+
+	Body         interface{}            // Treść application/json: wartość typu Go lub *Schema
+	BodyRequired []string               // Pola treści, które muszą być podane (napisy - niepuste)
+	BodyTypes    map[string]interface{} // Treści innych typów (np. application/merge-patch+json)
+
+	Status       int         // Kod odpowiedzi poprawnej, domyślnie 200
+	Response     interface{} // Treść odpowiedzi: wartość typu Go lub *Schema; nil = brak treści
+	ResponseType string      // Typ treści odpowiedzi, domyślnie application/json
+	Errors       []int       // Kody błędów zwracane przez operację
+}
+
+// Param parametr zapytania lub nagłówek
+type Param struct {
+	Name        string
+	Description string
+	Type        string // string, integer, number lub boolean; domyślnie string
+	Required    bool
+	Enum        []string
+	Default     interface{}
+	Minimum     *float64
+	Maximum     *float64
+}
+
+// schema zwraca schemat wartości parametru
+func (p Param) schema() *Schema {
+	schema := &Schema{Type: p.Type, Default: p.Default, Minimum: p.Minimum, Maximum: p.Maximum}
+	if schema.Type == "" {
+		schema.Type = "string"
+	}
+	for _, value := range p.Enum {
+		schema.Enum = append(schema.Enum, value)
+	}
+	return schema
+}
+
+// compiledOperation dane operacji potrzebne do walidacji żądań
+type compiledOperation struct {
+	query        []Param
+	body         map[string]*Schema // Typ treści → schemat
+	bodyRequired bool
+}
+
+// Spec specyfikacja OpenAPI budowana z tras routera. Validate można dołączyć
+// jako middleware przed rejestracją tras - zacznie działać po Build
+type Spec struct {
+	info       Info
+	tags       []Tag
+	operations map[string]Operation
+	headers    map[string][]Param // Metoda HTTP → nagłówki wspólne dla jej operacji
+
+	mu     sync.RWMutex
+	doc    *Document
+	routes map[string]*compiledOperation // "GET /api/vertices/:id" → operacja
+}
+
+// New tworzy specyfikację z opisami operacji według nazw handlerów
+// ("VertexHandler.GetAllVertices", zob. HandlerName)
+func New(info Info, tags []Tag, operations map[string]Operation) *Spec {
+	return &Spec{info: info, tags: tags, operations: operations}
+}
+
+// AddHeader dokumentuje nagłówek obsługiwany przez wszystkie operacje podanych
+// metod HTTP, np. dodany przez middleware
+func (s *Spec) AddHeader(param Param, methods ...string) {
+	if s.headers == nil {
+		s.headers = make(map[string][]Param)
+	}
+	for _, method := range methods {
+		s.headers[method] = append(s.headers[method], param)
+	}
+}
+
+// HandlerName zwraca nazwę handlera w postaci "Typ.Metoda" na podstawie
+// nazwy funkcji z gin.RouteInfo, np. "microservice_overview/handlers.(*VertexHandler).GetAllVertices-fm"
+func HandlerName(funcName string) string {
+	name := strings.TrimSuffix(pathBase(funcName), "-fm")
+	if _, rest, found := strings.Cut(name, "."); found {
+		name = rest
+	}
+	name = strings.TrimPrefix(name, "(*")
+	return strings.Replace(name, ").", ".", 1)
+}
+
+// Build tworzy dokument z tras zaczynających się od prefix (np. "/api/v2").
+// Trasy bez opisu też trafiają do dokumentu, z nazwą handlera jako opisem.
+// Trasy pod prefiksami aliases (np. "/api" dla "/api/v1") są sprawdzane
+// przez Validate tak samo, ale nie trafiają do dokumentu
+func (s *Spec) Build(routes gin.RoutesInfo, prefix string, aliases ...string) {
+	sorted := make(gin.RoutesInfo, 0, len(routes))
+	for _, route := range routes {
+		if route.Path == prefix || strings.HasPrefix(route.Path, prefix+"/") {
+			sorted = append(sorted, route)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+
+	gen := newSchemaGenerator()
+	problemSchema := gen.schemaOf(problem.Problem{})
+	gen.components[problemName].Required = []string{"type", "title", "status", "code"}
+	doc := &Document{
+		OpenAPI:    Version,
+		Info:       s.info,
+		Tags:       s.tags,
+		Paths:      make(map[string]*PathItem),
+		Components: Components{Schemas: gen.components},
+	}
+	compiled := make(map[string]*compiledOperation)
+	usedIDs := make(map[string]int)
+
+	for _, route := range sorted {
+		handler := HandlerName(route.Handler)
+		op, documented := s.operations[handler]
+		if !documented {
+			op.Summary = handler
+		}
+
+		path, pathParams := openAPIPath(route.Path)
+		object := &OperationObject{
+			OperationID: op.ID,
+			Summary:     op.Summary,
+			Description: op.Description,
+			Responses:   make(map[string]*Response),
+		}
+		if object.OperationID == "" {
+			object.OperationID = lowerFirst(handler[strings.LastIndex(handler, ".")+1:])
+		}
+		if usedIDs[object.OperationID]++; usedIDs[object.OperationID] > 1 {
+			object.OperationID += strconv.Itoa(usedIDs[object.OperationID])
+		}
+		if op.Tag != "" {
+			object.Tags = []string{op.Tag}
+		}
+
+		for _, name := range pathParams {
+			object.Parameters = append(object.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+		for _, param := range op.Query {
+			object.Parameters = append(object.Parameters, &Parameter{Name: param.Name, In: "query", Description: param.Description, Required: param.Required, Schema: param.schema()})
+		}
+		for _, headers := range [][]Param{op.Headers, s.headers[route.Method]} {
+			for _, param := range headers {
+				object.Parameters = append(object.Parameters, &Parameter{Name: param.Name, In: "header", Description: param.Description, Required: param.Required, Schema: param.schema()})
+			}
+		}
+
+		compiledOp := &compiledOperation{query: op.Query, body: make(map[string]*Schema)}
+		if op.Body != nil {
+			compiledOp.body["application/json"] = requireFields(gen, gen.schemaOf(op.Body), op.BodyRequired)
+		}
+		for contentType, body := range op.BodyTypes {
+			compiledOp.body[contentType] = gen.schemaOf(body)
+		}
+		if len(compiledOp.body) > 0 {
+			compiledOp.bodyRequired = true
+			object.RequestBody = &RequestBody{Required: true, Content: make(map[string]*MediaType)}
+			for contentType, schema := range compiledOp.body {
+				object.RequestBody.Content[contentType] = &MediaType{Schema: schema}
+			}
+		}
+
+		status := op.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		success := &Response{Description: http.StatusText(status)}
+		if schema := gen.schemaOf(op.Response); schema != nil {
+			contentType := op.ResponseType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			success.Content = map[string]*MediaType{contentType: {Schema: schema}}
+		}
+		object.Responses[strconv.Itoa(status)] = success
+		if len(compiledOp.body) > 0 || len(op.Query) > 0 {
+			object.Responses["400"] = errorResponse(http.StatusBadRequest, problemSchema)
+		}
+		for _, code := range op.Errors {
+			if code == http.StatusNotModified {
+				object.Responses["304"] = &Response{Description: http.StatusText(code)}
+				continue
+			}
+			object.Responses[strconv.Itoa(code)] = errorResponse(code, problemSchema)
+		}
+
+		item := doc.Paths[path]
+		if item == nil {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		item.set(route.Method, object)
+		compiled[route.Method+" "+route.Path] = compiledOp
+		for _, alias := range aliases {
+			compiled[route.Method+" "+alias+strings.TrimPrefix(route.Path, prefix)] = compiledOp
+		}
+	}
+
+	s.mu.Lock()
+	s.doc = doc
+	s.routes = compiled
+	s.mu.Unlock()
+}
+
+// Document zwraca zbudowany dokument (nil przed Build)
+func (s *Spec) Document() *Document {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.doc
+}
+
+// problemName nazwa schematu błędu (problem.Problem) w components
+const problemName = "Problem"
+
+func errorResponse(code int, schema *Schema) *Response {
+	return &Response{
+		Description: http.StatusText(code),
+		Content:     map[string]*MediaType{problem.ContentType: {Schema: schema}},
+	}
+}
+
+// requireFields dokłada do schematu treści wymagane pola; wymagany napis nie może być pusty
+func requireFields(gen *schemaGenerator, schema *Schema, fields []string) *Schema {
+	if len(fields) == 0 {
+		return schema
+	}
+	resolved := schema
+	if schema.Ref != "" {
+		resolved = gen.components[strings.TrimPrefix(schema.Ref, refPrefix)]
+	}
+	constraints := &Schema{Required: fields, Properties: make(map[string]*Schema)}
+	for _, field := range fields {
+		if property := resolved.Properties[field]; property != nil && property.Type == "string" {
+			constraints.Properties[field] = &Schema{MinLength: 1}
+		}
+	}
+	return &Schema{AllOf: []*Schema{schema, constraints}}
+}
+
+// openAPIPath zamienia ścieżkę gin (/vertices/:id) na ścieżkę OpenAPI
+// (/vertices/{id}) i zwraca nazwy parametrów
+func openAPIPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
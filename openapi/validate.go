@@ -0,0 +1,246 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"microservice_overview/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Validate sprawdza parametry zapytania i treść żądania względem specyfikacji
+// operacji; niezgodne żądanie kończy się odpowiedzią 400 (INVALID_REQUEST)
+// z listą problemów w polu errors.
+// Trasy spoza specyfikacji przechodzą bez zmian
+func (s *Spec) Validate(c *gin.Context) {
+	s.mu.RLock()
+	op, components := s.routes[c.Request.Method+" "+c.FullPath()], Components{}
+	if s.doc != nil {
+		components = s.doc.Components
+	}
+	s.mu.RUnlock()
+	if op == nil {
+		return
+	}
+
+	if problems := op.validate(c.Request, components.Schemas); len(problems) > 0 {
+		p := problem.New(http.StatusBadRequest, problem.CodeInvalidRequest, strings.Join(problems, "; "))
+		p.Errors = problems
+		problem.Abort(c, p)
+	}
+}
+
+// validate zwraca opisy niezgodności żądania z operacją
+func (op *compiledOperation) validate(req *http.Request, schemas map[string]*Schema) []string {
+	var problems []string
+
+	query := req.URL.Query()
+	for _, param := range op.query {
+		raw, present := query[param.Name]
+		if !present {
+			if param.Required {
+				problems = append(problems, param.Name+" query parameter is required")
+			}
+			continue
+		}
+		value, err := parseParam(param, raw[0])
+		if err != nil {
+			problems = append(problems, param.Name+" "+err.Error())
+			continue
+		}
+		problems = append(problems, validateValue(value, param.schema(), param.Name, schemas)...)
+	}
+
+	if len(op.body) == 0 || req.Body == nil {
+		return problems
+	}
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return append(problems, "failed to read request body: "+err.Error())
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data)) // handler czyta treść ponownie
+	if len(bytes.TrimSpace(data)) == 0 {
+		if op.bodyRequired {
+			problems = append(problems, "request body is required")
+		}
+		return problems
+	}
+
+	// Treść typu spoza specyfikacji jest sprawdzana jako JSON, tak jak czyta
+	// ją ShouldBindJSON; handlery PATCH odrzucają ją same (415)
+	contentType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	schema, ok := op.body[contentType]
+	if !ok {
+		if schema, ok = op.body["application/json"]; !ok {
+			return problems
+		}
+	}
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return append(problems, "request body is not valid JSON: "+err.Error())
+	}
+	return append(problems, validateValue(body, schema, "", schemas)...)
+}
+
+// parseParam zamienia wartość parametru zapytania na typ z jego schematu
+func parseParam(param Param, raw string) (interface{}, error) {
+	switch param.Type {
+	case "integer":
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("must be an integer")
+		}
+		return float64(value), nil
+	case "number":
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("must be a number")
+		}
+		return value, nil
+	case "boolean":
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("must be true or false")
+		}
+		return value, nil
+	}
+	return raw, nil
+}
+
+// validateValue sprawdza wartość zdekodowaną z JSON względem schematu.
+// path to położenie wartości w treści (np. metadata_schema[0].type)
+func validateValue(value interface{}, schema *Schema, path string, schemas map[string]*Schema) []string {
+	if schema.Ref != "" {
+		resolved, ok := schemas[strings.TrimPrefix(schema.Ref, refPrefix)]
+		if !ok {
+			return nil
+		}
+		schema = resolved
+	}
+	name := path
+	if name == "" {
+		name = "request body"
+	}
+
+	if value == nil {
+		if schema.Nullable || (schema.Type == "" && len(schema.AllOf) == 0) {
+			return nil
+		}
+		return []string{name + " must not be null"}
+	}
+
+	var problems []string
+	for _, part := range schema.AllOf {
+		problems = append(problems, validateValue(value, part, path, schemas)...)
+	}
+
+	switch schema.Type {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return append(problems, name+" must be a string")
+		}
+	case "number", "integer":
+		n, ok := value.(float64)
+		if !ok {
+			return append(problems, name+" must be a"+article(schema.Type)+" "+schema.Type)
+		}
+		if schema.Type == "integer" && n != math.Trunc(n) {
+			return append(problems, name+" must be an integer")
+		}
+		if schema.Minimum != nil && n < *schema.Minimum {
+			problems = append(problems, fmt.Sprintf("%s must be at least %v", name, *schema.Minimum))
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			problems = append(problems, fmt.Sprintf("%s must be at most %v", name, *schema.Maximum))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return append(problems, name+" must be a boolean")
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return append(problems, name+" must be an array")
+		}
+		if schema.Items != nil {
+			for i, item := range items {
+				problems = append(problems, validateValue(item, schema.Items, fmt.Sprintf("%s[%d]", path, i), schemas)...)
+			}
+		}
+	case "object":
+		if _, ok := value.(map[string]interface{}); !ok {
+			return append(problems, name+" must be an object")
+		}
+	}
+
+	if s, ok := value.(string); ok && schema.MinLength > 0 && len([]rune(s)) < schema.MinLength {
+		if schema.MinLength == 1 {
+			problems = append(problems, name+" must not be empty")
+		} else {
+			problems = append(problems, fmt.Sprintf("%s must be at least %d characters long", name, schema.MinLength))
+		}
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(value, schema.Enum) {
+		options := make([]string, len(schema.Enum))
+		for i, option := range schema.Enum {
+			options[i] = fmt.Sprint(option)
+		}
+		problems = append(problems, name+" must be one of: "+strings.Join(options, ", "))
+	}
+
+	// Pola obiektu sprawdzane są także dla schematów bez typu (np. części allOf)
+	if object, ok := value.(map[string]interface{}); ok {
+		for _, field := range schema.Required {
+			if _, present := object[field]; !present {
+				problems = append(problems, join(path, field)+" is required")
+			}
+		}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if property, ok := schema.Properties[key]; ok {
+				problems = append(problems, validateValue(object[key], property, join(path, key), schemas)...)
+			} else if additional, ok := schema.AdditionalProperties.(*Schema); ok {
+				problems = append(problems, validateValue(object[key], additional, join(path, key), schemas)...)
+			}
+		}
+	}
+	return problems
+}
+
+func inEnum(value interface{}, enum []interface{}) bool {
+	for _, option := range enum {
+		if reflect.DeepEqual(value, option) || fmt.Sprint(value) == fmt.Sprint(option) {
+			return true
+		}
+	}
+	return false
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func article(typeName string) string {
+	if typeName == "integer" {
+		return "n"
+	}
+	return ""
+}
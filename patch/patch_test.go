@@ -0,0 +1,179 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// assertJSONEqual porównuje dokumenty JSON niezależnie od kolejności kluczy
+func assertJSONEqual(t *testing.T, got []byte, want string) {
+	t.Helper()
+
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("invalid result JSON %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("invalid expected JSON %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "replace field",
+			doc:      `{"name": "A", "description": "old"}`,
+			patch:    `{"description": "new"}`,
+			expected: `{"name": "A", "description": "new"}`,
+		},
+		{
+			name:     "null removes field",
+			doc:      `{"name": "A", "parent_id": "p"}`,
+			patch:    `{"parent_id": null}`,
+			expected: `{"name": "A"}`,
+		},
+		{
+			name:     "nested objects are merged",
+			doc:      `{"a": {"b": 1, "c": 2}}`,
+			patch:    `{"a": {"c": 3, "d": 4}}`,
+			expected: `{"a": {"b": 1, "c": 3, "d": 4}}`,
+		},
+		{
+			name:     "arrays are replaced",
+			doc:      `{"tags": ["a", "b"]}`,
+			patch:    `{"tags": ["c"]}`,
+			expected: `{"tags": ["c"]}`,
+		},
+		{
+			name:     "non-object patch replaces document",
+			doc:      `{"a": 1}`,
+			patch:    `["x"]`,
+			expected: `["x"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("MergePatch() error = %v", err)
+			}
+			assertJSONEqual(t, result, tt.expected)
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "add field",
+			doc:      `{"name": "A"}`,
+			patch:    `[{"op": "add", "path": "/description", "value": "text"}]`,
+			expected: `{"name": "A", "description": "text"}`,
+		},
+		{
+			name:     "add null value",
+			doc:      `{"name": "A", "parent_id": "p"}`,
+			patch:    `[{"op": "add", "path": "/parent_id", "value": null}]`,
+			expected: `{"name": "A", "parent_id": null}`,
+		},
+		{
+			name:     "add to array",
+			doc:      `{"tags": ["a", "c"]}`,
+			patch:    `[{"op": "add", "path": "/tags/1", "value": "b"}, {"op": "add", "path": "/tags/-", "value": "d"}]`,
+			expected: `{"tags": ["a", "b", "c", "d"]}`,
+		},
+		{
+			name:     "remove field",
+			doc:      `{"name": "A", "description": "text"}`,
+			patch:    `[{"op": "remove", "path": "/description"}]`,
+			expected: `{"name": "A"}`,
+		},
+		{
+			name:     "replace field",
+			doc:      `{"name": "A"}`,
+			patch:    `[{"op": "replace", "path": "/name", "value": "B"}]`,
+			expected: `{"name": "B"}`,
+		},
+		{
+			name:     "move field",
+			doc:      `{"a": {"x": 1}, "b": {}}`,
+			patch:    `[{"op": "move", "from": "/a/x", "path": "/b/y"}]`,
+			expected: `{"a": {}, "b": {"y": 1}}`,
+		},
+		{
+			name:     "copy field",
+			doc:      `{"a": {"x": 1}}`,
+			patch:    `[{"op": "copy", "from": "/a", "path": "/b"}]`,
+			expected: `{"a": {"x": 1}, "b": {"x": 1}}`,
+		},
+		{
+			name:     "test passes",
+			doc:      `{"version": 3}`,
+			patch:    `[{"op": "test", "path": "/version", "value": 3}]`,
+			expected: `{"version": 3}`,
+		},
+		{
+			name:     "escaped pointer",
+			doc:      `{"a/b": 1, "m~n": 2}`,
+			patch:    `[{"op": "replace", "path": "/a~1b", "value": 3}, {"op": "remove", "path": "/m~0n"}]`,
+			expected: `{"a/b": 3}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ApplyJSONPatch([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("ApplyJSONPatch() error = %v", err)
+			}
+			assertJSONEqual(t, result, tt.expected)
+		})
+	}
+}
+
+func TestApplyJSONPatch_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+	}{
+		{name: "unknown operation", patch: `[{"op": "merge", "path": "/name"}]`},
+		{name: "missing value", patch: `[{"op": "add", "path": "/name"}]`},
+		{name: "replace missing field", patch: `[{"op": "replace", "path": "/missing", "value": 1}]`},
+		{name: "remove missing field", patch: `[{"op": "remove", "path": "/missing"}]`},
+		{name: "invalid pointer", patch: `[{"op": "remove", "path": "name"}]`},
+		{name: "array index out of range", patch: `[{"op": "add", "path": "/tags/5", "value": "x"}]`},
+		{name: "move into own child", patch: `[{"op": "move", "from": "/tags", "path": "/tags/0"}]`},
+		{name: "not an array of operations", patch: `{"op": "remove"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ApplyJSONPatch([]byte(`{"name": "A", "tags": []}`), []byte(tt.patch)); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatch_TestFailed(t *testing.T) {
+	_, err := ApplyJSONPatch([]byte(`{"version": 3}`), []byte(`[{"op": "test", "path": "/version", "value": 2}]`))
+	if !errors.Is(err, ErrTestFailed) {
+		t.Errorf("Expected ErrTestFailed, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package problem
+
+import (
+	"errors"
+	"net/http"
+
+	"microservice_overview/analysis"
+	"microservice_overview/patch"
+	"microservice_overview/query"
+	"microservice_overview/rules"
+	"microservice_overview/storage"
+)
+
+// GenericDetail opis błędów 5xx - przyczyna trafia do logu, nie do klienta
+const GenericDetail = "internal server error"
+
+// known błędy storage i pakietów pomocniczych z przypisanym statusem i kodem.
+// Jedna tabela dla REST API, GraphQL i gRPC - nowy błąd dopisuje się tylko tutaj
+var known = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{storage.ErrNotFound, http.StatusNotFound, CodeNotFound},
+	{storage.ErrVersionConflict, http.StatusPreconditionFailed, CodeVersionConflict},
+	{storage.ErrDuplicateID, http.StatusConflict, CodeDuplicateID},
+	{storage.ErrParentNotFound, http.StatusUnprocessableEntity, CodeParentNotFound},
+	{storage.ErrEndpointNotFound, http.StatusUnprocessableEntity, CodeEndpointNotFound},
+	{storage.ErrHierarchyCycle, http.StatusConflict, CodeHierarchyCycle},
+	{storage.ErrEdgeOnNonLeaf, http.StatusConflict, CodeEdgeOnNonLeaf},
+	{storage.ErrDuplicateEdge, http.StatusConflict, CodeDuplicateEdge},
+	{storage.ErrVertexInUse, http.StatusConflict, CodeResourceInUse},
+	{storage.ErrEdgeTypeInUse, http.StatusConflict, CodeResourceInUse},
+	{storage.ErrVertexKindInUse, http.StatusConflict, CodeResourceInUse},
+	{storage.ErrLayerInUse, http.StatusConflict, CodeResourceInUse},
+	{storage.ErrRuleViolation, http.StatusUnprocessableEntity, CodeRuleViolation},
+	{storage.ErrLayerViolation, http.StatusUnprocessableEntity, CodeLayerViolation},
+	{storage.ErrInvalidVertexKind, http.StatusBadRequest, CodeInvalidRequest},
+	{storage.ErrInvalidLayer, http.StatusBadRequest, CodeInvalidRequest},
+	{storage.ErrInvalidLatency, http.StatusBadRequest, CodeInvalidRequest},
+	{storage.ErrInvalidSLO, http.StatusBadRequest, CodeInvalidRequest},
+	{storage.ErrUnknownEdgeType, http.StatusBadRequest, CodeInvalidRequest},
+	{storage.ErrInvalidEdgeType, http.StatusBadRequest, CodeInvalidRequest},
+	{storage.ErrEdgeKindMismatch, http.StatusBadRequest, CodeInvalidRequest},
+	{rules.ErrInvalidRule, http.StatusBadRequest, CodeInvalidRequest},
+	{patch.ErrTestFailed, http.StatusConflict, CodePatchTestFailed},
+	{query.ErrTooExpensive, http.StatusUnprocessableEntity, CodeQueryTooExpensive},
+}
+
+// For zwraca status HTTP i kod znanego błędu; ok jest false dla błędów spoza tabeli
+func For(err error) (status int, code string, ok bool) {
+	var cycleErr *analysis.SynchronousCycleError
+	var syntaxErr *query.SyntaxError
+	switch {
+	case errors.As(err, &cycleErr):
+		return http.StatusUnprocessableEntity, CodeSynchronousCycle, true
+	case errors.As(err, &syntaxErr):
+		return http.StatusBadRequest, CodeQuerySyntax, true
+	}
+	for _, k := range known {
+		if errors.Is(err, k.err) {
+			return k.status, k.code, true
+		}
+	}
+	return 0, "", false
+}
+
+// FromError opisuje błąd jako problem. Znane błędy dostają status i kod z For,
+// pozostałe - status fallback i domyślny kod. Błędy 5xx mają ogólny opis
+func FromError(fallback int, err error) *Problem {
+	status, code, ok := For(err)
+	if !ok {
+		status = fallback
+	}
+	detail := err.Error()
+	if status >= http.StatusInternalServerError {
+		detail = GenericDetail
+	}
+	p := New(status, code, detail)
+
+	var ruleErr *storage.RuleViolationError
+	var layerErr *storage.LayerViolationError
+	var cycleErr *analysis.SynchronousCycleError
+	var syntaxErr *query.SyntaxError
+	switch {
+	case errors.As(err, &ruleErr):
+		p.Violations = ruleErr.Violations
+	case errors.As(err, &layerErr):
+		p.Violations = layerErr.Violations
+	case errors.As(err, &cycleErr):
+		p.Cycle = cycleErr.Cycle
+	case errors.As(err, &syntaxErr):
+		p.Position = &syntaxErr.Pos
+	}
+	return p
+}
@@ -0,0 +1,61 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"microservice_overview/analysis"
+	"microservice_overview/models"
+	"microservice_overview/query"
+	"microservice_overview/rules"
+	"microservice_overview/storage"
+)
+
+func TestFor(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{fmt.Errorf("vertex x: %w", storage.ErrNotFound), http.StatusNotFound, CodeNotFound},
+		{fmt.Errorf("%w: a", storage.ErrHierarchyCycle), http.StatusConflict, CodeHierarchyCycle},
+		{fmt.Errorf("%w: a", storage.ErrEdgeOnNonLeaf), http.StatusConflict, CodeEdgeOnNonLeaf},
+		{&storage.RuleViolationError{}, http.StatusUnprocessableEntity, CodeRuleViolation},
+		{&analysis.SynchronousCycleError{Cycle: []string{"a", "b", "a"}}, http.StatusUnprocessableEntity, CodeSynchronousCycle},
+		{&query.SyntaxError{Pos: 3, Message: "unexpected token"}, http.StatusBadRequest, CodeQuerySyntax},
+		{&query.CostError{Steps: 10}, http.StatusUnprocessableEntity, CodeQueryTooExpensive},
+		{fmt.Errorf("%w \"call\"", storage.ErrUnknownEdgeType), http.StatusBadRequest, CodeInvalidRequest},
+		{fmt.Errorf("%w: invalid style", storage.ErrInvalidEdgeType), http.StatusBadRequest, CodeInvalidRequest},
+		{fmt.Errorf("%w: edge type calls", storage.ErrEdgeKindMismatch), http.StatusBadRequest, CodeInvalidRequest},
+		{fmt.Errorf("%w: name is required", rules.ErrInvalidRule), http.StatusBadRequest, CodeInvalidRequest},
+	}
+	for _, tc := range cases {
+		status, code, ok := For(tc.err)
+		if !ok || status != tc.status || code != tc.code {
+			t.Errorf("For(%v) = %d %s %v, expected %d %s", tc.err, status, code, ok, tc.status, tc.code)
+		}
+	}
+	if _, _, ok := For(errors.New("unexpected")); ok {
+		t.Error("expected an unknown error not to be mapped")
+	}
+}
+
+func TestFromError(t *testing.T) {
+	violations := []models.RuleViolation{{RuleID: "r1", RuleName: "no-db-calls"}}
+	p := FromError(http.StatusBadRequest, &storage.RuleViolationError{Violations: violations})
+	if p.Status != http.StatusUnprocessableEntity || p.Code != CodeRuleViolation || p.Violations == nil {
+		t.Errorf("expected a rule violation problem with violations, got %+v", p)
+	}
+
+	if p := FromError(http.StatusBadRequest, errors.New("name is required")); p.Status != http.StatusBadRequest || p.Detail != "name is required" {
+		t.Errorf("expected the fallback status with the error detail, got %+v", p)
+	}
+
+	// Przyczyna błędu 5xx nie trafia do klienta
+	p = FromError(http.StatusInternalServerError, errors.New("pq: password authentication failed for user postgres"))
+	if p.Status != http.StatusInternalServerError || p.Code != CodeInternal || p.Detail != GenericDetail {
+		t.Errorf("expected a generic internal error, got %+v", p)
+	}
+}
@@ -0,0 +1,98 @@
+// Package problem opisuje błędy API w formacie RFC 7807 (application/problem+json).
+// Klienci rozpoznają błąd po stałym polu code, a nie po treści detail
+package problem
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentType typ treści odpowiedzi z błędem
+const ContentType = "application/problem+json"
+
+// Kody błędów
+const (
+	CodeInvalidRequest       = "INVALID_REQUEST"        // Żądanie niezgodne z kontraktem API lub błędne wartości pól
+	CodeNotFound             = "NOT_FOUND"              // Zasób wskazany w ścieżce lub parametrze nie istnieje
+	CodeDuplicateID          = "DUPLICATE_ID"           // Zasób o podanym ID lub nazwie już istnieje
+	CodeDuplicateEdge        = "DUPLICATE_EDGE"         // Relacja o tych samych końcach i typie już istnieje
+	CodeParentNotFound       = "PARENT_NOT_FOUND"       // Wskazany rodzic wierzchołka nie istnieje
+	CodeEndpointNotFound     = "ENDPOINT_NOT_FOUND"     // Wierzchołek początkowy lub końcowy relacji nie istnieje
+	CodeHierarchyCycle       = "HIERARCHY_CYCLE"        // Zmiana rodzica utworzyłaby cykl w hierarchii
+	CodeEdgeOnNonLeaf        = "EDGE_ON_NON_LEAF"       // Relacja dotyczyłaby wierzchołka, który ma dzieci
+	CodeResourceInUse        = "RESOURCE_IN_USE"        // Zasób jest używany przez inne (wierzchołek, typ relacji, rodzaj, warstwa)
+	CodeRuleViolation        = "RULE_VIOLATION"         // Zależność łamie reguły architektury
+	CodeLayerViolation       = "LAYER_VIOLATION"        // Zależność łamie model warstwowy
+	CodeSynchronousCycle     = "SYNCHRONOUS_CYCLE"      // Cykl wywołań synchronicznych uniemożliwia liczenie opóźnień
+	CodeQuerySyntax          = "QUERY_SYNTAX_ERROR"     // Błąd składni zapytania
+	CodeQueryTooExpensive    = "QUERY_TOO_EXPENSIVE"    // Wyszukiwanie dopasowań przekroczyło budżet kroków
+	CodeQueryTooComplex      = "QUERY_TOO_COMPLEX"      // Zapytanie GraphQL przekracza limit głębokości lub złożoności
+	CodePatchTestFailed      = "PATCH_TEST_FAILED"      // Niespełniona operacja test w JSON Patch
+	CodeConflict             = "CONFLICT"               // Inny konflikt ze stanem zasobu
+	CodeVersionConflict      = "VERSION_CONFLICT"       // If-Match nie zgadza się z aktualną wersją
+	CodePreconditionRequired = "PRECONDITION_REQUIRED"  // Brak wymaganego nagłówka If-Match
+	CodeUnsupportedMedia     = "UNSUPPORTED_MEDIA_TYPE" // Nieobsługiwany Content-Type
+	CodeIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED" // Idempotency-Key użyty wcześniej z innym żądaniem
+	CodeIdempotencyInFlight  = "IDEMPOTENCY_KEY_IN_USE" // Żądanie z tym Idempotency-Key jest jeszcze obsługiwane
+	CodeUnprocessable        = "UNPROCESSABLE"          // Inne żądanie poprawne składniowo, ale niemożliwe do wykonania
+	CodeUnavailable          = "UNAVAILABLE"            // Usługa chwilowo niedostępna
+	CodeInternal             = "INTERNAL_ERROR"         // Nieoczekiwany błąd serwera
+)
+
+// defaultCodes kody używane, gdy błąd nie ma własnego
+var defaultCodes = map[int]string{
+	http.StatusBadRequest:           CodeInvalidRequest,
+	http.StatusNotFound:             CodeNotFound,
+	http.StatusConflict:             CodeConflict,
+	http.StatusPreconditionFailed:   CodeVersionConflict,
+	http.StatusUnsupportedMediaType: CodeUnsupportedMedia,
+	http.StatusUnprocessableEntity:  CodeUnprocessable,
+	http.StatusPreconditionRequired: CodePreconditionRequired,
+	http.StatusServiceUnavailable:   CodeUnavailable,
+}
+
+// Problem treść odpowiedzi z błędem. Pola po Code to rozszerzenia
+// obecne tylko przy niektórych kodach
+type Problem struct {
+	Type     string `json:"type"`               // Zawsze about:blank - rodzaj błędu określa code
+	Title    string `json:"title"`              // Opis statusu HTTP
+	Status   int    `json:"status"`             // Status HTTP
+	Detail   string `json:"detail,omitempty"`   // Opis tego wystąpienia błędu
+	Instance string `json:"instance,omitempty"` // Ścieżka żądania
+	Code     string `json:"code"`               // Stały kod błędu, np. PARENT_NOT_FOUND
+
+	Errors     []string    `json:"errors,omitempty"`     // Wszystkie problemy walidacji (INVALID_REQUEST)
+	Violations interface{} `json:"violations,omitempty"` // Naruszenia (RULE_VIOLATION, LAYER_VIOLATION)
+	Cycle      []string    `json:"cycle,omitempty"`      // Wierzchołki cyklu (SYNCHRONOUS_CYCLE)
+	Position   *int        `json:"position,omitempty"`   // Pozycja błędu w zapytaniu (QUERY_SYNTAX_ERROR)
+	Operation  *int        `json:"operation,omitempty"`  // Indeks nieudanej operacji paczki (POST /api/batch)
+}
+
+// New tworzy problem; pusty code oznacza domyślny kod dla statusu
+func New(status int, code, detail string) *Problem {
+	if code == "" {
+		code = DefaultCode(status)
+	}
+	return &Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail, Code: code}
+}
+
+// DefaultCode zwraca kod błędu używany dla statusu HTTP
+func DefaultCode(status int) string {
+	if code, ok := defaultCodes[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeInvalidRequest
+}
+
+// Abort wysyła problem jako odpowiedź i przerywa obsługę żądania
+func Abort(c *gin.Context, p *Problem) {
+	if p.Instance == "" {
+		p.Instance = c.Request.URL.Path
+	}
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
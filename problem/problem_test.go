@@ -0,0 +1,66 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNew_DefaultCodes(t *testing.T) {
+	cases := map[int]string{
+		http.StatusBadRequest:           CodeInvalidRequest,
+		http.StatusNotFound:             CodeNotFound,
+		http.StatusPreconditionFailed:   CodeVersionConflict,
+		http.StatusPreconditionRequired: CodePreconditionRequired,
+		http.StatusInternalServerError:  CodeInternal,
+		http.StatusBadGateway:           CodeInternal,
+		http.StatusTeapot:               CodeInvalidRequest,
+	}
+	for status, expected := range cases {
+		if p := New(status, "", "x"); p.Code != expected || p.Status != status || p.Title != http.StatusText(status) {
+			t.Errorf("New(%d) = %+v, expected code %s", status, p, expected)
+		}
+	}
+	if p := New(http.StatusUnprocessableEntity, CodeParentNotFound, "x"); p.Code != CodeParentNotFound {
+		t.Errorf("expected the explicit code to be kept, got %s", p.Code)
+	}
+}
+
+func TestAbort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	handled := false
+	r.GET("/api/things/:id", func(c *gin.Context) {
+		Abort(c, New(http.StatusNotFound, "", "thing not found"))
+	}, func(c *gin.Context) {
+		handled = true
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/things/42", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound || w.Header().Get("Content-Type") != ContentType {
+		t.Fatalf("expected 404 %s, got %d %s", ContentType, w.Code, w.Header().Get("Content-Type"))
+	}
+	if handled {
+		t.Error("expected the handler chain to be aborted")
+	}
+	var body map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	expected := map[string]interface{}{
+		"type": "about:blank", "title": "Not Found", "status": float64(404),
+		"detail": "thing not found", "instance": "/api/things/42", "code": "NOT_FOUND",
+	}
+	for key, value := range expected {
+		if body[key] != value {
+			t.Errorf("%s: expected %v, got %v", key, value, body[key])
+		}
+	}
+	if len(body) != len(expected) {
+		t.Errorf("expected empty extensions to be omitted, got %v", body)
+	}
+}
@@ -0,0 +1,1560 @@
+// API gRPC odpowiadające REST API w handlers/ (CRUD wierzchołków i relacji,
+// pełny graf) oraz strumień zmian Watch.
+//
+// Po zmianie pliku wygeneruj kod ponownie (patrz README, sekcja gRPC).
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: proto/overview.proto
+
+package overviewpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Vertex wierzchołek grafu (serwis, baza danych, kolejka, zespół...)
+type Vertex struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug            string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Kind            string                 `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	Metadata        *structpb.Struct       `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Layer           string                 `protobuf:"bytes,7,opt,name=layer,proto3" json:"layer,omitempty"`
+	ParentId        *string                `protobuf:"bytes,8,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"` // Brak = najwyższy poziom
+	Entrypoint      bool                   `protobuf:"varint,9,opt,name=entrypoint,proto3" json:"entrypoint,omitempty"`
+	AvailabilitySlo float64                `protobuf:"fixed64,10,opt,name=availability_slo,json=availabilitySlo,proto3" json:"availability_slo,omitempty"`
+	ProcessingMs    float64                `protobuf:"fixed64,11,opt,name=processing_ms,json=processingMs,proto3" json:"processing_ms,omitempty"`
+	Version         int64                  `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Vertex) Reset() {
+	*x = Vertex{}
+	mi := &file_proto_overview_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vertex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vertex) ProtoMessage() {}
+
+func (x *Vertex) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vertex.ProtoReflect.Descriptor instead.
+func (*Vertex) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Vertex) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Vertex) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Vertex) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Vertex) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Vertex) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Vertex) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Vertex) GetLayer() string {
+	if x != nil {
+		return x.Layer
+	}
+	return ""
+}
+
+func (x *Vertex) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
+func (x *Vertex) GetEntrypoint() bool {
+	if x != nil {
+		return x.Entrypoint
+	}
+	return false
+}
+
+func (x *Vertex) GetAvailabilitySlo() float64 {
+	if x != nil {
+		return x.AvailabilitySlo
+	}
+	return 0
+}
+
+func (x *Vertex) GetProcessingMs() float64 {
+	if x != nil {
+		return x.ProcessingMs
+	}
+	return 0
+}
+
+func (x *Vertex) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Vertex) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Vertex) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Edge relacja między wierzchołkami
+type Edge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	LatencyMs     float64                `protobuf:"fixed64,5,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	Fallback      bool                   `protobuf:"varint,6,opt,name=fallback,proto3" json:"fallback,omitempty"`
+	Version       int64                  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Edge) Reset() {
+	*x = Edge{}
+	mi := &file_proto_overview_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Edge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Edge) ProtoMessage() {}
+
+func (x *Edge) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Edge.ProtoReflect.Descriptor instead.
+func (*Edge) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Edge) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Edge) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Edge) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Edge) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Edge) GetLatencyMs() float64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *Edge) GetFallback() bool {
+	if x != nil {
+		return x.Fallback
+	}
+	return false
+}
+
+func (x *Edge) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Edge) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Edge) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// LayerViolation relacja łamiąca model warstwowy
+type LayerViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // upward lub skip
+	EdgeId        string                 `protobuf:"bytes,2,opt,name=edge_id,json=edgeId,proto3" json:"edge_id,omitempty"`
+	Dependent     string                 `protobuf:"bytes,3,opt,name=dependent,proto3" json:"dependent,omitempty"`
+	Dependency    string                 `protobuf:"bytes,4,opt,name=dependency,proto3" json:"dependency,omitempty"`
+	FromLayer     string                 `protobuf:"bytes,5,opt,name=from_layer,json=fromLayer,proto3" json:"from_layer,omitempty"`
+	ToLayer       string                 `protobuf:"bytes,6,opt,name=to_layer,json=toLayer,proto3" json:"to_layer,omitempty"`
+	Message       string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LayerViolation) Reset() {
+	*x = LayerViolation{}
+	mi := &file_proto_overview_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LayerViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayerViolation) ProtoMessage() {}
+
+func (x *LayerViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayerViolation.ProtoReflect.Descriptor instead.
+func (*LayerViolation) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LayerViolation) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LayerViolation) GetEdgeId() string {
+	if x != nil {
+		return x.EdgeId
+	}
+	return ""
+}
+
+func (x *LayerViolation) GetDependent() string {
+	if x != nil {
+		return x.Dependent
+	}
+	return ""
+}
+
+func (x *LayerViolation) GetDependency() string {
+	if x != nil {
+		return x.Dependency
+	}
+	return ""
+}
+
+func (x *LayerViolation) GetFromLayer() string {
+	if x != nil {
+		return x.FromLayer
+	}
+	return ""
+}
+
+func (x *LayerViolation) GetToLayer() string {
+	if x != nil {
+		return x.ToLayer
+	}
+	return ""
+}
+
+func (x *LayerViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Graph pełny graf
+type Graph struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Vertices        []*Vertex              `protobuf:"bytes,1,rep,name=vertices,proto3" json:"vertices,omitempty"`
+	Edges           []*Edge                `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	LayerViolations []*LayerViolation      `protobuf:"bytes,3,rep,name=layer_violations,json=layerViolations,proto3" json:"layer_violations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Graph) Reset() {
+	*x = Graph{}
+	mi := &file_proto_overview_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Graph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Graph) ProtoMessage() {}
+
+func (x *Graph) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Graph.ProtoReflect.Descriptor instead.
+func (*Graph) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Graph) GetVertices() []*Vertex {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+func (x *Graph) GetEdges() []*Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *Graph) GetLayerViolations() []*LayerViolation {
+	if x != nil {
+		return x.LayerViolations
+	}
+	return nil
+}
+
+type ListVerticesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVerticesRequest) Reset() {
+	*x = ListVerticesRequest{}
+	mi := &file_proto_overview_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVerticesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVerticesRequest) ProtoMessage() {}
+
+func (x *ListVerticesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVerticesRequest.ProtoReflect.Descriptor instead.
+func (*ListVerticesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{4}
+}
+
+type ListVerticesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vertices      []*Vertex              `protobuf:"bytes,1,rep,name=vertices,proto3" json:"vertices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVerticesResponse) Reset() {
+	*x = ListVerticesResponse{}
+	mi := &file_proto_overview_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVerticesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVerticesResponse) ProtoMessage() {}
+
+func (x *ListVerticesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVerticesResponse.ProtoReflect.Descriptor instead.
+func (*ListVerticesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListVerticesResponse) GetVertices() []*Vertex {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+type GetVertexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // ID lub slug
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVertexRequest) Reset() {
+	*x = GetVertexRequest{}
+	mi := &file_proto_overview_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVertexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVertexRequest) ProtoMessage() {}
+
+func (x *GetVertexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVertexRequest.ProtoReflect.Descriptor instead.
+func (*GetVertexRequest) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetVertexRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateVertexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vertex        *Vertex                `protobuf:"bytes,1,opt,name=vertex,proto3" json:"vertex,omitempty"` // Puste ID jest generowane (UUIDv7)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVertexRequest) Reset() {
+	*x = CreateVertexRequest{}
+	mi := &file_proto_overview_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVertexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVertexRequest) ProtoMessage() {}
+
+func (x *CreateVertexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVertexRequest.ProtoReflect.Descriptor instead.
+func (*CreateVertexRequest) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateVertexRequest) GetVertex() *Vertex {
+	if x != nil {
+		return x.Vertex
+	}
+	return nil
+}
+
+type UpdateVertexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // ID lub slug
+	Vertex        *Vertex                `protobuf:"bytes,2,opt,name=vertex,proto3" json:"vertex,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"` // Oczekiwana wersja; 0 = bez sprawdzania
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVertexRequest) Reset() {
+	*x = UpdateVertexRequest{}
+	mi := &file_proto_overview_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVertexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVertexRequest) ProtoMessage() {}
+
+func (x *UpdateVertexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVertexRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVertexRequest) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateVertexRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateVertexRequest) GetVertex() *Vertex {
+	if x != nil {
+		return x.Vertex
+	}
+	return nil
+}
+
+func (x *UpdateVertexRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type MoveVertexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                   // ID lub slug
+	ParentId      *string                `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"` // Brak = najwyższy poziom
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`                        // Oczekiwana wersja; 0 = bez sprawdzania
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveVertexRequest) Reset() {
+	*x = MoveVertexRequest{}
+	mi := &file_proto_overview_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveVertexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveVertexRequest) ProtoMessage() {}
+
+func (x *MoveVertexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveVertexRequest.ProtoReflect.Descriptor instead.
+func (*MoveVertexRequest) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MoveVertexRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MoveVertexRequest) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
+func (x *MoveVertexRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteVertexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`            // ID lub slug
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"` // Oczekiwana wersja; 0 = bez sprawdzania
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVertexRequest) Reset() {
+	*x = DeleteVertexRequest{}
+	mi := &file_proto_overview_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVertexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVertexRequest) ProtoMessage() {}
+
+func (x *DeleteVertexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVertexRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVertexRequest) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteVertexRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteVertexRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteVertexResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVertexResponse) Reset() {
+	*x = DeleteVertexResponse{}
+	mi := &file_proto_overview_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVertexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVertexResponse) ProtoMessage() {}
+
+func (x *DeleteVertexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVertexResponse.ProtoReflect.Descriptor instead.
+func (*DeleteVertexResponse) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{11}
+}
+
+type ListEdgesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEdgesRequest) Reset() {
+	*x = ListEdgesRequest{}
+	mi := &file_proto_overview_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEdgesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEdgesRequest) ProtoMessage() {}
+
+func (x *ListEdgesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEdgesRequest.ProtoReflect.Descriptor instead.
+func (*ListEdgesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{12}
+}
+
+type ListEdgesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Edges         []*Edge                `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEdgesResponse) Reset() {
+	*x = ListEdgesResponse{}
+	mi := &file_proto_overview_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEdgesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEdgesResponse) ProtoMessage() {}
+
+func (x *ListEdgesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEdgesResponse.ProtoReflect.Descriptor instead.
+func (*ListEdgesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListEdgesResponse) GetEdges() []*Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+type GetEdgeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEdgeRequest) Reset() {
+	*x = GetEdgeRequest{}
+	mi := &file_proto_overview_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEdgeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEdgeRequest) ProtoMessage() {}
+
+func (x *GetEdgeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEdgeRequest.ProtoReflect.Descriptor instead.
+func (*GetEdgeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetEdgeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateEdgeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Edge          *Edge                  `protobuf:"bytes,1,opt,name=edge,proto3" json:"edge,omitempty"` // Puste ID jest generowane (UUIDv7)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEdgeRequest) Reset() {
+	*x = CreateEdgeRequest{}
+	mi := &file_proto_overview_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEdgeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEdgeRequest) ProtoMessage() {}
+
+func (x *CreateEdgeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEdgeRequest.ProtoReflect.Descriptor instead.
+func (*CreateEdgeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateEdgeRequest) GetEdge() *Edge {
+	if x != nil {
+		return x.Edge
+	}
+	return nil
+}
+
+type UpdateEdgeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Edge          *Edge                  `protobuf:"bytes,2,opt,name=edge,proto3" json:"edge,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"` // Oczekiwana wersja; 0 = bez sprawdzania
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEdgeRequest) Reset() {
+	*x = UpdateEdgeRequest{}
+	mi := &file_proto_overview_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEdgeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEdgeRequest) ProtoMessage() {}
+
+func (x *UpdateEdgeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEdgeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEdgeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateEdgeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateEdgeRequest) GetEdge() *Edge {
+	if x != nil {
+		return x.Edge
+	}
+	return nil
+}
+
+func (x *UpdateEdgeRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteEdgeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"` // Oczekiwana wersja; 0 = bez sprawdzania
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEdgeRequest) Reset() {
+	*x = DeleteEdgeRequest{}
+	mi := &file_proto_overview_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEdgeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEdgeRequest) ProtoMessage() {}
+
+func (x *DeleteEdgeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEdgeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEdgeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteEdgeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteEdgeRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteEdgeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEdgeResponse) Reset() {
+	*x = DeleteEdgeResponse{}
+	mi := &file_proto_overview_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEdgeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEdgeResponse) ProtoMessage() {}
+
+func (x *DeleteEdgeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEdgeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteEdgeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{18}
+}
+
+type GetGraphRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGraphRequest) Reset() {
+	*x = GetGraphRequest{}
+	mi := &file_proto_overview_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGraphRequest) ProtoMessage() {}
+
+func (x *GetGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGraphRequest.ProtoReflect.Descriptor instead.
+func (*GetGraphRequest) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{19}
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []string               `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"` // Np. vertex.created, edge.deleted; puste = wszystkie
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_proto_overview_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WatchRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+// Event zmiana wierzchołka lub relacji; przy usunięciu zawiera ostatni znany stan
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type  string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Time  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// Types that are valid to be assigned to Object:
+	//
+	//	*Event_Vertex
+	//	*Event_Edge
+	Object        isEvent_Object `protobuf_oneof:"object"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_proto_overview_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_overview_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_proto_overview_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Event) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Event) GetObject() isEvent_Object {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *Event) GetVertex() *Vertex {
+	if x != nil {
+		if x, ok := x.Object.(*Event_Vertex); ok {
+			return x.Vertex
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetEdge() *Edge {
+	if x != nil {
+		if x, ok := x.Object.(*Event_Edge); ok {
+			return x.Edge
+		}
+	}
+	return nil
+}
+
+type isEvent_Object interface {
+	isEvent_Object()
+}
+
+type Event_Vertex struct {
+	Vertex *Vertex `protobuf:"bytes,4,opt,name=vertex,proto3,oneof"`
+}
+
+type Event_Edge struct {
+	Edge *Edge `protobuf:"bytes,5,opt,name=edge,proto3,oneof"`
+}
+
+func (*Event_Vertex) isEvent_Object() {}
+
+func (*Event_Edge) isEvent_Object() {}
+
+var File_proto_overview_proto protoreflect.FileDescriptor
+
+const file_proto_overview_proto_rawDesc = "" +
+	"\n" +
+	"\x14proto/overview.proto\x12\voverview.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf1\x03\n" +
+	"\x06Vertex\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04kind\x18\x05 \x01(\tR\x04kind\x123\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12\x14\n" +
+	"\x05layer\x18\a \x01(\tR\x05layer\x12 \n" +
+	"\tparent_id\x18\b \x01(\tH\x00R\bparentId\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"entrypoint\x18\t \x01(\bR\n" +
+	"entrypoint\x12)\n" +
+	"\x10availability_slo\x18\n" +
+	" \x01(\x01R\x0favailabilitySlo\x12#\n" +
+	"\rprocessing_ms\x18\v \x01(\x01R\fprocessingMs\x12\x18\n" +
+	"\aversion\x18\f \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\f\n" +
+	"\n" +
+	"_parent_id\"\x99\x02\n" +
+	"\x04Edge\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x05 \x01(\x01R\tlatencyMs\x12\x1a\n" +
+	"\bfallback\x18\x06 \x01(\bR\bfallback\x12\x18\n" +
+	"\aversion\x18\a \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xcf\x01\n" +
+	"\x0eLayerViolation\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x17\n" +
+	"\aedge_id\x18\x02 \x01(\tR\x06edgeId\x12\x1c\n" +
+	"\tdependent\x18\x03 \x01(\tR\tdependent\x12\x1e\n" +
+	"\n" +
+	"dependency\x18\x04 \x01(\tR\n" +
+	"dependency\x12\x1d\n" +
+	"\n" +
+	"from_layer\x18\x05 \x01(\tR\tfromLayer\x12\x19\n" +
+	"\bto_layer\x18\x06 \x01(\tR\atoLayer\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\"\xa9\x01\n" +
+	"\x05Graph\x12/\n" +
+	"\bvertices\x18\x01 \x03(\v2\x13.overview.v1.VertexR\bvertices\x12'\n" +
+	"\x05edges\x18\x02 \x03(\v2\x11.overview.v1.EdgeR\x05edges\x12F\n" +
+	"\x10layer_violations\x18\x03 \x03(\v2\x1b.overview.v1.LayerViolationR\x0flayerViolations\"\x15\n" +
+	"\x13ListVerticesRequest\"G\n" +
+	"\x14ListVerticesResponse\x12/\n" +
+	"\bvertices\x18\x01 \x03(\v2\x13.overview.v1.VertexR\bvertices\"\"\n" +
+	"\x10GetVertexRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"B\n" +
+	"\x13CreateVertexRequest\x12+\n" +
+	"\x06vertex\x18\x01 \x01(\v2\x13.overview.v1.VertexR\x06vertex\"l\n" +
+	"\x13UpdateVertexRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12+\n" +
+	"\x06vertex\x18\x02 \x01(\v2\x13.overview.v1.VertexR\x06vertex\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"m\n" +
+	"\x11MoveVertexRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\tparent_id\x18\x02 \x01(\tH\x00R\bparentId\x88\x01\x01\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversionB\f\n" +
+	"\n" +
+	"_parent_id\"?\n" +
+	"\x13DeleteVertexRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"\x16\n" +
+	"\x14DeleteVertexResponse\"\x12\n" +
+	"\x10ListEdgesRequest\"<\n" +
+	"\x11ListEdgesResponse\x12'\n" +
+	"\x05edges\x18\x01 \x03(\v2\x11.overview.v1.EdgeR\x05edges\" \n" +
+	"\x0eGetEdgeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\":\n" +
+	"\x11CreateEdgeRequest\x12%\n" +
+	"\x04edge\x18\x01 \x01(\v2\x11.overview.v1.EdgeR\x04edge\"d\n" +
+	"\x11UpdateEdgeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x04edge\x18\x02 \x01(\v2\x11.overview.v1.EdgeR\x04edge\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"=\n" +
+	"\x11DeleteEdgeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"\x14\n" +
+	"\x12DeleteEdgeResponse\"\x11\n" +
+	"\x0fGetGraphRequest\"$\n" +
+	"\fWatchRequest\x12\x14\n" +
+	"\x05types\x18\x01 \x03(\tR\x05types\"\xbd\x01\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12-\n" +
+	"\x06vertex\x18\x04 \x01(\v2\x13.overview.v1.VertexH\x00R\x06vertex\x12'\n" +
+	"\x04edge\x18\x05 \x01(\v2\x11.overview.v1.EdgeH\x00R\x04edgeB\b\n" +
+	"\x06object2\x9a\a\n" +
+	"\fGraphService\x12S\n" +
+	"\fListVertices\x12 .overview.v1.ListVerticesRequest\x1a!.overview.v1.ListVerticesResponse\x12?\n" +
+	"\tGetVertex\x12\x1d.overview.v1.GetVertexRequest\x1a\x13.overview.v1.Vertex\x12E\n" +
+	"\fCreateVertex\x12 .overview.v1.CreateVertexRequest\x1a\x13.overview.v1.Vertex\x12E\n" +
+	"\fUpdateVertex\x12 .overview.v1.UpdateVertexRequest\x1a\x13.overview.v1.Vertex\x12A\n" +
+	"\n" +
+	"MoveVertex\x12\x1e.overview.v1.MoveVertexRequest\x1a\x13.overview.v1.Vertex\x12S\n" +
+	"\fDeleteVertex\x12 .overview.v1.DeleteVertexRequest\x1a!.overview.v1.DeleteVertexResponse\x12J\n" +
+	"\tListEdges\x12\x1d.overview.v1.ListEdgesRequest\x1a\x1e.overview.v1.ListEdgesResponse\x129\n" +
+	"\aGetEdge\x12\x1b.overview.v1.GetEdgeRequest\x1a\x11.overview.v1.Edge\x12?\n" +
+	"\n" +
+	"CreateEdge\x12\x1e.overview.v1.CreateEdgeRequest\x1a\x11.overview.v1.Edge\x12?\n" +
+	"\n" +
+	"UpdateEdge\x12\x1e.overview.v1.UpdateEdgeRequest\x1a\x11.overview.v1.Edge\x12M\n" +
+	"\n" +
+	"DeleteEdge\x12\x1e.overview.v1.DeleteEdgeRequest\x1a\x1f.overview.v1.DeleteEdgeResponse\x12<\n" +
+	"\bGetGraph\x12\x1c.overview.v1.GetGraphRequest\x1a\x12.overview.v1.Graph\x128\n" +
+	"\x05Watch\x12\x19.overview.v1.WatchRequest\x1a\x12.overview.v1.Event0\x01B3Z1microservice_overview/proto/overviewpb;overviewpbb\x06proto3"
+
+var (
+	file_proto_overview_proto_rawDescOnce sync.Once
+	file_proto_overview_proto_rawDescData []byte
+)
+
+func file_proto_overview_proto_rawDescGZIP() []byte {
+	file_proto_overview_proto_rawDescOnce.Do(func() {
+		file_proto_overview_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_overview_proto_rawDesc), len(file_proto_overview_proto_rawDesc)))
+	})
+	return file_proto_overview_proto_rawDescData
+}
+
+var file_proto_overview_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_proto_overview_proto_goTypes = []any{
+	(*Vertex)(nil),                // 0: overview.v1.Vertex
+	(*Edge)(nil),                  // 1: overview.v1.Edge
+	(*LayerViolation)(nil),        // 2: overview.v1.LayerViolation
+	(*Graph)(nil),                 // 3: overview.v1.Graph
+	(*ListVerticesRequest)(nil),   // 4: overview.v1.ListVerticesRequest
+	(*ListVerticesResponse)(nil),  // 5: overview.v1.ListVerticesResponse
+	(*GetVertexRequest)(nil),      // 6: overview.v1.GetVertexRequest
+	(*CreateVertexRequest)(nil),   // 7: overview.v1.CreateVertexRequest
+	(*UpdateVertexRequest)(nil),   // 8: overview.v1.UpdateVertexRequest
+	(*MoveVertexRequest)(nil),     // 9: overview.v1.MoveVertexRequest
+	(*DeleteVertexRequest)(nil),   // 10: overview.v1.DeleteVertexRequest
+	(*DeleteVertexResponse)(nil),  // 11: overview.v1.DeleteVertexResponse
+	(*ListEdgesRequest)(nil),      // 12: overview.v1.ListEdgesRequest
+	(*ListEdgesResponse)(nil),     // 13: overview.v1.ListEdgesResponse
+	(*GetEdgeRequest)(nil),        // 14: overview.v1.GetEdgeRequest
+	(*CreateEdgeRequest)(nil),     // 15: overview.v1.CreateEdgeRequest
+	(*UpdateEdgeRequest)(nil),     // 16: overview.v1.UpdateEdgeRequest
+	(*DeleteEdgeRequest)(nil),     // 17: overview.v1.DeleteEdgeRequest
+	(*DeleteEdgeResponse)(nil),    // 18: overview.v1.DeleteEdgeResponse
+	(*GetGraphRequest)(nil),       // 19: overview.v1.GetGraphRequest
+	(*WatchRequest)(nil),          // 20: overview.v1.WatchRequest
+	(*Event)(nil),                 // 21: overview.v1.Event
+	(*structpb.Struct)(nil),       // 22: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil), // 23: google.protobuf.Timestamp
+}
+var file_proto_overview_proto_depIdxs = []int32{
+	22, // 0: overview.v1.Vertex.metadata:type_name -> google.protobuf.Struct
+	23, // 1: overview.v1.Vertex.created_at:type_name -> google.protobuf.Timestamp
+	23, // 2: overview.v1.Vertex.updated_at:type_name -> google.protobuf.Timestamp
+	23, // 3: overview.v1.Edge.created_at:type_name -> google.protobuf.Timestamp
+	23, // 4: overview.v1.Edge.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: overview.v1.Graph.vertices:type_name -> overview.v1.Vertex
+	1,  // 6: overview.v1.Graph.edges:type_name -> overview.v1.Edge
+	2,  // 7: overview.v1.Graph.layer_violations:type_name -> overview.v1.LayerViolation
+	0,  // 8: overview.v1.ListVerticesResponse.vertices:type_name -> overview.v1.Vertex
+	0,  // 9: overview.v1.CreateVertexRequest.vertex:type_name -> overview.v1.Vertex
+	0,  // 10: overview.v1.UpdateVertexRequest.vertex:type_name -> overview.v1.Vertex
+	1,  // 11: overview.v1.ListEdgesResponse.edges:type_name -> overview.v1.Edge
+	1,  // 12: overview.v1.CreateEdgeRequest.edge:type_name -> overview.v1.Edge
+	1,  // 13: overview.v1.UpdateEdgeRequest.edge:type_name -> overview.v1.Edge
+	23, // 14: overview.v1.Event.time:type_name -> google.protobuf.Timestamp
+	0,  // 15: overview.v1.Event.vertex:type_name -> overview.v1.Vertex
+	1,  // 16: overview.v1.Event.edge:type_name -> overview.v1.Edge
+	4,  // 17: overview.v1.GraphService.ListVertices:input_type -> overview.v1.ListVerticesRequest
+	6,  // 18: overview.v1.GraphService.GetVertex:input_type -> overview.v1.GetVertexRequest
+	7,  // 19: overview.v1.GraphService.CreateVertex:input_type -> overview.v1.CreateVertexRequest
+	8,  // 20: overview.v1.GraphService.UpdateVertex:input_type -> overview.v1.UpdateVertexRequest
+	9,  // 21: overview.v1.GraphService.MoveVertex:input_type -> overview.v1.MoveVertexRequest
+	10, // 22: overview.v1.GraphService.DeleteVertex:input_type -> overview.v1.DeleteVertexRequest
+	12, // 23: overview.v1.GraphService.ListEdges:input_type -> overview.v1.ListEdgesRequest
+	14, // 24: overview.v1.GraphService.GetEdge:input_type -> overview.v1.GetEdgeRequest
+	15, // 25: overview.v1.GraphService.CreateEdge:input_type -> overview.v1.CreateEdgeRequest
+	16, // 26: overview.v1.GraphService.UpdateEdge:input_type -> overview.v1.UpdateEdgeRequest
+	17, // 27: overview.v1.GraphService.DeleteEdge:input_type -> overview.v1.DeleteEdgeRequest
+	19, // 28: overview.v1.GraphService.GetGraph:input_type -> overview.v1.GetGraphRequest
+	20, // 29: overview.v1.GraphService.Watch:input_type -> overview.v1.WatchRequest
+	5,  // 30: overview.v1.GraphService.ListVertices:output_type -> overview.v1.ListVerticesResponse
+	0,  // 31: overview.v1.GraphService.GetVertex:output_type -> overview.v1.Vertex
+	0,  // 32: overview.v1.GraphService.CreateVertex:output_type -> overview.v1.Vertex
+	0,  // 33: overview.v1.GraphService.UpdateVertex:output_type -> overview.v1.Vertex
+	0,  // 34: overview.v1.GraphService.MoveVertex:output_type -> overview.v1.Vertex
+	11, // 35: overview.v1.GraphService.DeleteVertex:output_type -> overview.v1.DeleteVertexResponse
+	13, // 36: overview.v1.GraphService.ListEdges:output_type -> overview.v1.ListEdgesResponse
+	1,  // 37: overview.v1.GraphService.GetEdge:output_type -> overview.v1.Edge
+	1,  // 38: overview.v1.GraphService.CreateEdge:output_type -> overview.v1.Edge
+	1,  // 39: overview.v1.GraphService.UpdateEdge:output_type -> overview.v1.Edge
+	18, // 40: overview.v1.GraphService.DeleteEdge:output_type -> overview.v1.DeleteEdgeResponse
+	3,  // 41: overview.v1.GraphService.GetGraph:output_type -> overview.v1.Graph
+	21, // 42: overview.v1.GraphService.Watch:output_type -> overview.v1.Event
+	30, // [30:43] is the sub-list for method output_type
+	17, // [17:30] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_proto_overview_proto_init() }
+func file_proto_overview_proto_init() {
+	if File_proto_overview_proto != nil {
+		return
+	}
+	file_proto_overview_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_overview_proto_msgTypes[9].OneofWrappers = []any{}
+	file_proto_overview_proto_msgTypes[21].OneofWrappers = []any{
+		(*Event_Vertex)(nil),
+		(*Event_Edge)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_overview_proto_rawDesc), len(file_proto_overview_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_overview_proto_goTypes,
+		DependencyIndexes: file_proto_overview_proto_depIdxs,
+		MessageInfos:      file_proto_overview_proto_msgTypes,
+	}.Build()
+	File_proto_overview_proto = out.File
+	file_proto_overview_proto_goTypes = nil
+	file_proto_overview_proto_depIdxs = nil
+}
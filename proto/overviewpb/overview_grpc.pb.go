@@ -0,0 +1,607 @@
+// API gRPC odpowiadające REST API w handlers/ (CRUD wierzchołków i relacji,
+// pełny graf) oraz strumień zmian Watch.
+//
+// Po zmianie pliku wygeneruj kod ponownie (patrz README, sekcja gRPC).
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: proto/overview.proto
+
+package overviewpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	GraphService_ListVertices_FullMethodName = "/overview.v1.GraphService/ListVertices"
+	GraphService_GetVertex_FullMethodName    = "/overview.v1.GraphService/GetVertex"
+	GraphService_CreateVertex_FullMethodName = "/overview.v1.GraphService/CreateVertex"
+	GraphService_UpdateVertex_FullMethodName = "/overview.v1.GraphService/UpdateVertex"
+	GraphService_MoveVertex_FullMethodName   = "/overview.v1.GraphService/MoveVertex"
+	GraphService_DeleteVertex_FullMethodName = "/overview.v1.GraphService/DeleteVertex"
+	GraphService_ListEdges_FullMethodName    = "/overview.v1.GraphService/ListEdges"
+	GraphService_GetEdge_FullMethodName      = "/overview.v1.GraphService/GetEdge"
+	GraphService_CreateEdge_FullMethodName   = "/overview.v1.GraphService/CreateEdge"
+	GraphService_UpdateEdge_FullMethodName   = "/overview.v1.GraphService/UpdateEdge"
+	GraphService_DeleteEdge_FullMethodName   = "/overview.v1.GraphService/DeleteEdge"
+	GraphService_GetGraph_FullMethodName     = "/overview.v1.GraphService/GetGraph"
+	GraphService_Watch_FullMethodName        = "/overview.v1.GraphService/Watch"
+)
+
+// GraphServiceClient is the client API for GraphService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GraphServiceClient interface {
+	// Wierzchołki
+	ListVertices(ctx context.Context, in *ListVerticesRequest, opts ...grpc.CallOption) (*ListVerticesResponse, error)
+	GetVertex(ctx context.Context, in *GetVertexRequest, opts ...grpc.CallOption) (*Vertex, error)
+	CreateVertex(ctx context.Context, in *CreateVertexRequest, opts ...grpc.CallOption) (*Vertex, error)
+	UpdateVertex(ctx context.Context, in *UpdateVertexRequest, opts ...grpc.CallOption) (*Vertex, error)
+	MoveVertex(ctx context.Context, in *MoveVertexRequest, opts ...grpc.CallOption) (*Vertex, error)
+	DeleteVertex(ctx context.Context, in *DeleteVertexRequest, opts ...grpc.CallOption) (*DeleteVertexResponse, error)
+	// Relacje
+	ListEdges(ctx context.Context, in *ListEdgesRequest, opts ...grpc.CallOption) (*ListEdgesResponse, error)
+	GetEdge(ctx context.Context, in *GetEdgeRequest, opts ...grpc.CallOption) (*Edge, error)
+	CreateEdge(ctx context.Context, in *CreateEdgeRequest, opts ...grpc.CallOption) (*Edge, error)
+	UpdateEdge(ctx context.Context, in *UpdateEdgeRequest, opts ...grpc.CallOption) (*Edge, error)
+	DeleteEdge(ctx context.Context, in *DeleteEdgeRequest, opts ...grpc.CallOption) (*DeleteEdgeResponse, error)
+	// Graf
+	GetGraph(ctx context.Context, in *GetGraphRequest, opts ...grpc.CallOption) (*Graph, error)
+	// Strumień zmian wierzchołków i relacji od chwili subskrypcji
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (GraphService_WatchClient, error)
+}
+
+type graphServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGraphServiceClient(cc grpc.ClientConnInterface) GraphServiceClient {
+	return &graphServiceClient{cc}
+}
+
+func (c *graphServiceClient) ListVertices(ctx context.Context, in *ListVerticesRequest, opts ...grpc.CallOption) (*ListVerticesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVerticesResponse)
+	err := c.cc.Invoke(ctx, GraphService_ListVertices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) GetVertex(ctx context.Context, in *GetVertexRequest, opts ...grpc.CallOption) (*Vertex, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vertex)
+	err := c.cc.Invoke(ctx, GraphService_GetVertex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) CreateVertex(ctx context.Context, in *CreateVertexRequest, opts ...grpc.CallOption) (*Vertex, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vertex)
+	err := c.cc.Invoke(ctx, GraphService_CreateVertex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) UpdateVertex(ctx context.Context, in *UpdateVertexRequest, opts ...grpc.CallOption) (*Vertex, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vertex)
+	err := c.cc.Invoke(ctx, GraphService_UpdateVertex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) MoveVertex(ctx context.Context, in *MoveVertexRequest, opts ...grpc.CallOption) (*Vertex, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vertex)
+	err := c.cc.Invoke(ctx, GraphService_MoveVertex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) DeleteVertex(ctx context.Context, in *DeleteVertexRequest, opts ...grpc.CallOption) (*DeleteVertexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteVertexResponse)
+	err := c.cc.Invoke(ctx, GraphService_DeleteVertex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) ListEdges(ctx context.Context, in *ListEdgesRequest, opts ...grpc.CallOption) (*ListEdgesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEdgesResponse)
+	err := c.cc.Invoke(ctx, GraphService_ListEdges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) GetEdge(ctx context.Context, in *GetEdgeRequest, opts ...grpc.CallOption) (*Edge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Edge)
+	err := c.cc.Invoke(ctx, GraphService_GetEdge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) CreateEdge(ctx context.Context, in *CreateEdgeRequest, opts ...grpc.CallOption) (*Edge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Edge)
+	err := c.cc.Invoke(ctx, GraphService_CreateEdge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) UpdateEdge(ctx context.Context, in *UpdateEdgeRequest, opts ...grpc.CallOption) (*Edge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Edge)
+	err := c.cc.Invoke(ctx, GraphService_UpdateEdge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) DeleteEdge(ctx context.Context, in *DeleteEdgeRequest, opts ...grpc.CallOption) (*DeleteEdgeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteEdgeResponse)
+	err := c.cc.Invoke(ctx, GraphService_DeleteEdge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) GetGraph(ctx context.Context, in *GetGraphRequest, opts ...grpc.CallOption) (*Graph, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Graph)
+	err := c.cc.Invoke(ctx, GraphService_GetGraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (GraphService_WatchClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GraphService_ServiceDesc.Streams[0], GraphService_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &graphServiceWatchClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GraphService_WatchClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type graphServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *graphServiceWatchClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GraphServiceServer is the server API for GraphService service.
+// All implementations must embed UnimplementedGraphServiceServer
+// for forward compatibility
+type GraphServiceServer interface {
+	// Wierzchołki
+	ListVertices(context.Context, *ListVerticesRequest) (*ListVerticesResponse, error)
+	GetVertex(context.Context, *GetVertexRequest) (*Vertex, error)
+	CreateVertex(context.Context, *CreateVertexRequest) (*Vertex, error)
+	UpdateVertex(context.Context, *UpdateVertexRequest) (*Vertex, error)
+	MoveVertex(context.Context, *MoveVertexRequest) (*Vertex, error)
+	DeleteVertex(context.Context, *DeleteVertexRequest) (*DeleteVertexResponse, error)
+	// Relacje
+	ListEdges(context.Context, *ListEdgesRequest) (*ListEdgesResponse, error)
+	GetEdge(context.Context, *GetEdgeRequest) (*Edge, error)
+	CreateEdge(context.Context, *CreateEdgeRequest) (*Edge, error)
+	UpdateEdge(context.Context, *UpdateEdgeRequest) (*Edge, error)
+	DeleteEdge(context.Context, *DeleteEdgeRequest) (*DeleteEdgeResponse, error)
+	// Graf
+	GetGraph(context.Context, *GetGraphRequest) (*Graph, error)
+	// Strumień zmian wierzchołków i relacji od chwili subskrypcji
+	Watch(*WatchRequest, GraphService_WatchServer) error
+	mustEmbedUnimplementedGraphServiceServer()
+}
+
+// UnimplementedGraphServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGraphServiceServer struct {
+}
+
+func (UnimplementedGraphServiceServer) ListVertices(context.Context, *ListVerticesRequest) (*ListVerticesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVertices not implemented")
+}
+func (UnimplementedGraphServiceServer) GetVertex(context.Context, *GetVertexRequest) (*Vertex, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVertex not implemented")
+}
+func (UnimplementedGraphServiceServer) CreateVertex(context.Context, *CreateVertexRequest) (*Vertex, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVertex not implemented")
+}
+func (UnimplementedGraphServiceServer) UpdateVertex(context.Context, *UpdateVertexRequest) (*Vertex, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateVertex not implemented")
+}
+func (UnimplementedGraphServiceServer) MoveVertex(context.Context, *MoveVertexRequest) (*Vertex, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveVertex not implemented")
+}
+func (UnimplementedGraphServiceServer) DeleteVertex(context.Context, *DeleteVertexRequest) (*DeleteVertexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVertex not implemented")
+}
+func (UnimplementedGraphServiceServer) ListEdges(context.Context, *ListEdgesRequest) (*ListEdgesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEdges not implemented")
+}
+func (UnimplementedGraphServiceServer) GetEdge(context.Context, *GetEdgeRequest) (*Edge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEdge not implemented")
+}
+func (UnimplementedGraphServiceServer) CreateEdge(context.Context, *CreateEdgeRequest) (*Edge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEdge not implemented")
+}
+func (UnimplementedGraphServiceServer) UpdateEdge(context.Context, *UpdateEdgeRequest) (*Edge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEdge not implemented")
+}
+func (UnimplementedGraphServiceServer) DeleteEdge(context.Context, *DeleteEdgeRequest) (*DeleteEdgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEdge not implemented")
+}
+func (UnimplementedGraphServiceServer) GetGraph(context.Context, *GetGraphRequest) (*Graph, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGraph not implemented")
+}
+func (UnimplementedGraphServiceServer) Watch(*WatchRequest, GraphService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedGraphServiceServer) mustEmbedUnimplementedGraphServiceServer() {}
+
+// UnsafeGraphServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GraphServiceServer will
+// result in compilation errors.
+type UnsafeGraphServiceServer interface {
+	mustEmbedUnimplementedGraphServiceServer()
+}
+
+func RegisterGraphServiceServer(s grpc.ServiceRegistrar, srv GraphServiceServer) {
+	s.RegisterService(&GraphService_ServiceDesc, srv)
+}
+
+func _GraphService_ListVertices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVerticesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ListVertices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_ListVertices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ListVertices(ctx, req.(*ListVerticesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_GetVertex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVertexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).GetVertex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_GetVertex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).GetVertex(ctx, req.(*GetVertexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_CreateVertex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVertexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).CreateVertex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_CreateVertex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).CreateVertex(ctx, req.(*CreateVertexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_UpdateVertex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVertexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).UpdateVertex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_UpdateVertex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).UpdateVertex(ctx, req.(*UpdateVertexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_MoveVertex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveVertexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).MoveVertex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_MoveVertex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).MoveVertex(ctx, req.(*MoveVertexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_DeleteVertex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVertexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).DeleteVertex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_DeleteVertex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).DeleteVertex(ctx, req.(*DeleteVertexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_ListEdges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEdgesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ListEdges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_ListEdges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ListEdges(ctx, req.(*ListEdgesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_GetEdge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEdgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).GetEdge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_GetEdge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).GetEdge(ctx, req.(*GetEdgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_CreateEdge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEdgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).CreateEdge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_CreateEdge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).CreateEdge(ctx, req.(*CreateEdgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_UpdateEdge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEdgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).UpdateEdge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_UpdateEdge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).UpdateEdge(ctx, req.(*UpdateEdgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_DeleteEdge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEdgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).DeleteEdge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_DeleteEdge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).DeleteEdge(ctx, req.(*DeleteEdgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_GetGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).GetGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_GetGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).GetGraph(ctx, req.(*GetGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GraphServiceServer).Watch(m, &graphServiceWatchServer{ServerStream: stream})
+}
+
+type GraphService_WatchServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type graphServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *graphServiceWatchServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// GraphService_ServiceDesc is the grpc.ServiceDesc for GraphService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GraphService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "overview.v1.GraphService",
+	HandlerType: (*GraphServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListVertices",
+			Handler:    _GraphService_ListVertices_Handler,
+		},
+		{
+			MethodName: "GetVertex",
+			Handler:    _GraphService_GetVertex_Handler,
+		},
+		{
+			MethodName: "CreateVertex",
+			Handler:    _GraphService_CreateVertex_Handler,
+		},
+		{
+			MethodName: "UpdateVertex",
+			Handler:    _GraphService_UpdateVertex_Handler,
+		},
+		{
+			MethodName: "MoveVertex",
+			Handler:    _GraphService_MoveVertex_Handler,
+		},
+		{
+			MethodName: "DeleteVertex",
+			Handler:    _GraphService_DeleteVertex_Handler,
+		},
+		{
+			MethodName: "ListEdges",
+			Handler:    _GraphService_ListEdges_Handler,
+		},
+		{
+			MethodName: "GetEdge",
+			Handler:    _GraphService_GetEdge_Handler,
+		},
+		{
+			MethodName: "CreateEdge",
+			Handler:    _GraphService_CreateEdge_Handler,
+		},
+		{
+			MethodName: "UpdateEdge",
+			Handler:    _GraphService_UpdateEdge_Handler,
+		},
+		{
+			MethodName: "DeleteEdge",
+			Handler:    _GraphService_DeleteEdge_Handler,
+		},
+		{
+			MethodName: "GetGraph",
+			Handler:    _GraphService_GetGraph_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _GraphService_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/overview.proto",
+}
@@ -0,0 +1,587 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"microservice_overview/models"
+)
+
+// MaxRows górny limit wierszy wyniku; dłuższy wynik jest obcinany
+const MaxRows = 1000
+
+// MaxSteps budżet wyszukiwania dopasowań: liczba sprawdzonych wierzchołków
+// i przejść relacjami. Wzorce o zmiennej długości mogą w gęstym grafie
+// wymagać wykładniczo wielu kroków niezależnie od liczby wierszy wyniku
+const MaxSteps = 1_000_000
+
+// ErrTooExpensive wyszukiwanie przekroczyło budżet kroków (zob. CostError)
+var ErrTooExpensive = errors.New("query too expensive")
+
+// CostError zapytanie przerwane po przekroczeniu budżetu kroków
+type CostError struct {
+	Steps int
+}
+
+func (e *CostError) Error() string {
+	return fmt.Sprintf("%s: search exceeded %d steps - narrow the pattern with vertex kinds, properties, relationship types or a lower hop limit", ErrTooExpensive, e.Steps)
+}
+
+// Is pozwala porównywać błąd z ErrTooExpensive przez errors.Is
+func (e *CostError) Is(target error) bool {
+	return target == ErrTooExpensive
+}
+
+// Result wynik zapytania: nazwy kolumn i wiersze wartości (wierzchołki,
+// relacje, listy relacji lub wartości właściwości)
+type Result struct {
+	Columns   []string        `json:"columns"`
+	Rows      [][]interface{} `json:"rows"`
+	Count     int             `json:"count"`
+	Truncated bool            `json:"truncated"` // Wynik obcięty do LIMIT lub MaxRows
+}
+
+// Run parsuje i wykonuje zapytanie na wierzchołkach i relacjach grafu
+func Run(input string, graph *models.Graph) (*Result, error) {
+	q, err := Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return Execute(q, graph)
+}
+
+// binding wartość zmiennej: wierzchołek, relacja lub lista relacji (dla relacji o zmiennej długości)
+type binding struct {
+	vertex *models.Vertex
+	edge   *models.Edge
+	edges  []*models.Edge
+	path   bool
+}
+
+// executor indeks grafu i stan wyszukiwania dopasowań
+type executor struct {
+	query    *Query
+	vertices []models.Vertex
+	byID     map[string]*models.Vertex
+	slugs    map[string]string
+	out, in  map[string][]*models.Edge
+	used     map[*models.Edge]bool // relacja może wystąpić w dopasowaniu tylko raz
+	bindings map[string]binding
+	result   *Result
+	seen     map[string]bool // klucze wierszy dla DISTINCT
+	limit    int
+
+	where       []Expr            // Warunki WHERE sprawdzane dla pełnego dopasowania
+	nodeFilters map[string][]Expr // Warunki dotyczące tylko jednej zmiennej wierzchołka
+	steps       int               // Zużyte kroki wyszukiwania
+	maxSteps    int
+	err         error
+}
+
+// Execute wykonuje sparsowane zapytanie. Dopasowania są wyszukiwane
+// w kolejności wierzchołków i relacji z grafu, a każda relacja może wystąpić
+// w jednym dopasowaniu co najwyżej raz. Wyszukiwanie dłuższe niż MaxSteps
+// kończy się błędem CostError.
+func Execute(q *Query, graph *models.Graph) (*Result, error) {
+	return execute(q, graph, MaxSteps)
+}
+
+func execute(q *Query, graph *models.Graph, maxSteps int) (*Result, error) {
+	e := &executor{
+		query:    q,
+		vertices: graph.Vertices,
+		byID:     make(map[string]*models.Vertex, len(graph.Vertices)),
+		slugs:    make(map[string]string, len(graph.Vertices)),
+		out:      make(map[string][]*models.Edge),
+		in:       make(map[string][]*models.Edge),
+		used:     make(map[*models.Edge]bool),
+		bindings: make(map[string]binding),
+		result:   &Result{Rows: [][]interface{}{}},
+		seen:     make(map[string]bool),
+		limit:    MaxRows,
+		maxSteps: maxSteps,
+	}
+	if q.Limit > 0 && q.Limit < MaxRows {
+		e.limit = q.Limit
+	}
+	for i := range graph.Vertices {
+		v := &graph.Vertices[i]
+		e.byID[v.ID] = v
+		if v.Slug != "" {
+			e.slugs[v.Slug] = v.ID
+		}
+	}
+	for i := range graph.Edges {
+		edge := &graph.Edges[i]
+		e.out[edge.From] = append(e.out[edge.From], edge)
+		e.in[edge.To] = append(e.in[edge.To], edge)
+	}
+	for _, item := range q.Return {
+		e.result.Columns = append(e.result.Columns, item.Alias)
+	}
+	e.pushDownWhere()
+
+	for i := range e.vertices {
+		if !e.matchNode(0, &e.vertices[i]) {
+			break
+		}
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+	e.result.Count = len(e.result.Rows)
+	return e.result, nil
+}
+
+// pushDownWhere dzieli WHERE na warunki połączone AND. Warunki dotyczące
+// tylko jednej zmiennej wierzchołka są sprawdzane już przy jej wiązaniu
+// (nodeMatches), więc niepasujące wierzchołki nie są dalej rozwijane
+func (e *executor) pushDownWhere() {
+	nodeVars := make(map[string]bool)
+	for _, node := range e.query.Pattern.Nodes {
+		if node.Var != "" {
+			nodeVars[node.Var] = true
+		}
+	}
+	e.nodeFilters = make(map[string][]Expr)
+	for _, cond := range conjuncts(e.query.Where) {
+		vars := make(map[string]bool)
+		exprVars(cond, vars)
+		if len(vars) == 1 {
+			for name := range vars {
+				if nodeVars[name] {
+					e.nodeFilters[name] = append(e.nodeFilters[name], cond)
+					cond = nil
+				}
+			}
+		}
+		if cond != nil {
+			e.where = append(e.where, cond)
+		}
+	}
+}
+
+// conjuncts rozbija warunek na składniki koniunkcji najwyższego poziomu
+func conjuncts(expr Expr) []Expr {
+	if expr == nil {
+		return nil
+	}
+	if l, ok := expr.(Logical); ok && l.Op == "AND" {
+		return append(conjuncts(l.Left), conjuncts(l.Right)...)
+	}
+	return []Expr{expr}
+}
+
+// exprVars zbiera zmienne użyte w warunku
+func exprVars(expr Expr, vars map[string]bool) {
+	switch ex := expr.(type) {
+	case Logical:
+		exprVars(ex.Left, vars)
+		exprVars(ex.Right, vars)
+	case Not:
+		exprVars(ex.Expr, vars)
+	case Comparison:
+		for _, o := range []Operand{ex.Left, ex.Right} {
+			if o.Var != "" {
+				vars[o.Var] = true
+			}
+		}
+	}
+}
+
+// step zużywa krok budżetu; po jego wyczerpaniu zapisuje błąd i zwraca false
+func (e *executor) step() bool {
+	e.steps++
+	if e.steps > e.maxSteps {
+		e.err = &CostError{Steps: e.maxSteps}
+		return false
+	}
+	return true
+}
+
+// matchNode wiąże i-ty wierzchołek wzorca i kontynuuje dopasowanie.
+// Zwraca false, gdy osiągnięto limit wierszy lub budżet kroków.
+func (e *executor) matchNode(i int, v *models.Vertex) bool {
+	if !e.step() {
+		return false
+	}
+	node := e.query.Pattern.Nodes[i]
+	if !e.nodeMatches(node, v) {
+		return true
+	}
+	if node.Var != "" {
+		if bound, ok := e.bindings[node.Var]; ok {
+			if bound.vertex != v {
+				return true
+			}
+			return e.continueFrom(i, v)
+		}
+		e.bindings[node.Var] = binding{vertex: v}
+		defer delete(e.bindings, node.Var)
+	}
+	return e.continueFrom(i, v)
+}
+
+// continueFrom dopasowuje relację za i-tym wierzchołkiem lub zapisuje wiersz
+func (e *executor) continueFrom(i int, v *models.Vertex) bool {
+	if i == len(e.query.Pattern.Rels) {
+		return e.emit()
+	}
+	rel := e.query.Pattern.Rels[i]
+	return e.expand(i, rel, v, nil)
+}
+
+// expand przechodzi relacjami i-tej pozycji wzorca; path to dotychczas
+// użyte relacje (dla relacji o zmiennej długości)
+func (e *executor) expand(i int, rel RelPattern, v *models.Vertex, path []*models.Edge) bool {
+	if len(path) >= rel.Min {
+		if rel.Var != "" {
+			b := binding{path: rel.Variable, edges: append([]*models.Edge(nil), path...)}
+			if !rel.Variable {
+				b.edge = path[0]
+			}
+			e.bindings[rel.Var] = b
+		}
+		ok := e.matchNode(i+1, v)
+		if rel.Var != "" {
+			delete(e.bindings, rel.Var)
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(path) == rel.Max {
+		return true
+	}
+
+	for _, step := range e.next(rel, v) {
+		if e.used[step.edge] {
+			continue
+		}
+		if !e.step() {
+			return false
+		}
+		e.used[step.edge] = true
+		ok := e.expand(i, rel, step.to, append(path, step.edge))
+		delete(e.used, step.edge)
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+type step struct {
+	edge *models.Edge
+	to   *models.Vertex
+}
+
+// next zwraca relacje wychodzące z v zgodne z kierunkiem i typami wzorca
+func (e *executor) next(rel RelPattern, v *models.Vertex) []step {
+	var steps []step
+	typeMatches := func(edge *models.Edge) bool {
+		if len(rel.Types) == 0 {
+			return true
+		}
+		for _, t := range rel.Types {
+			if t == edge.Type {
+				return true
+			}
+		}
+		return false
+	}
+	if rel.Direction != DirectionIn {
+		for _, edge := range e.out[v.ID] {
+			if to := e.byID[edge.To]; to != nil && typeMatches(edge) {
+				steps = append(steps, step{edge: edge, to: to})
+			}
+		}
+	}
+	if rel.Direction != DirectionOut {
+		for _, edge := range e.in[v.ID] {
+			if from := e.byID[edge.From]; from != nil && typeMatches(edge) {
+				steps = append(steps, step{edge: edge, to: from})
+			}
+		}
+	}
+	return steps
+}
+
+// nodeMatches sprawdza rodzaj, właściwości wierzchołka i warunki WHERE jego
+// zmiennej. Właściwość domain (lub team) pasuje do wierzchołka o podanym ID
+// lub slugu i całego jego poddrzewa.
+func (e *executor) nodeMatches(node NodePattern, v *models.Vertex) bool {
+	if node.Kind != "" && !strings.EqualFold(node.Kind, v.Kind) {
+		return false
+	}
+	for key, expected := range node.Props {
+		if key == "domain" || key == "team" {
+			ref, ok := expected.(string)
+			if !ok || !e.inDomain(v, ref) {
+				return false
+			}
+			continue
+		}
+		if !equal(vertexProperty(v, key), expected) {
+			return false
+		}
+	}
+	if filters := e.nodeFilters[node.Var]; len(filters) > 0 {
+		previous, bound := e.bindings[node.Var]
+		e.bindings[node.Var] = binding{vertex: v}
+		defer func() {
+			if bound {
+				e.bindings[node.Var] = previous
+			} else {
+				delete(e.bindings, node.Var)
+			}
+		}()
+		for _, cond := range filters {
+			if !e.eval(cond) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// inDomain sprawdza, czy wierzchołek jest wskazaną domeną lub leży w jej poddrzewie
+func (e *executor) inDomain(v *models.Vertex, ref string) bool {
+	domainID := ref
+	if id, ok := e.slugs[ref]; ok && e.byID[ref] == nil {
+		domainID = id
+	}
+	visited := make(map[string]bool)
+	for current := v; current != nil && !visited[current.ID]; {
+		if current.ID == domainID {
+			return true
+		}
+		visited[current.ID] = true
+		if current.ParentID == nil {
+			return false
+		}
+		current = e.byID[*current.ParentID]
+	}
+	return false
+}
+
+// emit sprawdza pozostałe warunki WHERE i zapisuje wiersz. Zwraca false, gdy osiągnięto limit.
+func (e *executor) emit() bool {
+	for _, cond := range e.where {
+		if !e.eval(cond) {
+			return true
+		}
+	}
+	row := make([]interface{}, len(e.query.Return))
+	for i, item := range e.query.Return {
+		row[i] = e.value(item.Var, item.Key)
+	}
+	if e.query.Distinct {
+		key := fmt.Sprintf("%v", rowKey(row))
+		if e.seen[key] {
+			return true
+		}
+		e.seen[key] = true
+	}
+	if len(e.result.Rows) == e.limit {
+		e.result.Truncated = true
+		return false
+	}
+	e.result.Rows = append(e.result.Rows, row)
+	return true
+}
+
+// rowKey zamienia wiersz na porównywalny klucz (wierzchołki i relacje po ID)
+func rowKey(row []interface{}) []interface{} {
+	key := make([]interface{}, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case models.Vertex:
+			key[i] = "v:" + v.ID
+		case models.Edge:
+			key[i] = "e:" + v.ID
+		case []models.Edge:
+			ids := make([]string, len(v))
+			for j := range v {
+				ids[j] = v[j].ID
+			}
+			key[i] = "p:" + strings.Join(ids, ",")
+		default:
+			key[i] = value
+		}
+	}
+	return key
+}
+
+// value zwraca wartość zmiennej lub jej właściwości
+func (e *executor) value(name, key string) interface{} {
+	b := e.bindings[name]
+	switch {
+	case b.vertex != nil:
+		if key == "" {
+			return *b.vertex
+		}
+		return vertexProperty(b.vertex, key)
+	case b.path:
+		if key == "" {
+			edges := make([]models.Edge, len(b.edges))
+			for i, edge := range b.edges {
+				edges[i] = *edge
+			}
+			return edges
+		}
+		if key == "length" {
+			return float64(len(b.edges))
+		}
+		return nil
+	case b.edge != nil:
+		if key == "" {
+			return *b.edge
+		}
+		return edgeProperty(b.edge, key)
+	}
+	return nil
+}
+
+func (e *executor) eval(expr Expr) bool {
+	switch ex := expr.(type) {
+	case Logical:
+		if ex.Op == "AND" {
+			return e.eval(ex.Left) && e.eval(ex.Right)
+		}
+		return e.eval(ex.Left) || e.eval(ex.Right)
+	case Not:
+		return !e.eval(ex.Expr)
+	case Comparison:
+		left, right := e.operand(ex.Left), e.operand(ex.Right)
+		return compare(left, ex.Op, right)
+	}
+	return false
+}
+
+func (e *executor) operand(o Operand) interface{} {
+	if o.Var == "" {
+		return o.Literal
+	}
+	return e.value(o.Var, o.Key)
+}
+
+// vertexProperty zwraca pole wierzchołka lub wartość z jego metadanych
+func vertexProperty(v *models.Vertex, key string) interface{} {
+	switch key {
+	case "id":
+		return v.ID
+	case "name":
+		return v.Name
+	case "slug":
+		return v.Slug
+	case "description":
+		return v.Description
+	case "kind":
+		return v.Kind
+	case "layer":
+		return v.Layer
+	case "parent_id":
+		if v.ParentID == nil {
+			return nil
+		}
+		return *v.ParentID
+	case "entrypoint":
+		return v.Entrypoint
+	case "availability_slo":
+		return v.AvailabilitySLO
+	case "processing_ms":
+		return v.ProcessingMs
+	}
+	return v.Metadata[key]
+}
+
+// edgeProperty zwraca pole relacji
+func edgeProperty(edge *models.Edge, key string) interface{} {
+	switch key {
+	case "id":
+		return edge.ID
+	case "from":
+		return edge.From
+	case "to":
+		return edge.To
+	case "type":
+		return edge.Type
+	case "latency_ms":
+		return edge.LatencyMs
+	case "fallback":
+		return edge.Fallback
+	}
+	return nil
+}
+
+// compare porównuje wartości; liczby porównywane są liczbowo, napisy
+// leksykograficznie, a porównanie z brakującą wartością jest fałszywe (poza <>)
+func compare(left interface{}, op string, right interface{}) bool {
+	switch op {
+	case "=":
+		return equal(left, right)
+	case "<>":
+		return !equal(left, right)
+	case "CONTAINS":
+		l, okL := left.(string)
+		r, okR := right.(string)
+		return okL && okR && strings.Contains(l, r)
+	}
+
+	var cmp int
+	if fl, ok := toFloat(left); ok {
+		fr, ok := toFloat(right)
+		if !ok {
+			return false
+		}
+		switch {
+		case fl < fr:
+			cmp = -1
+		case fl > fr:
+			cmp = 1
+		}
+	} else {
+		l, okL := left.(string)
+		r, okR := right.(string)
+		if !okL || !okR {
+			return false
+		}
+		cmp = strings.Compare(l, r)
+	}
+	switch op {
+	case "<":
+		return cmp < 0
+	case ">":
+		return cmp > 0
+	case "<=":
+		return cmp <= 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+func equal(a, b interface{}) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
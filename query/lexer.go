@@ -0,0 +1,134 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tokenKind rodzaj tokenu zapytania
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenKeyword
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+// keywords słowa kluczowe (bez rozróżniania wielkości liter)
+var keywords = map[string]bool{
+	"MATCH": true, "WHERE": true, "RETURN": true, "DISTINCT": true, "LIMIT": true,
+	"AND": true, "OR": true, "NOT": true, "CONTAINS": true, "AS": true,
+	"TRUE": true, "FALSE": true, "NULL": true,
+}
+
+// twoCharSymbols symbole dwuznakowe; pozostałe symbole są jednoznakowe
+var twoCharSymbols = []string{"..", "<>", "<=", ">=", "!="}
+
+type token struct {
+	kind  tokenKind
+	text  string      // słowa kluczowe wielkimi literami, symbole dosłownie
+	value interface{} // wartość literału tekstowego lub liczbowego
+	pos   int         // pozycja w zapytaniu (od 1)
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lex dzieli zapytanie na tokeny
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '"' || r == '\'':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				sb.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, syntaxError(pos, "unterminated string")
+			}
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), value: sb.String(), pos: pos})
+			i = j + 1
+
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && unicode.IsDigit(runes[j]) {
+				j++
+			}
+			// Część ułamkowa, ale nie operator zakresu "1..3"
+			if j+1 < len(runes) && runes[j] == '.' && unicode.IsDigit(runes[j+1]) {
+				j++
+				for j < len(runes) && unicode.IsDigit(runes[j]) {
+					j++
+				}
+			}
+			text := string(runes[i:j])
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, syntaxError(pos, "invalid number "+text)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, value: value, pos: pos})
+			i = j
+
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			text := string(runes[i:j])
+			if keywords[strings.ToUpper(text)] {
+				tokens = append(tokens, token{kind: tokenKeyword, text: strings.ToUpper(text), pos: pos})
+			} else {
+				tokens = append(tokens, token{kind: tokenIdent, text: text, pos: pos})
+			}
+			i = j
+
+		case r == '`':
+			j := i + 1
+			for j < len(runes) && runes[j] != '`' {
+				j++
+			}
+			if j >= len(runes) {
+				return nil, syntaxError(pos, "unterminated quoted identifier")
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[i+1 : j]), pos: pos})
+			i = j + 1
+
+		default:
+			symbol := string(r)
+			for _, candidate := range twoCharSymbols {
+				if i+1 < len(runes) && string(runes[i:i+2]) == candidate {
+					symbol = candidate
+				}
+			}
+			if !strings.Contains("()[]{}:,.*|-<>=", symbol) && len(symbol) == 1 {
+				return nil, syntaxError(pos, fmt.Sprintf("unexpected character %q", r))
+			}
+			tokens = append(tokens, token{kind: tokenSymbol, text: symbol, pos: pos})
+			i += len([]rune(symbol))
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
@@ -0,0 +1,559 @@
+// Package query wykonuje zapytania o wzorce ścieżek w grafie (podzbiór składni
+// Cypher) na zapisanych wierzchołkach i relacjach.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrSyntax zwracany przy błędzie składni zapytania
+var ErrSyntax = errors.New("query syntax error")
+
+// SyntaxError błąd składni z pozycją w zapytaniu (0 - dotyczy całego zapytania)
+type SyntaxError struct {
+	Pos     int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Pos == 0 {
+		return fmt.Sprintf("%s: %s", ErrSyntax, e.Message)
+	}
+	return fmt.Sprintf("%s at position %d: %s", ErrSyntax, e.Pos, e.Message)
+}
+
+// Is pozwala porównywać błąd z ErrSyntax przez errors.Is
+func (e *SyntaxError) Is(target error) bool {
+	return target == ErrSyntax
+}
+
+func syntaxError(pos int, message string) error {
+	return &SyntaxError{Pos: pos, Message: message}
+}
+
+// Kierunki relacji we wzorcu
+const (
+	DirectionOut  = "out"  // (a)-[]->(b)
+	DirectionIn   = "in"   // (a)<-[]-(b)
+	DirectionBoth = "both" // (a)-[]-(b)
+)
+
+// MaxHops górny limit długości relacji o zmiennej długości (np. [*] lub [*2..])
+const MaxHops = 10
+
+// NodePattern wzorzec wierzchołka: (zmienna:rodzaj {klucz: wartość})
+type NodePattern struct {
+	Var   string
+	Kind  string
+	Props map[string]interface{}
+}
+
+// RelPattern wzorzec relacji: -[zmienna:typ1|typ2*min..max]->
+type RelPattern struct {
+	Var       string
+	Types     []string
+	Direction string
+	Min, Max  int
+	Variable  bool // relacja o zmiennej długości - zmienna wiąże listę relacji
+}
+
+// Pattern ścieżka: wierzchołek, a po nim naprzemiennie relacje i wierzchołki
+type Pattern struct {
+	Nodes []NodePattern
+	Rels  []RelPattern
+}
+
+// Expr warunek klauzuli WHERE
+type Expr interface{}
+
+// Operand właściwość zmiennej (Var.Key) lub literał
+type Operand struct {
+	Var     string
+	Key     string
+	Literal interface{}
+}
+
+// Comparison porównanie dwóch operandów; Op to =, <>, <, >, <=, >= lub CONTAINS
+type Comparison struct {
+	Left, Right Operand
+	Op          string
+}
+
+// Logical koniunkcja (AND) lub alternatywa (OR) warunków
+type Logical struct {
+	Op          string
+	Left, Right Expr
+}
+
+// Not negacja warunku
+type Not struct {
+	Expr Expr
+}
+
+// ReturnItem kolumna wyniku: zmienna lub jej właściwość
+type ReturnItem struct {
+	Var   string
+	Key   string
+	Alias string
+}
+
+// Query sparsowane zapytanie
+type Query struct {
+	Pattern  Pattern
+	Where    Expr
+	Distinct bool
+	Return   []ReturnItem
+	Limit    int // 0 = bez limitu zapytania (obowiązuje MaxRows)
+}
+
+// Parse parsuje zapytanie w składni:
+//
+//	MATCH (a:service {domain: "payments"})-[:calls*1..3]->(b:database)
+//	WHERE b.engine = "postgres" AND NOT a.deprecated = true
+//	RETURN DISTINCT a, b.name AS db LIMIT 10
+func Parse(input string) (*Query, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	q, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	return q, validate(q)
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept pobiera token, jeśli jest symbolem lub słowem kluczowym o podanym tekście
+func (p *parser) accept(text string) bool {
+	t := p.peek()
+	if (t.kind == tokenSymbol || t.kind == tokenKeyword) && t.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		return p.unexpected(fmt.Sprintf("expected %q", text))
+	}
+	return nil
+}
+
+func (p *parser) unexpected(message string) error {
+	t := p.peek()
+	return syntaxError(t.pos, fmt.Sprintf("%s, got %s", message, t))
+}
+
+func (p *parser) ident() (string, error) {
+	t := p.peek()
+	if t.kind != tokenIdent {
+		return "", p.unexpected("expected identifier")
+	}
+	p.pos++
+	return t.text, nil
+}
+
+func (p *parser) parseQuery() (*Query, error) {
+	q := &Query{}
+	if err := p.expect("MATCH"); err != nil {
+		return nil, err
+	}
+	pattern, err := p.parsePattern()
+	if err != nil {
+		return nil, err
+	}
+	q.Pattern = pattern
+
+	if p.accept("WHERE") {
+		if q.Where, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.expect("RETURN"); err != nil {
+		return nil, err
+	}
+	q.Distinct = p.accept("DISTINCT")
+	for {
+		item, err := p.parseReturnItem()
+		if err != nil {
+			return nil, err
+		}
+		q.Return = append(q.Return, item)
+		if !p.accept(",") {
+			break
+		}
+	}
+
+	if p.accept("LIMIT") {
+		t := p.next()
+		limit, ok := t.value.(float64)
+		if t.kind != tokenNumber || !ok || limit < 1 || limit != float64(int(limit)) {
+			return nil, syntaxError(t.pos, "LIMIT must be a positive integer")
+		}
+		q.Limit = int(limit)
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.unexpected("expected end of query")
+	}
+	return q, nil
+}
+
+func (p *parser) parsePattern() (Pattern, error) {
+	var pattern Pattern
+	node, err := p.parseNode()
+	if err != nil {
+		return pattern, err
+	}
+	pattern.Nodes = append(pattern.Nodes, node)
+	for {
+		t := p.peek()
+		if t.kind != tokenSymbol || (t.text != "-" && t.text != "<") {
+			return pattern, nil
+		}
+		rel, err := p.parseRel()
+		if err != nil {
+			return pattern, err
+		}
+		node, err := p.parseNode()
+		if err != nil {
+			return pattern, err
+		}
+		pattern.Rels = append(pattern.Rels, rel)
+		pattern.Nodes = append(pattern.Nodes, node)
+	}
+}
+
+func (p *parser) parseNode() (NodePattern, error) {
+	var node NodePattern
+	if err := p.expect("("); err != nil {
+		return node, err
+	}
+	if p.peek().kind == tokenIdent {
+		node.Var = p.next().text
+	}
+	if p.accept(":") {
+		kind, err := p.ident()
+		if err != nil {
+			return node, err
+		}
+		node.Kind = kind
+	}
+	if p.accept("{") {
+		props, err := p.parseProps()
+		if err != nil {
+			return node, err
+		}
+		node.Props = props
+	}
+	return node, p.expect(")")
+}
+
+func (p *parser) parseProps() (map[string]interface{}, error) {
+	props := make(map[string]interface{})
+	if p.accept("}") {
+		return props, nil
+	}
+	for {
+		key, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		props[key] = value
+		if p.accept("}") {
+			return props, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseLiteral() (interface{}, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenString || t.kind == tokenNumber:
+		return t.value, nil
+	case t.kind == tokenKeyword && t.text == "TRUE":
+		return true, nil
+	case t.kind == tokenKeyword && t.text == "FALSE":
+		return false, nil
+	case t.kind == tokenKeyword && t.text == "NULL":
+		return nil, nil
+	case t.kind == tokenSymbol && t.text == "-" && p.peek().kind == tokenNumber:
+		return -p.next().value.(float64), nil
+	}
+	return nil, syntaxError(t.pos, fmt.Sprintf("expected literal, got %s", t))
+}
+
+// parseRel parsuje -[...]->, <-[...]-, -[...]- oraz skrócone -->, <--, --
+func (p *parser) parseRel() (RelPattern, error) {
+	rel := RelPattern{Min: 1, Max: 1}
+	incoming := p.accept("<")
+	if err := p.expect("-"); err != nil {
+		return rel, err
+	}
+	if p.accept("[") {
+		if err := p.parseRelDetail(&rel); err != nil {
+			return rel, err
+		}
+		if err := p.expect("]"); err != nil {
+			return rel, err
+		}
+	}
+	if err := p.expect("-"); err != nil {
+		return rel, err
+	}
+	outgoing := p.accept(">")
+
+	switch {
+	case outgoing && !incoming:
+		rel.Direction = DirectionOut
+	case incoming && !outgoing:
+		rel.Direction = DirectionIn
+	default:
+		rel.Direction = DirectionBoth
+	}
+	return rel, nil
+}
+
+func (p *parser) parseRelDetail(rel *RelPattern) error {
+	if p.peek().kind == tokenIdent {
+		rel.Var = p.next().text
+	}
+	if p.accept(":") {
+		for {
+			name, err := p.ident()
+			if err != nil {
+				return err
+			}
+			rel.Types = append(rel.Types, normalizeType(name))
+			if !p.accept("|") {
+				break
+			}
+		}
+	}
+	if !p.accept("*") {
+		return nil
+	}
+
+	// *, *n, *n.., *..m, *n..m
+	rel.Variable = true
+	rel.Min, rel.Max = 1, MaxHops
+	if t := p.peek(); t.kind == tokenNumber {
+		n, err := p.hops()
+		if err != nil {
+			return err
+		}
+		rel.Min, rel.Max = n, n
+	}
+	if p.accept("..") {
+		rel.Max = MaxHops
+		if t := p.peek(); t.kind == tokenNumber {
+			n, err := p.hops()
+			if err != nil {
+				return err
+			}
+			rel.Max = n
+		}
+	}
+	if rel.Min > rel.Max {
+		return syntaxError(p.peek().pos, fmt.Sprintf("invalid hop range %d..%d", rel.Min, rel.Max))
+	}
+	return nil
+}
+
+func (p *parser) hops() (int, error) {
+	t := p.next()
+	n := t.value.(float64)
+	if n != float64(int(n)) || n > MaxHops {
+		return 0, syntaxError(t.pos, fmt.Sprintf("hop count must be an integer between 0 and %d", MaxHops))
+	}
+	return int(n), nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = Logical{Op: "OR", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = Logical{Op: "AND", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if p.accept("NOT") {
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: expr}, nil
+	}
+	if p.accept("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return expr, p.expect(")")
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	t := p.next()
+	op := t.text
+	switch {
+	case t.kind == tokenSymbol && (op == "=" || op == "<>" || op == "!=" || op == "<" || op == ">" || op == "<=" || op == ">="):
+		if op == "!=" {
+			op = "<>"
+		}
+	case t.kind == tokenKeyword && op == "CONTAINS":
+	default:
+		return nil, syntaxError(t.pos, fmt.Sprintf("expected comparison operator, got %s", t))
+	}
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return Comparison{Left: left, Op: op, Right: right}, nil
+}
+
+func (p *parser) parseOperand() (Operand, error) {
+	if p.peek().kind != tokenIdent {
+		value, err := p.parseLiteral()
+		return Operand{Literal: value}, err
+	}
+	name := p.next().text
+	if err := p.expect("."); err != nil {
+		return Operand{}, err
+	}
+	key, err := p.ident()
+	return Operand{Var: name, Key: key}, err
+}
+
+func (p *parser) parseReturnItem() (ReturnItem, error) {
+	name, err := p.ident()
+	if err != nil {
+		return ReturnItem{}, err
+	}
+	item := ReturnItem{Var: name, Alias: name}
+	if p.accept(".") {
+		if item.Key, err = p.ident(); err != nil {
+			return item, err
+		}
+		item.Alias = name + "." + item.Key
+	}
+	if p.accept("AS") {
+		if item.Alias, err = p.ident(); err != nil {
+			return item, err
+		}
+	}
+	return item, nil
+}
+
+// validate sprawdza, czy WHERE i RETURN używają zmiennych zdefiniowanych we wzorcu
+func validate(q *Query) error {
+	vars := make(map[string]bool)
+	for _, node := range q.Pattern.Nodes {
+		if node.Var != "" {
+			vars[node.Var] = true
+		}
+	}
+	for _, rel := range q.Pattern.Rels {
+		if rel.Var == "" {
+			continue
+		}
+		if vars[rel.Var] {
+			return syntaxError(0, fmt.Sprintf("variable %s is used for both a vertex and a relationship", rel.Var))
+		}
+		vars[rel.Var] = true
+	}
+
+	var undefined []string
+	check := func(name string) {
+		if name != "" && !vars[name] {
+			undefined = append(undefined, name)
+		}
+	}
+	var walk func(expr Expr)
+	walk = func(expr Expr) {
+		switch e := expr.(type) {
+		case Comparison:
+			check(e.Left.Var)
+			check(e.Right.Var)
+		case Logical:
+			walk(e.Left)
+			walk(e.Right)
+		case Not:
+			walk(e.Expr)
+		}
+	}
+	walk(q.Where)
+	for _, item := range q.Return {
+		check(item.Var)
+	}
+	if len(undefined) > 0 {
+		return syntaxError(0, "undefined variables: "+strings.Join(undefined, ", "))
+	}
+	return nil
+}
+
+// normalizeType sprowadza nazwę typu relacji do postaci z katalogu: "Calls-Async" -> "calls_async"
+func normalizeType(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
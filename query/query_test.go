@@ -0,0 +1,171 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"microservice_overview/models"
+)
+
+func testGraph() *models.Graph {
+	payments := "payments"
+	return &models.Graph{
+		Vertices: []models.Vertex{
+			{ID: "payments", Slug: "payments-team", Kind: "team"},
+			{ID: "checkout", Name: "Checkout", Kind: "service", ParentID: &payments, Metadata: models.JSONMap{"tier": float64(1)}},
+			{ID: "ledger", Name: "Ledger", Kind: "service", ParentID: &payments, Metadata: models.JSONMap{"tier": float64(2)}},
+			{ID: "ledger-db", Name: "Ledger DB", Kind: "database", Metadata: models.JSONMap{"engine": "postgres"}},
+			{ID: "audit-db", Name: "Audit DB", Kind: "database", Metadata: models.JSONMap{"engine": "mongo"}},
+			{ID: "events", Name: "Events", Kind: "queue"},
+		},
+		Edges: []models.Edge{
+			{ID: "e1", From: "checkout", To: "ledger", Type: "calls"},
+			{ID: "e2", From: "ledger", To: "ledger-db", Type: "calls"},
+			{ID: "e3", From: "ledger", To: "audit-db", Type: "calls"},
+			{ID: "e4", From: "checkout", To: "events", Type: "calls_async"},
+		},
+	}
+}
+
+func run(t *testing.T, input string) *Result {
+	t.Helper()
+	result, err := Run(input, testGraph())
+	if err != nil {
+		t.Fatalf("Unexpected error for %q: %v", input, err)
+	}
+	return result
+}
+
+func TestRun_VariableLengthPath(t *testing.T) {
+	result := run(t, `MATCH (a {team:"payments"})-[:calls*1..3]->(b:database) RETURN a.id, b.id`)
+
+	// checkout -> ledger -> (ledger-db, audit-db) oraz ledger -> (ledger-db, audit-db)
+	if result.Count != 4 {
+		t.Fatalf("Expected 4 rows, got %v", result.Rows)
+	}
+	if result.Columns[0] != "a.id" || result.Columns[1] != "b.id" {
+		t.Errorf("Unexpected columns: %v", result.Columns)
+	}
+}
+
+func TestRun_WhereDistinctAndAlias(t *testing.T) {
+	result := run(t, `MATCH (a)-[:calls*]->(b:database) WHERE b.engine = "postgres" AND a.tier <= 1 RETURN DISTINCT b.name AS db`)
+
+	if result.Count != 1 || result.Rows[0][0] != "Ledger DB" || result.Columns[0] != "db" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestRun_DirectionsAndRelationshipVariables(t *testing.T) {
+	// Kto woła ledger?
+	result := run(t, `MATCH (b {id: "ledger"})<-[r:calls]-(a) RETURN a.id, r.id`)
+	if result.Count != 1 || result.Rows[0][0] != "checkout" || result.Rows[0][1] != "e1" {
+		t.Errorf("Unexpected incoming result: %+v", result.Rows)
+	}
+
+	// Relacja bez kierunku i typu, skrócona składnia
+	result = run(t, `MATCH (a {id: "ledger"})--(b) RETURN b.id`)
+	if result.Count != 3 {
+		t.Errorf("Expected 3 neighbours of ledger, got %v", result.Rows)
+	}
+
+	// Ścieżka o zmiennej długości zwraca listę relacji
+	result = run(t, `MATCH (a {id: "checkout"})-[p:calls*2]->(b) RETURN p, p.length`)
+	if result.Count != 2 || len(result.Rows[0][0].([]models.Edge)) != 2 || result.Rows[0][1] != float64(2) {
+		t.Errorf("Unexpected path result: %+v", result.Rows)
+	}
+}
+
+func TestRun_Limit(t *testing.T) {
+	result := run(t, `MATCH (a) RETURN a LIMIT 2`)
+	if result.Count != 2 || !result.Truncated {
+		t.Errorf("Expected 2 rows and truncated result, got %d %v", result.Count, result.Truncated)
+	}
+	if _, ok := result.Rows[0][0].(models.Vertex); !ok {
+		t.Errorf("Expected vertex values, got %T", result.Rows[0][0])
+	}
+}
+
+func TestRun_SameVariableTwice(t *testing.T) {
+	g := testGraph()
+	g.Edges = append(g.Edges, models.Edge{ID: "e5", From: "ledger", To: "checkout", Type: "calls"})
+
+	result, err := Run(`MATCH (a)-[:calls]->(b)-[:calls]->(a) RETURN a.id, b.id`, g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Count != 2 {
+		t.Errorf("Expected cycle found from both ends, got %v", result.Rows)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	queries := []string{
+		`RETURN a`,
+		`MATCH (a RETURN a`,
+		`MATCH (a)-[:calls*3..1]->(b) RETURN a`,
+		`MATCH (a) RETURN b`,
+		`MATCH (a) WHERE a.name ~ "x" RETURN a`,
+		`MATCH (a {name: "x}) RETURN a`,
+		`MATCH (a) RETURN a LIMIT 0`,
+		`MATCH (a)-[a]->(b) RETURN a`,
+	}
+	for _, q := range queries {
+		_, err := Parse(q)
+		var syntaxErr *SyntaxError
+		if !errors.Is(err, ErrSyntax) || !errors.As(err, &syntaxErr) {
+			t.Errorf("Expected syntax error for %q, got %v", q, err)
+		}
+	}
+}
+
+// denseGraph zwraca graf pełny: relacja calls między każdą parą wierzchołków
+func denseGraph(n int) *models.Graph {
+	g := &models.Graph{}
+	for i := 0; i < n; i++ {
+		g.Vertices = append(g.Vertices, models.Vertex{ID: fmt.Sprintf("v%d", i), Name: fmt.Sprintf("Service %d", i), Kind: "service"})
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			g.Edges = append(g.Edges, models.Edge{ID: fmt.Sprintf("e%d-%d", i, j), From: g.Vertices[i].ID, To: g.Vertices[j].ID, Type: "calls"})
+		}
+	}
+	return g
+}
+
+func TestRun_StepBudget(t *testing.T) {
+	g := denseGraph(8)
+
+	// Warunek na końcu ścieżki można sprawdzić dopiero po przejściu wszystkich tras
+	_, err := Run(`MATCH (a)-[*]-(b) WHERE b.name = "nope" RETURN a`, g)
+	var costErr *CostError
+	if !errors.Is(err, ErrTooExpensive) || !errors.As(err, &costErr) || costErr.Steps != MaxSteps {
+		t.Fatalf("Expected CostError after %d steps, got %v", MaxSteps, err)
+	}
+
+	// Warunek na wierzchołku początkowym odrzuca go przed rozwijaniem ścieżek
+	result, err := Run(`MATCH (a)-[*]-(b) WHERE a.name = "nope" RETURN a`, g)
+	if err != nil || result.Count != 0 {
+		t.Fatalf("Expected an empty result without exhausting the budget, got %v %v", result, err)
+	}
+}
+
+func TestExecute_WherePushDown(t *testing.T) {
+	q, err := Parse(`MATCH (a)-[:calls*]->(b:database) WHERE a.id = "checkout" AND b.engine = "postgres" AND (a.tier = 1 OR b.id = "x") RETURN b.id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Bez warunków wiązanych z wierzchołkami ten sam budżet by nie wystarczył
+	result, err := execute(q, testGraph(), 12)
+	if err != nil {
+		t.Fatalf("Expected single-variable conditions to prune the search, got %v", err)
+	}
+	if result.Count != 1 || result.Rows[0][0] != "ledger-db" {
+		t.Errorf("Unexpected result: %+v", result.Rows)
+	}
+	if _, err := execute(q, testGraph(), 5); !errors.Is(err, ErrTooExpensive) {
+		t.Errorf("Expected the small budget to be exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"microservice_overview/models"
+)
+
+// Layers uporządkowany model warstwowy
+type Layers struct {
+	position map[string]int // nazwa warstwy -> pozycja od góry (0 = najwyższa)
+}
+
+// NewLayers porządkuje warstwy po Rank
+func NewLayers(layers []models.Layer) *Layers {
+	sorted := append([]models.Layer(nil), layers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Rank < sorted[j].Rank })
+
+	l := &Layers{position: make(map[string]int, len(sorted))}
+	for i, layer := range sorted {
+		l.position[layer.Name] = i
+	}
+	return l
+}
+
+// LayerOf zwraca warstwę wierzchołka: własną lub najbliższego przodka, który ją ma
+func (g *Graph) LayerOf(vertexID string) string {
+	visited := make(map[string]bool)
+	for current := vertexID; current != "" && !visited[current]; {
+		visited[current] = true
+		v, ok := g.vertices[current]
+		if !ok {
+			return ""
+		}
+		if v.Layer != "" {
+			return v.Layer
+		}
+		if v.ParentID == nil {
+			return ""
+		}
+		current = *v.ParentID
+	}
+	return ""
+}
+
+// CheckEdgeLayers zwraca naruszenia modelu warstwowego przez zależności jednej
+// relacji. Wierzchołki bez warstwy (lub z warstwą spoza modelu) są pomijane.
+func CheckEdgeLayers(layers *Layers, g *Graph, edge models.Edge) []models.LayerViolation {
+	violations := []models.LayerViolation{}
+	for _, dep := range g.Dependencies(edge) {
+		fromLayer, toLayer := g.LayerOf(dep.Dependent), g.LayerOf(dep.Dependency)
+		from, ok := layers.position[fromLayer]
+		if !ok {
+			continue
+		}
+		to, ok := layers.position[toLayer]
+		if !ok {
+			continue
+		}
+
+		var violationType, reason string
+		switch {
+		case to < from:
+			violationType, reason = models.LayerViolationUpward, "depends on a higher layer"
+		case to > from+1:
+			violationType, reason = models.LayerViolationSkip, fmt.Sprintf("skips %d layer(s)", to-from-1)
+		default:
+			continue
+		}
+		violations = append(violations, models.LayerViolation{
+			Type:       violationType,
+			EdgeID:     edge.ID,
+			Dependent:  dep.Dependent,
+			Dependency: dep.Dependency,
+			FromLayer:  fromLayer,
+			ToLayer:    toLayer,
+			Message:    fmt.Sprintf("%s (%s) depends on %s (%s) - %s", dep.Dependent, fromLayer, dep.Dependency, toLayer, reason),
+		})
+	}
+	return violations
+}
+
+// EvaluateLayers sprawdza wszystkie relacje względem modelu warstwowego
+func EvaluateLayers(layers *Layers, g *Graph, edges []models.Edge) []models.LayerViolation {
+	violations := []models.LayerViolation{}
+	for _, edge := range edges {
+		violations = append(violations, CheckEdgeLayers(layers, g, edge)...)
+	}
+	return violations
+}
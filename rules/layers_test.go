@@ -0,0 +1,55 @@
+package rules
+
+import (
+	"testing"
+
+	"microservice_overview/models"
+)
+
+func TestCheckEdgeLayers(t *testing.T) {
+	layers := NewLayers([]models.Layer{
+		{Name: "data", Rank: 50},
+		{Name: "edge", Rank: 10},
+		{Name: "domain", Rank: 30},
+		{Name: "bff", Rank: 20},
+	})
+	g := NewGraph([]models.Vertex{
+		{ID: "domain", Layer: "domain"},
+		{ID: "gateway", Layer: "edge"},
+		{ID: "bff", Layer: "bff"},
+		{ID: "orders", ParentID: stringPtr("domain")},
+		{ID: "db", Layer: "data"},
+		{ID: "unassigned"},
+	}, []models.EdgeType{
+		{Name: "calls", Direction: models.DirectionForward},
+		{Name: "publishes", Direction: models.DirectionReverse},
+	})
+
+	tests := []struct {
+		name     string
+		edge     models.Edge
+		expected string // typ naruszenia, pusty = brak
+	}{
+		{name: "next layer", edge: models.Edge{From: "gateway", To: "bff", Type: "calls"}},
+		{name: "same layer", edge: models.Edge{From: "orders", To: "domain", Type: "calls"}},
+		{name: "inherited layer upward", edge: models.Edge{From: "orders", To: "bff", Type: "calls"}, expected: models.LayerViolationUpward},
+		{name: "skip", edge: models.Edge{From: "bff", To: "db", Type: "calls"}, expected: models.LayerViolationSkip},
+		{name: "reverse edge type", edge: models.Edge{From: "bff", To: "gateway", Type: "publishes"}},
+		{name: "unassigned vertex", edge: models.Edge{From: "unassigned", To: "gateway", Type: "calls"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := CheckEdgeLayers(layers, g, tt.edge)
+			if tt.expected == "" {
+				if len(violations) != 0 {
+					t.Errorf("Expected no violations, got %+v", violations)
+				}
+				return
+			}
+			if len(violations) != 1 || violations[0].Type != tt.expected {
+				t.Errorf("Expected %s violation, got %+v", tt.expected, violations)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// Package rules ocenia reguły architektury (fitness functions) na grafie
+// zależności: granice domen, zależności od wycofywanych wierzchołków, poziomy
+// krytyczności itp.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"microservice_overview/models"
+)
+
+// Graph indeks grafu potrzebny do dopasowywania selektorów
+type Graph struct {
+	vertices  map[string]models.Vertex
+	slugs     map[string]string // slug -> ID
+	edgeTypes map[string]models.EdgeType
+}
+
+// NewGraph buduje indeks z wierzchołków i katalogu typów relacji
+func NewGraph(vertices []models.Vertex, edgeTypes []models.EdgeType) *Graph {
+	g := &Graph{
+		vertices:  make(map[string]models.Vertex, len(vertices)),
+		slugs:     make(map[string]string, len(vertices)),
+		edgeTypes: make(map[string]models.EdgeType, len(edgeTypes)),
+	}
+	for _, v := range vertices {
+		g.vertices[v.ID] = v
+		if v.Slug != "" {
+			g.slugs[v.Slug] = v.ID
+		}
+	}
+	for _, et := range edgeTypes {
+		g.edgeTypes[et.Name] = et
+	}
+	return g
+}
+
+// resolve zwraca ID wierzchołka po ID lub slugu
+func (g *Graph) resolve(ref string) (string, bool) {
+	if _, ok := g.vertices[ref]; ok {
+		return ref, true
+	}
+	id, ok := g.slugs[ref]
+	return id, ok
+}
+
+// inDomain sprawdza czy wierzchołek jest domeną lub leży w jej poddrzewie
+func (g *Graph) inDomain(vertexID, domainID string) bool {
+	visited := make(map[string]bool)
+	for current := vertexID; current != "" && !visited[current]; {
+		if current == domainID {
+			return true
+		}
+		visited[current] = true
+		v, ok := g.vertices[current]
+		if !ok || v.ParentID == nil {
+			return false
+		}
+		current = *v.ParentID
+	}
+	return false
+}
+
+// Matches sprawdza czy wierzchołek pasuje do selektora
+func (g *Graph) Matches(sel models.RuleSelector, vertexID string) bool {
+	v, ok := g.vertices[vertexID]
+	if !ok {
+		return false
+	}
+	if sel.Kind != "" && v.Kind != sel.Kind {
+		return false
+	}
+	if sel.Domain != "" {
+		domainID, ok := g.resolve(sel.Domain)
+		if !ok || !g.inDomain(vertexID, domainID) {
+			return false
+		}
+	}
+	for key, expected := range sel.Metadata {
+		if !valuesEqual(v.Metadata[key], expected) {
+			return false
+		}
+	}
+	return true
+}
+
+// Dependencies zamienia relację na zależności zgodnie z kierunkiem jej typu.
+// Relacja bez typu (lub o nieznanym typie) traktowana jest jak forward.
+func (g *Graph) Dependencies(edge models.Edge) []models.Dependency {
+	var edgeType *models.EdgeType
+	if et, ok := g.edgeTypes[edge.Type]; ok {
+		edgeType = &et
+	}
+	return edgeType.Dependencies(edge)
+}
+
+// ErrInvalidRule zwracany gdy definicja reguły jest niepoprawna
+var ErrInvalidRule = errors.New("invalid rule")
+
+// ValidateRule sprawdza definicję reguły i uzupełnia wartości domyślne
+// (severity error). Domeny w selektorach muszą istnieć w grafie.
+func ValidateRule(rule *models.ArchitectureRule, g *Graph) error {
+	if rule.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRule)
+	}
+	switch rule.Effect {
+	case models.RuleEffectDeny, models.RuleEffectAllowOnly:
+	default:
+		return fmt.Errorf("%w: invalid effect %q - must be one of: deny, allow_only", ErrInvalidRule, rule.Effect)
+	}
+	if rule.Severity == "" {
+		rule.Severity = models.RuleSeverityError
+	}
+	switch rule.Severity {
+	case models.RuleSeverityError, models.RuleSeverityWarning:
+	default:
+		return fmt.Errorf("%w: invalid severity %q - must be one of: error, warning", ErrInvalidRule, rule.Severity)
+	}
+	for _, sel := range []models.RuleSelector{rule.Source, rule.Target} {
+		if sel.Domain == "" {
+			continue
+		}
+		if _, ok := g.resolve(sel.Domain); !ok {
+			return fmt.Errorf("%w: domain vertex %q not found", ErrInvalidRule, sel.Domain)
+		}
+	}
+	return nil
+}
+
+// CheckEdge zwraca naruszenia reguł przez zależności wyrażone jedną relacją
+func CheckEdge(rules []models.ArchitectureRule, g *Graph, edge models.Edge) []models.RuleViolation {
+	violations := []models.RuleViolation{}
+	for _, rule := range rules {
+		if rule.Disabled {
+			continue
+		}
+		if len(rule.EdgeTypes) > 0 && !rule.EdgeTypes.Contains(edge.Type) {
+			continue
+		}
+		for _, dep := range g.Dependencies(edge) {
+			if !g.Matches(rule.Source, dep.Dependent) {
+				continue
+			}
+			targetMatches := g.Matches(rule.Target, dep.Dependency)
+			var reason string
+			switch rule.Effect {
+			case models.RuleEffectDeny:
+				if !targetMatches {
+					continue
+				}
+				reason = "dependency is denied"
+			case models.RuleEffectAllowOnly:
+				if targetMatches {
+					continue
+				}
+				reason = "dependency is outside of allowed targets"
+			default:
+				continue
+			}
+			violations = append(violations, models.RuleViolation{
+				RuleID:     rule.ID,
+				RuleName:   rule.Name,
+				Severity:   rule.Severity,
+				EdgeID:     edge.ID,
+				Dependent:  dep.Dependent,
+				Dependency: dep.Dependency,
+				Message:    fmt.Sprintf("rule %q: %s depends on %s - %s", rule.Name, dep.Dependent, dep.Dependency, reason),
+			})
+		}
+	}
+	return violations
+}
+
+// Evaluate sprawdza wszystkie relacje grafu i zwraca raport naruszeń
+func Evaluate(rules []models.ArchitectureRule, g *Graph, edges []models.Edge) *models.RuleReport {
+	report := &models.RuleReport{Violations: []models.RuleViolation{}}
+	for _, edge := range edges {
+		report.Violations = append(report.Violations, CheckEdge(rules, g, edge)...)
+	}
+	sort.SliceStable(report.Violations, func(i, j int) bool {
+		a, b := report.Violations[i], report.Violations[j]
+		if a.Severity != b.Severity {
+			return a.Severity == models.RuleSeverityError
+		}
+		return a.RuleName < b.RuleName
+	})
+	for _, v := range report.Violations {
+		if v.Severity == models.RuleSeverityError {
+			report.Errors++
+		} else {
+			report.Warnings++
+		}
+	}
+	report.Valid = report.Errors == 0
+	return report
+}
+
+// valuesEqual porównuje wartości metadanych; liczby porównywane są niezależnie
+// od typu (JSON daje float64, kod Go - int)
+func valuesEqual(a, b interface{}) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
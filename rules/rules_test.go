@@ -0,0 +1,172 @@
+package rules
+
+import (
+	"testing"
+
+	"microservice_overview/models"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+// testGraph: domeny billing i marketing z serwisami, wycofywana baza oraz typ publishes
+func testGraph() *Graph {
+	vertices := []models.Vertex{
+		{ID: "billing", Slug: "billing", Kind: "team"},
+		{ID: "marketing", Slug: "marketing", Kind: "team"},
+		{ID: "invoices", Slug: "invoices", Kind: "service", ParentID: stringPtr("billing"), Metadata: models.JSONMap{"tier": float64(1)}},
+		{ID: "payments", Slug: "payments", Kind: "service", ParentID: stringPtr("billing"), Metadata: models.JSONMap{"tier": float64(2)}},
+		{ID: "campaigns", Slug: "campaigns", Kind: "service", ParentID: stringPtr("marketing")},
+		{ID: "legacy-db", Slug: "legacy-db", Kind: "database", Metadata: models.JSONMap{"engine": "oracle", "deprecated": true}},
+	}
+	edgeTypes := []models.EdgeType{
+		{Name: "calls", Direction: models.DirectionForward},
+		{Name: "publishes", Direction: models.DirectionReverse},
+		{Name: "syncs", Direction: models.DirectionBidirectional},
+	}
+	return NewGraph(vertices, edgeTypes)
+}
+
+func TestMatches(t *testing.T) {
+	g := testGraph()
+
+	tests := []struct {
+		name     string
+		selector models.RuleSelector
+		vertexID string
+		expected bool
+	}{
+		{name: "empty selector", selector: models.RuleSelector{}, vertexID: "campaigns", expected: true},
+		{name: "kind", selector: models.RuleSelector{Kind: "database"}, vertexID: "legacy-db", expected: true},
+		{name: "other kind", selector: models.RuleSelector{Kind: "database"}, vertexID: "invoices", expected: false},
+		{name: "domain by slug", selector: models.RuleSelector{Domain: "billing"}, vertexID: "payments", expected: true},
+		{name: "outside domain", selector: models.RuleSelector{Domain: "billing"}, vertexID: "campaigns", expected: false},
+		{name: "unknown domain", selector: models.RuleSelector{Domain: "nope"}, vertexID: "campaigns", expected: false},
+		{name: "metadata number from code", selector: models.RuleSelector{Metadata: models.JSONMap{"tier": 1}}, vertexID: "invoices", expected: true},
+		{name: "metadata mismatch", selector: models.RuleSelector{Metadata: models.JSONMap{"tier": 1}}, vertexID: "payments", expected: false},
+		{name: "metadata missing", selector: models.RuleSelector{Metadata: models.JSONMap{"deprecated": true}}, vertexID: "payments", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.Matches(tt.selector, tt.vertexID); got != tt.expected {
+				t.Errorf("Matches() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	g := testGraph()
+
+	if deps := g.Dependencies(models.Edge{From: "a", To: "b", Type: "calls"}); len(deps) != 1 || deps[0].Dependent != "a" {
+		t.Errorf("forward edge: unexpected dependencies %v", deps)
+	}
+	if deps := g.Dependencies(models.Edge{From: "a", To: "b", Type: "publishes"}); len(deps) != 1 || deps[0].Dependent != "b" {
+		t.Errorf("reverse edge: unexpected dependencies %v", deps)
+	}
+	if deps := g.Dependencies(models.Edge{From: "a", To: "b", Type: "syncs"}); len(deps) != 2 {
+		t.Errorf("bidirectional edge: unexpected dependencies %v", deps)
+	}
+	if deps := g.Dependencies(models.Edge{From: "a", To: "b"}); len(deps) != 1 || deps[0].Dependent != "a" {
+		t.Errorf("untyped edge: unexpected dependencies %v", deps)
+	}
+}
+
+func TestCheckEdge(t *testing.T) {
+	g := testGraph()
+
+	noMarketing := models.ArchitectureRule{
+		ID: "r1", Name: "billing does not call marketing", Effect: models.RuleEffectDeny, Severity: models.RuleSeverityError,
+		Source: models.RuleSelector{Domain: "billing"}, Target: models.RuleSelector{Domain: "marketing"}, EdgeTypes: models.StringList{"calls"},
+	}
+	noDeprecated := models.ArchitectureRule{
+		ID: "r2", Name: "no deprecated dependencies", Effect: models.RuleEffectDeny, Severity: models.RuleSeverityWarning,
+		Target: models.RuleSelector{Metadata: models.JSONMap{"deprecated": true}},
+	}
+	tierOne := models.ArchitectureRule{
+		ID: "r3", Name: "tier-1 depends on tier-1", Effect: models.RuleEffectAllowOnly, Severity: models.RuleSeverityError,
+		Source: models.RuleSelector{Metadata: models.JSONMap{"tier": 1}}, Target: models.RuleSelector{Metadata: models.JSONMap{"tier": 1}},
+	}
+	all := []models.ArchitectureRule{noMarketing, noDeprecated, tierOne}
+
+	tests := []struct {
+		name     string
+		edge     models.Edge
+		expected []string // ID naruszonych reguł
+	}{
+		{name: "allowed", edge: models.Edge{ID: "e", From: "payments", To: "invoices", Type: "calls"}},
+		{name: "crossing domains", edge: models.Edge{ID: "e", From: "payments", To: "campaigns", Type: "calls"}, expected: []string{"r1"}},
+		{name: "rule limited to edge types", edge: models.Edge{ID: "e", From: "payments", To: "campaigns", Type: "publishes"}},
+		{name: "reverse direction", edge: models.Edge{ID: "e", From: "campaigns", To: "payments", Type: "publishes"}},
+		{name: "deprecated dependency", edge: models.Edge{ID: "e", From: "campaigns", To: "legacy-db", Type: "calls"}, expected: []string{"r2"}},
+		{name: "tier-1 to tier-2", edge: models.Edge{ID: "e", From: "invoices", To: "payments", Type: "calls"}, expected: []string{"r3"}},
+		{name: "tier-1 to deprecated", edge: models.Edge{ID: "e", From: "invoices", To: "legacy-db", Type: "calls"}, expected: []string{"r2", "r3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := CheckEdge(all, g, tt.edge)
+			if len(violations) != len(tt.expected) {
+				t.Fatalf("CheckEdge() = %v, want rules %v", violations, tt.expected)
+			}
+			for i, v := range violations {
+				if v.RuleID != tt.expected[i] {
+					t.Errorf("violation %d: rule %s, want %s", i, v.RuleID, tt.expected[i])
+				}
+			}
+		})
+	}
+
+	// Wyłączona reguła nie jest sprawdzana
+	tierOne.Disabled = true
+	if violations := CheckEdge([]models.ArchitectureRule{tierOne}, g, models.Edge{From: "invoices", To: "payments"}); len(violations) != 0 {
+		t.Errorf("Disabled rule should be skipped, got %v", violations)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	g := testGraph()
+
+	all := []models.ArchitectureRule{
+		{ID: "w", Name: "warn", Effect: models.RuleEffectDeny, Severity: models.RuleSeverityWarning, Target: models.RuleSelector{Kind: "database"}},
+		{ID: "e", Name: "error", Effect: models.RuleEffectDeny, Severity: models.RuleSeverityError, Target: models.RuleSelector{Domain: "marketing"}},
+	}
+	edges := []models.Edge{
+		{ID: "e1", From: "invoices", To: "legacy-db"},
+		{ID: "e2", From: "invoices", To: "campaigns"},
+	}
+
+	report := Evaluate(all, g, edges)
+	if report.Valid || report.Errors != 1 || report.Warnings != 1 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	if report.Violations[0].Severity != models.RuleSeverityError {
+		t.Errorf("Errors should be listed first, got %+v", report.Violations)
+	}
+}
+
+func TestValidateRule(t *testing.T) {
+	g := testGraph()
+
+	rule := &models.ArchitectureRule{Name: "r", Effect: models.RuleEffectDeny}
+	if err := ValidateRule(rule, g); err != nil {
+		t.Fatalf("ValidateRule() error = %v", err)
+	}
+	if rule.Severity != models.RuleSeverityError {
+		t.Errorf("Expected default severity error, got %s", rule.Severity)
+	}
+
+	invalid := []models.ArchitectureRule{
+		{Effect: models.RuleEffectDeny},
+		{Name: "r", Effect: "forbid"},
+		{Name: "r", Effect: models.RuleEffectDeny, Severity: "fatal"},
+		{Name: "r", Effect: models.RuleEffectDeny, Source: models.RuleSelector{Domain: "unknown"}},
+	}
+	for i := range invalid {
+		if err := ValidateRule(&invalid[i], g); err == nil {
+			t.Errorf("Expected error for rule %+v", invalid[i])
+		}
+	}
+}
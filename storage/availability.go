@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"microservice_overview/models"
+)
+
+// ErrInvalidSLO zwracany gdy docelowa dostępność wierzchołka jest spoza zakresu
+var ErrInvalidSLO = errors.New("invalid availability SLO")
+
+// validateVertexSLO sprawdza docelową dostępność: procent z zakresu (0, 100]
+// lub 0, gdy SLO nie jest określone
+func validateVertexSLO(vertex *models.Vertex) error {
+	slo := vertex.AvailabilitySLO
+	if slo < 0 || slo > 100 || math.IsNaN(slo) {
+		return fmt.Errorf("%w: availability_slo must be a percentage between 0 and 100, got %v", ErrInvalidSLO, slo)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"microservice_overview/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrEdgeTypeInUse zwracany przy próbie usunięcia typu, którego używają relacje
+var ErrEdgeTypeInUse = errors.New("edge type is used by existing edges")
+
+// ErrUnknownEdgeType zwracany gdy relacja wskazuje typ spoza katalogu
+var ErrUnknownEdgeType = errors.New("unknown edge type")
+
+// ErrInvalidEdgeType zwracany gdy definicja typu relacji łamie reguły katalogu
+var ErrInvalidEdgeType = errors.New("invalid edge type")
+
+// edgeTypeNamePattern dopuszczalne nazwy typów relacji (po normalizacji)
+var edgeTypeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// defaultEdgeTypes katalog typów relacji tworzony przy pierwszym uruchomieniu
+var defaultEdgeTypes = []models.EdgeType{
+	{Name: "calls", Description: "Synchroniczne wywołanie (HTTP, gRPC)", Direction: models.DirectionForward, Synchronous: true, Color: "#2B7CE9", Style: models.StyleSolid,
+		AllowedSourceKinds: models.StringList{models.KindService, "external_api"}},
+	{Name: "calls_async", Description: "Asynchroniczne wywołanie (kolejka, zdarzenie)", Direction: models.DirectionForward, Synchronous: false, Color: "#7B61FF", Style: models.StyleDashed,
+		AllowedSourceKinds: models.StringList{models.KindService, "external_api"}},
+	{Name: "requires", Description: "Twarda zależność bez bezpośredniego wywołania", Direction: models.DirectionForward, Synchronous: true, Color: "#E67E22", Style: models.StyleSolid},
+	{Name: "publishes", Description: "Publikowanie zdarzeń konsumowanych przez cel", Direction: models.DirectionReverse, Synchronous: false, Color: "#27AE60", Style: models.StyleDotted},
+}
+
+// normalizeEdgeTypeName sprowadza nazwę typu do postaci kanonicznej: "Calls Async" -> "calls_async"
+func normalizeEdgeTypeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
+
+// seedEdgeTypes dodaje brakujące typy z domyślnego katalogu
+func (s *DBStorage) seedEdgeTypes() error {
+	for _, edgeType := range defaultEdgeTypes {
+		edgeType := edgeType
+		if err := s.db.Where("name = ?", edgeType.Name).FirstOrCreate(&edgeType).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateEdgeTypeDefinition sprawdza i normalizuje definicję typu relacji
+func validateEdgeTypeDefinition(edgeType *models.EdgeType) error {
+	edgeType.Name = normalizeEdgeTypeName(edgeType.Name)
+	if !edgeTypeNamePattern.MatchString(edgeType.Name) {
+		return fmt.Errorf("%w name %q - use lowercase letters, digits and underscores", ErrInvalidEdgeType, edgeType.Name)
+	}
+
+	if edgeType.Direction == "" {
+		edgeType.Direction = models.DirectionForward
+	}
+	switch edgeType.Direction {
+	case models.DirectionForward, models.DirectionReverse, models.DirectionBidirectional:
+	default:
+		return fmt.Errorf("%w: invalid direction %q - must be one of: forward, reverse, bidirectional", ErrInvalidEdgeType, edgeType.Direction)
+	}
+
+	if edgeType.Style == "" {
+		edgeType.Style = models.StyleSolid
+	}
+	switch edgeType.Style {
+	case models.StyleSolid, models.StyleDashed, models.StyleDotted:
+	default:
+		return fmt.Errorf("%w: invalid style %q - must be one of: solid, dashed, dotted", ErrInvalidEdgeType, edgeType.Style)
+	}
+
+	return nil
+}
+
+// validateEdgeTypeWithKinds sprawdza definicję typu oraz czy dozwolone rodzaje istnieją w rejestrze
+func (s *DBStorage) validateEdgeTypeWithKinds(edgeType *models.EdgeType) error {
+	if err := validateEdgeTypeDefinition(edgeType); err != nil {
+		return err
+	}
+	if err := s.validateKindNames(edgeType.AllowedSourceKinds); err != nil {
+		return err
+	}
+	return s.validateKindNames(edgeType.AllowedTargetKinds)
+}
+
+// resolveEdgeType zwraca typ z katalogu dla nazwy podanej w relacji
+func (s *DBStorage) resolveEdgeType(name string) (*models.EdgeType, error) {
+	var edgeType models.EdgeType
+	err := s.db.First(&edgeType, "name = ?", normalizeEdgeTypeName(name)).Error
+	if err == nil {
+		return &edgeType, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	var known []string
+	if err := s.db.Model(&models.EdgeType{}).Order("name").Pluck("name", &known).Error; err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w %q - known types: %s", ErrUnknownEdgeType, name, strings.Join(known, ", "))
+}
+
+// validateEdgeType sprawdza typ relacji w katalogu i zapisuje go w postaci kanonicznej.
+// Pusty typ jest dozwolony (relacja bez typu) - zwracany jest wtedy nil.
+func (s *DBStorage) validateEdgeType(edge *models.Edge) (*models.EdgeType, error) {
+	if strings.TrimSpace(edge.Type) == "" {
+		edge.Type = ""
+		return nil, nil
+	}
+	edgeType, err := s.resolveEdgeType(edge.Type)
+	if err != nil {
+		return nil, err
+	}
+	edge.Type = edgeType.Name
+	return edgeType, nil
+}
+
+// Typy relacji
+
+func (s *DBStorage) GetAllEdgeTypes() ([]models.EdgeType, error) {
+	var edgeTypes []models.EdgeType
+	err := s.db.Order("name").Find(&edgeTypes).Error
+	return edgeTypes, err
+}
+
+func (s *DBStorage) GetEdgeType(name string) (*models.EdgeType, error) {
+	var edgeType models.EdgeType
+	if err := s.db.First(&edgeType, "name = ?", normalizeEdgeTypeName(name)).Error; err != nil {
+		return nil, notFound(err, "edge type "+name)
+	}
+	return &edgeType, nil
+}
+
+func (s *DBStorage) CreateEdgeType(edgeType *models.EdgeType) error {
+	if err := s.validateEdgeTypeWithKinds(edgeType); err != nil {
+		return err
+	}
+	if err := s.checkIDFree(&models.EdgeType{}, "name", edgeType.Name, "edge type "+edgeType.Name); err != nil {
+		return err
+	}
+	return s.db.Create(edgeType).Error
+}
+
+func (s *DBStorage) UpdateEdgeType(edgeType *models.EdgeType) error {
+	if err := s.validateEdgeTypeWithKinds(edgeType); err != nil {
+		return err
+	}
+	var current models.EdgeType
+	if err := s.db.First(&current, "name = ?", edgeType.Name).Error; err != nil {
+		return notFound(err, "edge type "+edgeType.Name)
+	}
+	edgeType.CreatedAt = current.CreatedAt
+	return s.db.Save(edgeType).Error
+}
+
+func (s *DBStorage) DeleteEdgeType(name string) error {
+	name = normalizeEdgeTypeName(name)
+
+	var count int64
+	if err := s.db.Model(&models.Edge{}).Where("type = ?", name).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d edge(s) of type %s", ErrEdgeTypeInUse, count, name)
+	}
+	return deleted(s.db.Delete(&models.EdgeType{}, "name = ?", name), "edge type "+name)
+}
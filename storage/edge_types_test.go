@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"microservice_overview/models"
+)
+
+func TestNormalizeEdgeTypeName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "calls", expected: "calls"},
+		{input: "Calls", expected: "calls"},
+		{input: " Calls-Async ", expected: "calls_async"},
+		{input: "reads from", expected: "reads_from"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeEdgeTypeName(tt.input); got != tt.expected {
+			t.Errorf("normalizeEdgeTypeName(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestValidateEdgeType(t *testing.T) {
+	s := newTestStorage(t)
+
+	edge := &models.Edge{Type: "Calls"}
+	if _, err := s.validateEdgeType(edge); err != nil {
+		t.Fatalf("validateEdgeType() error = %v", err)
+	}
+	if edge.Type != "calls" {
+		t.Errorf("Expected normalized type calls, got %s", edge.Type)
+	}
+
+	if _, err := s.validateEdgeType(&models.Edge{Type: "call"}); err == nil {
+		t.Error("Expected error for unknown edge type")
+	}
+}
+
+func TestDeleteEdgeType_InUse(t *testing.T) {
+	s := newTestStorage(t)
+
+	s.CreateVertex(&models.Vertex{ID: "v1", Name: "Vertex 1"})
+	s.CreateVertex(&models.Vertex{ID: "v2", Name: "Vertex 2"})
+	if err := s.CreateEdge(&models.Edge{ID: "e1", From: "v1", To: "v2", Type: "requires"}); err != nil {
+		t.Fatalf("CreateEdge() error = %v", err)
+	}
+
+	if err := s.DeleteEdgeType("requires"); !errors.Is(err, ErrEdgeTypeInUse) {
+		t.Errorf("Expected ErrEdgeTypeInUse, got %v", err)
+	}
+}
+
+func TestValidateEdgeType_ReportsDatabaseErrors(t *testing.T) {
+	s := newTestStorage(t)
+	if err := s.db.Migrator().DropTable(&models.EdgeType{}); err != nil {
+		t.Fatalf("failed to drop edge types: %v", err)
+	}
+
+	_, err := s.validateEdgeType(&models.Edge{Type: "calls"})
+	if err == nil || strings.Contains(err.Error(), "unknown edge type") {
+		t.Errorf("Expected the database error, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"time"
+
+	"microservice_overview/models"
+)
+
+// Tabela zdarzeń
+
+func (s *DBStorage) AppendEvents(records []models.EventRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return s.db.Create(&records).Error
+}
+
+func (s *DBStorage) GetEventsAfter(id int64, limit int) ([]models.EventRecord, error) {
+	var records []models.EventRecord
+	err := s.db.Where("id > ?", id).Order("id").Limit(limit).Find(&records).Error
+	return records, err
+}
+
+func (s *DBStorage) GetLastEventID() (int64, error) {
+	var id int64
+	err := s.db.Model(&models.EventRecord{}).Select("COALESCE(MAX(id), 0)").Scan(&id).Error
+	return id, err
+}
+
+func (s *DBStorage) DeleteEventsBefore(t time.Time) (int64, error) {
+	result := s.db.Delete(&models.EventRecord{}, "created_at < ?", t)
+	return result.RowsAffected, result.Error
+}
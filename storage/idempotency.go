@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"microservice_overview/models"
+
+	"gorm.io/gorm/clause"
+)
+
+// Klucze idempotencji
+
+func (s *DBStorage) CreateIdempotencyRecord(record *models.IdempotencyRecord) error {
+	// Rezerwacja klucza musi być atomowa - dwa równoległe żądania z tym samym
+	// kluczem nie mogą obu przejść, więc o zajętości decyduje sam INSERT
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("idempotency key %s %w", record.Key, ErrDuplicateID)
+	}
+	return nil
+}
+
+func (s *DBStorage) GetIdempotencyRecord(key string) (*models.IdempotencyRecord, error) {
+	var record models.IdempotencyRecord
+	if err := s.db.First(&record, "idempotency_key = ?", key).Error; err != nil {
+		return nil, notFound(err, "idempotency key "+key)
+	}
+	return &record, nil
+}
+
+func (s *DBStorage) CompleteIdempotencyRecord(record *models.IdempotencyRecord) error {
+	// Select zapisuje też puste wartości (np. odpowiedź bez treści); token
+	// chroni rezerwację przejętą po utracie dzierżawy
+	result := s.db.Model(record).Where("token = ?", record.Token).Select("Status", "ContentType", "ETag", "Body").Updates(record)
+	return deleted(result, "idempotency key "+record.Key)
+}
+
+func (s *DBStorage) DeleteIdempotencyRecord(key, token string) error {
+	return deleted(s.db.Delete(&models.IdempotencyRecord{}, "idempotency_key = ? AND token = ?", key, token), "idempotency key "+key)
+}
+
+func (s *DBStorage) DeleteIdempotencyRecordsBefore(t time.Time) (int64, error) {
+	result := s.db.Delete(&models.IdempotencyRecord{}, "created_at < ?", t)
+	return result.RowsAffected, result.Error
+}
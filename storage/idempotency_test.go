@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"microservice_overview/models"
+)
+
+func TestIdempotencyRecords(t *testing.T) {
+	s := newTestStorage(t)
+
+	record := &models.IdempotencyRecord{Key: "deploy-42", Fingerprint: "POST /api/vertices abc", Token: "t1"}
+	if err := s.CreateIdempotencyRecord(record); err != nil {
+		t.Fatalf("CreateIdempotencyRecord() error = %v", err)
+	}
+	again := &models.IdempotencyRecord{Key: "deploy-42", Fingerprint: "POST /api/vertices def"}
+	if err := s.CreateIdempotencyRecord(again); !errors.Is(err, ErrDuplicateID) {
+		t.Fatalf("Expected ErrDuplicateID for a reserved key, got %v", err)
+	}
+
+	stored, err := s.GetIdempotencyRecord("deploy-42")
+	if err != nil || stored.Completed() || stored.Fingerprint != record.Fingerprint {
+		t.Fatalf("Expected the pending reservation, got %+v %v", stored, err)
+	}
+
+	// Odpowiedź zapisuje tylko właściciel rezerwacji
+	foreign := *record
+	foreign.Token, foreign.Status = "t2", 500
+	if err := s.CompleteIdempotencyRecord(&foreign); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for another reservation's token, got %v", err)
+	}
+
+	record.Status, record.ContentType, record.ETag, record.Body = 201, "application/json", `"1"`, []byte(`{"id":"a"}`)
+	if err := s.CompleteIdempotencyRecord(record); err != nil {
+		t.Fatalf("CompleteIdempotencyRecord() error = %v", err)
+	}
+	stored, _ = s.GetIdempotencyRecord("deploy-42")
+	if !stored.Completed() || stored.Status != 201 || stored.ETag != `"1"` || string(stored.Body) != `{"id":"a"}` {
+		t.Errorf("Expected the stored response, got %+v", stored)
+	}
+
+	if err := s.CompleteIdempotencyRecord(&models.IdempotencyRecord{Key: "missing", Status: 200}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown key, got %v", err)
+	}
+
+	s.CreateIdempotencyRecord(&models.IdempotencyRecord{Key: "fresh", Fingerprint: "DELETE /api/edges/e1 0", Token: "t3"})
+	purged, err := s.DeleteIdempotencyRecordsBefore(record.CreatedAt.Add(time.Nanosecond))
+	if err != nil || purged != 1 {
+		t.Fatalf("Expected one purged record, got %d %v", purged, err)
+	}
+	if _, err := s.GetIdempotencyRecord("deploy-42"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the old record to be purged, got %v", err)
+	}
+
+	if err := s.DeleteIdempotencyRecord("fresh", "t1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for another reservation's token, got %v", err)
+	}
+	if err := s.DeleteIdempotencyRecord("fresh", "t3"); err != nil {
+		t.Errorf("DeleteIdempotencyRecord() error = %v", err)
+	}
+	if err := s.DeleteIdempotencyRecord("fresh", "t3"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a released key, got %v", err)
+	}
+}
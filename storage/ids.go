@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxSlugLength maksymalna długość sluga (bez sufiksu zapewniającego unikalność)
+const maxSlugLength = 64
+
+// newID generuje identyfikator UUIDv7 - rosnący w czasie, więc dobrze indeksowany
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+
+	// 48 bitów znacznika czasu w milisekundach
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(time.Now().UnixMilli()))
+	copy(b[0:6], ts[2:8])
+
+	b[6] = (b[6] & 0x0f) | 0x70 // wersja 7
+	b[8] = (b[8] & 0x3f) | 0x80 // wariant RFC 4122
+
+	h := hex.EncodeToString(b[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// transliteration zamienia polskie znaki diakrytyczne na ich odpowiedniki ASCII
+var transliteration = map[rune]string{
+	'ą': "a", 'ć': "c", 'ę': "e", 'ł': "l", 'ń': "n", 'ó': "o", 'ś': "s", 'ź': "z", 'ż': "z",
+}
+
+// slugify buduje bezpieczny w URL slug z nazwy: małe litery, cyfry i myślniki
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+			dash = false
+		case transliteration[r] != "":
+			b.WriteString(transliteration[r])
+			dash = false
+		default:
+			if b.Len() > 0 && !dash {
+				b.WriteByte('-')
+				dash = true
+			}
+		}
+	}
+
+	slug := strings.Trim(b.String(), "-")
+	if len(slug) > maxSlugLength {
+		slug = strings.Trim(slug[:maxSlugLength], "-")
+	}
+	if slug == "" {
+		slug = "vertex"
+	}
+	return slug
+}
+
+// withSuffix dokleja numer do sluga, gdy bazowy slug jest zajęty
+func withSuffix(slug string, n int) string {
+	return slug + "-" + strconv.Itoa(n)
+}
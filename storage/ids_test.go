@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestNewID(t *testing.T) {
+	uuidV7 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	seen := make(map[string]bool)
+	previous := ""
+	for i := 0; i < 100; i++ {
+		id := newID()
+		if !uuidV7.MatchString(id) {
+			t.Fatalf("newID() = %s, want UUIDv7", id)
+		}
+		if seen[id] {
+			t.Fatalf("newID() returned duplicate %s", id)
+		}
+		seen[id] = true
+
+		// Prefiks czasowy nie maleje
+		if id[:13] < previous {
+			t.Errorf("newID() = %s is older than previous prefix %s", id, previous)
+		}
+		previous = id[:13]
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "simple", input: "User Service", expected: "user-service"},
+		{name: "polish characters", input: "Obsługa Zamówień", expected: "obsluga-zamowien"},
+		{name: "punctuation collapsed", input: "  Billing -- API (v2)!  ", expected: "billing-api-v2"},
+		{name: "already a slug", input: "payment-service", expected: "payment-service"},
+		{name: "no usable characters", input: "!!!", expected: "vertex"},
+		{name: "non-latin letters dropped", input: "服务 gateway", expected: "gateway"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := slugify(tt.input); result != tt.expected {
+				t.Errorf("slugify(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSlugify_MaxLength(t *testing.T) {
+	long := ""
+	for i := 0; i < 20; i++ {
+		long += "abcde "
+	}
+	if slug := slugify(long); len(slug) > maxSlugLength {
+		t.Errorf("slugify() length = %d, want at most %d", len(slug), maxSlugLength)
+	}
+}
@@ -0,0 +1,294 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"microservice_overview/models"
+
+	"gorm.io/gorm"
+)
+
+// errDryRun wycofuje transakcję naprawy w trybie podglądu
+var errDryRun = errors.New("dry run")
+
+// integrityData stan bazy potrzebny do sprawdzenia spójności grafu
+type integrityData struct {
+	vertices map[string]models.Vertex // aktywne wierzchołki
+	deleted  map[string]bool          // wierzchołki usunięte (soft delete)
+	edges    []models.Edge            // aktywne relacje, od najstarszej
+}
+
+// loadIntegrityData wczytuje wierzchołki (również usunięte) i relacje
+func (s *DBStorage) loadIntegrityData() (*integrityData, error) {
+	var vertices []models.Vertex
+	if err := s.db.Find(&vertices).Error; err != nil {
+		return nil, err
+	}
+
+	var deleted []models.Vertex
+	if err := s.db.Unscoped().Where("deleted_at IS NOT NULL").Find(&deleted).Error; err != nil {
+		return nil, err
+	}
+
+	var edges []models.Edge
+	if err := s.db.Order("created_at, id").Find(&edges).Error; err != nil {
+		return nil, err
+	}
+
+	data := &integrityData{
+		vertices: make(map[string]models.Vertex, len(vertices)),
+		deleted:  make(map[string]bool, len(deleted)),
+		edges:    edges,
+	}
+	for _, v := range vertices {
+		data.vertices[v.ID] = v
+	}
+	for _, v := range deleted {
+		data.deleted[v.ID] = true
+	}
+	return data, nil
+}
+
+// checkIntegrity zwraca wszystkie naruszenia reguł spójności - najpierw
+// problemy z hierarchią, potem z relacjami
+func checkIntegrity(data *integrityData) []models.IntegrityIssue {
+	issues := checkHierarchy(data)
+	return append(issues, checkEdges(data)...)
+}
+
+// checkHierarchy wykrywa osierocone parent_id oraz cykle w hierarchii
+func checkHierarchy(data *integrityData) []models.IntegrityIssue {
+	ids := make([]string, 0, len(data.vertices))
+	for id := range data.vertices {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	issues := []models.IntegrityIssue{}
+
+	for _, id := range ids {
+		v := data.vertices[id]
+		if v.ParentID == nil || *v.ParentID == "" {
+			continue
+		}
+		if _, ok := data.vertices[*v.ParentID]; ok {
+			continue
+		}
+		reason := "does not exist"
+		if data.deleted[*v.ParentID] {
+			reason = "is deleted"
+		}
+		issues = append(issues, models.IntegrityIssue{
+			Type:     models.IssueOrphanedParent,
+			VertexID: id,
+			Related:  []string{*v.ParentID},
+			Message:  fmt.Sprintf("parent vertex %s of vertex %s %s", *v.ParentID, id, reason),
+		})
+	}
+
+	// Idziemy w górę hierarchii z każdego wierzchołka; powrót do wierzchołka
+	// z bieżącej ścieżki oznacza cykl
+	done := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		onPath := make(map[string]int)
+		var path []string
+		current := id
+		for !done[current] {
+			if idx, ok := onPath[current]; ok {
+				cycle := append([]string(nil), path[idx:]...)
+				sort.Strings(cycle)
+				issues = append(issues, models.IntegrityIssue{
+					Type:     models.IssueHierarchyCycle,
+					VertexID: cycle[0],
+					Related:  cycle,
+					Message:  fmt.Sprintf("vertices %v form a cycle in the hierarchy", cycle),
+				})
+				break
+			}
+			onPath[current] = len(path)
+			path = append(path, current)
+
+			v := data.vertices[current]
+			if v.ParentID == nil {
+				break
+			}
+			if _, ok := data.vertices[*v.ParentID]; !ok {
+				break
+			}
+			current = *v.ParentID
+		}
+		for _, p := range path {
+			done[p] = true
+		}
+	}
+
+	return issues
+}
+
+// checkEdges wykrywa relacje do brakujących wierzchołków, relacje między
+// nie-liśćmi oraz zduplikowane relacje
+func checkEdges(data *integrityData) []models.IntegrityIssue {
+	hasChildren := make(map[string]bool)
+	for _, v := range data.vertices {
+		if v.ParentID == nil {
+			continue
+		}
+		if _, ok := data.vertices[*v.ParentID]; ok {
+			hasChildren[*v.ParentID] = true
+		}
+	}
+
+	issues := []models.IntegrityIssue{}
+	seen := make(map[string]string)
+
+	for _, edge := range data.edges {
+		broken := false
+		for _, endpoint := range []string{edge.From, edge.To} {
+			if _, ok := data.vertices[endpoint]; ok {
+				continue
+			}
+			broken = true
+			if data.deleted[endpoint] {
+				issues = append(issues, models.IntegrityIssue{
+					Type:    models.IssueEdgeToDeleted,
+					EdgeID:  edge.ID,
+					Related: []string{endpoint},
+					Message: fmt.Sprintf("edge %s points at deleted vertex %s", edge.ID, endpoint),
+				})
+			} else {
+				issues = append(issues, models.IntegrityIssue{
+					Type:    models.IssueDanglingEdge,
+					EdgeID:  edge.ID,
+					Related: []string{endpoint},
+					Message: fmt.Sprintf("edge %s points at missing vertex %s", edge.ID, endpoint),
+				})
+			}
+		}
+		if broken {
+			continue
+		}
+
+		for _, endpoint := range []string{edge.From, edge.To} {
+			if hasChildren[endpoint] {
+				issues = append(issues, models.IntegrityIssue{
+					Type:     models.IssueEdgeOnNonLeaf,
+					EdgeID:   edge.ID,
+					VertexID: endpoint,
+					Message:  fmt.Sprintf("edge %s is attached to vertex %s which has children", edge.ID, endpoint),
+				})
+			}
+		}
+
+		key := edge.From + "\x00" + edge.To + "\x00" + edge.Type
+		if first, ok := seen[key]; ok {
+			issues = append(issues, models.IntegrityIssue{
+				Type:    models.IssueDuplicateEdge,
+				EdgeID:  edge.ID,
+				Related: []string{first},
+				Message: fmt.Sprintf("edge %s duplicates edge %s", edge.ID, first),
+			})
+			continue
+		}
+		seen[key] = edge.ID
+	}
+
+	return issues
+}
+
+// ValidateGraph sprawdza zapisane dane pod kątem naruszonych reguł spójności
+func (s *DBStorage) ValidateGraph() (*models.IntegrityReport, error) {
+	data, err := s.loadIntegrityData()
+	if err != nil {
+		return nil, err
+	}
+	issues := checkIntegrity(data)
+	return &models.IntegrityReport{Valid: len(issues) == 0, Issues: issues}, nil
+}
+
+// RepairGraph naprawia naruszenia spójności zgodnie z wybraną strategią.
+// Najpierw naprawiana jest hierarchia (zmienia to zbiór liści), potem
+// usuwane są błędne relacje. W trybie dryRun zmiany są wycofywane.
+func (s *DBStorage) RepairGraph(strategy string, dryRun bool) (*models.RepairReport, error) {
+	if strategy == "" {
+		strategy = models.RepairStrategyDetach
+	}
+	if strategy != models.RepairStrategyDetach && strategy != models.RepairStrategyDelete {
+		return nil, fmt.Errorf("unknown repair strategy: %s", strategy)
+	}
+
+	report := &models.RepairReport{Strategy: strategy, DryRun: dryRun, Actions: []models.RepairAction{}}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txs := &DBStorage{db: tx}
+
+		// Hierarchia: usunięcie osieroconego wierzchołka osieroca jego dzieci,
+		// więc powtarzamy aż do wyczerpania problemów
+		for {
+			data, err := txs.loadIntegrityData()
+			if err != nil {
+				return err
+			}
+			issues := checkHierarchy(data)
+			if len(issues) == 0 {
+				break
+			}
+			for _, issue := range issues {
+				if issue.Type == models.IssueHierarchyCycle || strategy == models.RepairStrategyDetach {
+					if err := tx.Model(&models.Vertex{}).Where("id = ?", issue.VertexID).Updates(map[string]interface{}{
+						"parent_id": nil,
+						"version":   gorm.Expr("version + 1"),
+					}).Error; err != nil {
+						return err
+					}
+					report.Actions = append(report.Actions, models.RepairAction{
+						Action: models.RepairActionDetachVertex, VertexID: issue.VertexID, Issue: issue,
+					})
+					continue
+				}
+				if err := tx.Delete(&models.Vertex{}, "id = ?", issue.VertexID).Error; err != nil {
+					return err
+				}
+				report.Actions = append(report.Actions, models.RepairAction{
+					Action: models.RepairActionDeleteVertex, VertexID: issue.VertexID, Issue: issue,
+				})
+			}
+		}
+
+		// Relacje: każda błędna relacja jest usuwana (z duplikatów zostaje najstarsza)
+		data, err := txs.loadIntegrityData()
+		if err != nil {
+			return err
+		}
+		removed := make(map[string]bool)
+		for _, issue := range checkEdges(data) {
+			if removed[issue.EdgeID] {
+				continue
+			}
+			if err := tx.Delete(&models.Edge{}, "id = ?", issue.EdgeID).Error; err != nil {
+				return err
+			}
+			removed[issue.EdgeID] = true
+			report.Actions = append(report.Actions, models.RepairAction{
+				Action: models.RepairActionDeleteEdge, EdgeID: issue.EdgeID, Issue: issue,
+			})
+		}
+
+		data, err = txs.loadIntegrityData()
+		if err != nil {
+			return err
+		}
+		report.Remaining = checkIntegrity(data)
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	return report, nil
+}
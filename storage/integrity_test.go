@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"microservice_overview/models"
+)
+
+// newTestStorage tworzy storage z bazą w pamięci
+func newTestStorage(t *testing.T) *DBStorage {
+	t.Helper()
+
+	os.Setenv("DEV_MODE", "true")
+	defer os.Unsetenv("DEV_MODE")
+
+	s, err := NewStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	return s.(*DBStorage)
+}
+
+// seedDriftedGraph zapisuje dane z pominięciem walidacji, tak jak mogły trafić do bazy wcześniej
+func seedDriftedGraph(t *testing.T, s *DBStorage) {
+	t.Helper()
+
+	vertices := []models.Vertex{
+		{ID: "a", Name: "A"},
+		{ID: "b", Name: "B"},
+		{ID: "domain", Name: "Domain"},
+		{ID: "child", Name: "Child", ParentID: stringPtr("domain")},
+		{ID: "orphan", Name: "Orphan", ParentID: stringPtr("gone")},
+		{ID: "orphan-child", Name: "Orphan Child", ParentID: stringPtr("orphan")},
+		{ID: "cycle-1", Name: "Cycle 1", ParentID: stringPtr("cycle-2")},
+		{ID: "cycle-2", Name: "Cycle 2", ParentID: stringPtr("cycle-1")},
+		{ID: "deleted", Name: "Deleted"},
+	}
+	edges := []models.Edge{
+		{ID: "e-first", From: "a", To: "b", Type: "calls"},
+		{ID: "e-second", From: "a", To: "b", Type: "calls"},
+		{ID: "to-missing", From: "a", To: "missing"},
+		{ID: "to-deleted", From: "a", To: "deleted"},
+		{ID: "non-leaf", From: "b", To: "domain"},
+	}
+	for i := range vertices {
+		vertices[i].Slug = vertices[i].ID
+		if err := s.db.Create(&vertices[i]).Error; err != nil {
+			t.Fatalf("failed to seed vertex: %v", err)
+		}
+	}
+	for i := range edges {
+		if err := s.db.Create(&edges[i]).Error; err != nil {
+			t.Fatalf("failed to seed edge: %v", err)
+		}
+	}
+	if err := s.db.Delete(&models.Vertex{}, "id = ?", "deleted").Error; err != nil {
+		t.Fatalf("failed to delete vertex: %v", err)
+	}
+}
+
+func issueTypes(issues []models.IntegrityIssue) map[string]int {
+	types := make(map[string]int)
+	for _, issue := range issues {
+		types[issue.Type]++
+	}
+	return types
+}
+
+func TestValidateGraph(t *testing.T) {
+	s := newTestStorage(t)
+	seedDriftedGraph(t, s)
+
+	report, err := s.ValidateGraph()
+	if err != nil {
+		t.Fatalf("ValidateGraph() error = %v", err)
+	}
+	if report.Valid {
+		t.Fatal("Expected graph to be invalid")
+	}
+
+	expected := map[string]int{
+		models.IssueOrphanedParent: 1,
+		models.IssueHierarchyCycle: 1,
+		models.IssueDanglingEdge:   1,
+		models.IssueEdgeToDeleted:  1,
+		models.IssueEdgeOnNonLeaf:  1,
+		models.IssueDuplicateEdge:  1,
+	}
+	got := issueTypes(report.Issues)
+	for issueType, count := range expected {
+		if got[issueType] != count {
+			t.Errorf("Expected %d issues of type %s, got %d", count, issueType, got[issueType])
+		}
+	}
+}
+
+func TestValidateGraph_Clean(t *testing.T) {
+	s := newTestStorage(t)
+
+	s.CreateVertex(&models.Vertex{ID: "a", Name: "A"})
+	s.CreateVertex(&models.Vertex{ID: "b", Name: "B"})
+	s.CreateEdge(&models.Edge{ID: "e1", From: "a", To: "b", Type: "calls"})
+
+	report, err := s.ValidateGraph()
+	if err != nil {
+		t.Fatalf("ValidateGraph() error = %v", err)
+	}
+	if !report.Valid || len(report.Issues) != 0 {
+		t.Errorf("Expected valid graph, got issues %v", report.Issues)
+	}
+}
+
+func TestRepairGraph(t *testing.T) {
+	tests := []struct {
+		name             string
+		strategy         string
+		orphanRemains    bool
+		orphanChildAlive bool
+	}{
+		{name: "detach", strategy: models.RepairStrategyDetach, orphanRemains: true, orphanChildAlive: true},
+		{name: "delete", strategy: models.RepairStrategyDelete, orphanRemains: false, orphanChildAlive: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStorage(t)
+			seedDriftedGraph(t, s)
+
+			report, err := s.RepairGraph(tt.strategy, false)
+			if err != nil {
+				t.Fatalf("RepairGraph() error = %v", err)
+			}
+			if len(report.Remaining) != 0 {
+				t.Errorf("Expected no remaining issues, got %v", report.Remaining)
+			}
+
+			after, _ := s.ValidateGraph()
+			if !after.Valid {
+				t.Errorf("Expected valid graph after repair, got %v", after.Issues)
+			}
+
+			orphan, err := s.GetVertexByID("orphan")
+			if (err == nil) != tt.orphanRemains {
+				t.Errorf("Expected orphan to remain: %v, got error %v", tt.orphanRemains, err)
+			}
+			if tt.orphanRemains && orphan.ParentID != nil {
+				t.Errorf("Expected orphan to be detached, got parent %s", *orphan.ParentID)
+			}
+			if _, err := s.GetVertexByID("orphan-child"); (err == nil) != tt.orphanChildAlive {
+				t.Errorf("Expected orphan child alive: %v, got error %v", tt.orphanChildAlive, err)
+			}
+
+			// Z duplikatów zostaje najstarsza relacja
+			if _, err := s.GetEdgeByID("e-first"); err != nil {
+				t.Errorf("Expected original edge to remain, got %v", err)
+			}
+			if _, err := s.GetEdgeByID("e-second"); err == nil {
+				t.Error("Expected duplicate edge to be deleted")
+			}
+		})
+	}
+}
+
+func TestRepairGraph_DryRun(t *testing.T) {
+	s := newTestStorage(t)
+	seedDriftedGraph(t, s)
+
+	report, err := s.RepairGraph(models.RepairStrategyDetach, true)
+	if err != nil {
+		t.Fatalf("RepairGraph() error = %v", err)
+	}
+	if len(report.Actions) == 0 {
+		t.Error("Expected planned repair actions")
+	}
+
+	after, _ := s.ValidateGraph()
+	if after.Valid {
+		t.Error("Dry run must not change stored data")
+	}
+}
+
+func TestRepairGraph_UnknownStrategy(t *testing.T) {
+	s := newTestStorage(t)
+
+	if _, err := s.RepairGraph("rebuild", false); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
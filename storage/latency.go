@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"microservice_overview/models"
+)
+
+// ErrInvalidLatency zwracany gdy czas obsługi lub opóźnienie jest ujemne
+var ErrInvalidLatency = errors.New("invalid latency")
+
+// validateDuration sprawdza, czy czas w milisekundach jest skończony i nieujemny
+func validateDuration(field string, value float64) error {
+	if value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("%w: %s must be a non-negative number of milliseconds, got %v", ErrInvalidLatency, field, value)
+	}
+	return nil
+}
+
+// validateVertexLatency sprawdza czas obsługi wierzchołka
+func validateVertexLatency(vertex *models.Vertex) error {
+	return validateDuration("processing_ms", vertex.ProcessingMs)
+}
+
+// validateEdgeLatency sprawdza opóźnienie relacji
+func validateEdgeLatency(edge *models.Edge) error {
+	return validateDuration("latency_ms", edge.LatencyMs)
+}
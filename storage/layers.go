@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"microservice_overview/models"
+	"microservice_overview/rules"
+)
+
+// ErrLayerInUse zwracany przy próbie usunięcia warstwy, do której należą wierzchołki
+var ErrLayerInUse = errors.New("layer is used by existing vertices")
+
+// ErrInvalidLayer zwracany gdy wierzchołek wskazuje warstwę spoza modelu
+var ErrInvalidLayer = errors.New("invalid layer")
+
+// ErrLayerViolation zwracany gdy zapis relacji łamie model warstwowy (przy włączonym ENFORCE_LAYERS)
+var ErrLayerViolation = errors.New("layered architecture violation")
+
+// Tryby wymuszania modelu warstwowego (zmienna ENFORCE_LAYERS)
+const (
+	layerEnforcementOff    = "off"    // naruszenia są tylko raportowane w grafie
+	layerEnforcementUpward = "upward" // blokowane są zależności od wyższych warstw
+	layerEnforcementAll    = "all"    // blokowane są również zależności pomijające warstwy
+)
+
+// defaultLayers model warstwowy tworzony przy pierwszym uruchomieniu
+var defaultLayers = []models.Layer{
+	{Name: "edge", Rank: 10, Description: "Brzeg systemu: gateway, load balancer"},
+	{Name: "bff", Rank: 20, Description: "Backend for frontend"},
+	{Name: "domain", Rank: 30, Description: "Serwisy domenowe"},
+	{Name: "platform", Rank: 40, Description: "Usługi platformowe współdzielone przez domeny"},
+	{Name: "data", Rank: 50, Description: "Bazy danych, kolejki, magazyny"},
+}
+
+// LayerViolationError błąd zapisu relacji z listą naruszeń modelu warstwowego
+type LayerViolationError struct {
+	Violations []models.LayerViolation
+}
+
+func (e *LayerViolationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return fmt.Sprintf("%s: %s", ErrLayerViolation, strings.Join(messages, "; "))
+}
+
+// Is pozwala sprawdzać błąd przez errors.Is(err, ErrLayerViolation)
+func (e *LayerViolationError) Is(target error) bool {
+	return target == ErrLayerViolation
+}
+
+// layerEnforcement zwraca tryb wymuszania modelu warstwowego
+func layerEnforcement() string {
+	switch mode := strings.ToLower(getEnv("ENFORCE_LAYERS", layerEnforcementOff)); mode {
+	case layerEnforcementUpward, layerEnforcementAll:
+		return mode
+	case "true":
+		return layerEnforcementAll
+	default:
+		return layerEnforcementOff
+	}
+}
+
+// seedLayers dodaje domyślne warstwy, jeśli model jest pusty
+func (s *DBStorage) seedLayers() error {
+	var count int64
+	if err := s.db.Model(&models.Layer{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	layers := append([]models.Layer(nil), defaultLayers...)
+	return s.db.Create(&layers).Error
+}
+
+// validateLayerDefinition sprawdza i normalizuje definicję warstwy
+func (s *DBStorage) validateLayerDefinition(layer *models.Layer) error {
+	layer.Name = normalizeKindName(layer.Name)
+	if !edgeTypeNamePattern.MatchString(layer.Name) {
+		return fmt.Errorf("%w name %q - use lowercase letters, digits and underscores", ErrInvalidLayer, layer.Name)
+	}
+	var other models.Layer
+	if err := s.db.Where("rank = ? AND name <> ?", layer.Rank, layer.Name).Limit(1).Find(&other).Error; err != nil {
+		return err
+	}
+	if other.Name != "" {
+		return fmt.Errorf("%w: rank %d is already used by layer %s", ErrInvalidLayer, layer.Rank, other.Name)
+	}
+	return nil
+}
+
+// validateVertexLayer sprawdza czy warstwa wierzchołka istnieje. Pusta warstwa
+// oznacza dziedziczenie po rodzicu.
+func (s *DBStorage) validateVertexLayer(vertex *models.Vertex) error {
+	if strings.TrimSpace(vertex.Layer) == "" {
+		vertex.Layer = ""
+		return nil
+	}
+	layer, err := s.GetLayer(vertex.Layer)
+	if err != nil {
+		var known []string
+		s.db.Model(&models.Layer{}).Order("rank").Pluck("name", &known)
+		return fmt.Errorf("%w: unknown layer %q - known layers: %s", ErrInvalidLayer, vertex.Layer, strings.Join(known, ", "))
+	}
+	vertex.Layer = layer.Name
+	return nil
+}
+
+// layerModel wczytuje uporządkowany model warstwowy
+func (s *DBStorage) layerModel() (*rules.Layers, error) {
+	layers, err := s.GetAllLayers()
+	if err != nil {
+		return nil, err
+	}
+	return rules.NewLayers(layers), nil
+}
+
+// checkEdgeLayers blokuje relacje łamiące model warstwowy, jeśli włączono ENFORCE_LAYERS
+func (s *DBStorage) checkEdgeLayers(edge *models.Edge) error {
+	mode := layerEnforcement()
+	if mode == layerEnforcementOff {
+		return nil
+	}
+
+	layers, err := s.layerModel()
+	if err != nil {
+		return err
+	}
+	g, err := s.rulesGraph()
+	if err != nil {
+		return err
+	}
+	var blocking []models.LayerViolation
+	for _, v := range rules.CheckEdgeLayers(layers, g, *edge) {
+		if v.Type == models.LayerViolationUpward || mode == layerEnforcementAll {
+			blocking = append(blocking, v)
+		}
+	}
+	if len(blocking) > 0 {
+		return &LayerViolationError{Violations: blocking}
+	}
+	return nil
+}
+
+// layerViolations zwraca naruszenia modelu warstwowego w całym grafie
+func (s *DBStorage) layerViolations(vertices []models.Vertex, edges []models.Edge) ([]models.LayerViolation, error) {
+	layers, err := s.layerModel()
+	if err != nil {
+		return nil, err
+	}
+	edgeTypes, err := s.GetAllEdgeTypes()
+	if err != nil {
+		return nil, err
+	}
+	return rules.EvaluateLayers(layers, rules.NewGraph(vertices, edgeTypes), edges), nil
+}
+
+// Warstwy
+
+func (s *DBStorage) GetAllLayers() ([]models.Layer, error) {
+	var layers []models.Layer
+	err := s.db.Order("rank").Find(&layers).Error
+	return layers, err
+}
+
+func (s *DBStorage) GetLayer(name string) (*models.Layer, error) {
+	var layer models.Layer
+	if err := s.db.First(&layer, "name = ?", normalizeKindName(name)).Error; err != nil {
+		return nil, notFound(err, "layer "+name)
+	}
+	return &layer, nil
+}
+
+func (s *DBStorage) CreateLayer(layer *models.Layer) error {
+	if err := s.validateLayerDefinition(layer); err != nil {
+		return err
+	}
+	if err := s.checkIDFree(&models.Layer{}, "name", layer.Name, "layer "+layer.Name); err != nil {
+		return err
+	}
+	return s.db.Create(layer).Error
+}
+
+func (s *DBStorage) UpdateLayer(layer *models.Layer) error {
+	if err := s.validateLayerDefinition(layer); err != nil {
+		return err
+	}
+	var current models.Layer
+	if err := s.db.First(&current, "name = ?", layer.Name).Error; err != nil {
+		return notFound(err, "layer "+layer.Name)
+	}
+	layer.CreatedAt = current.CreatedAt
+	return s.db.Save(layer).Error
+}
+
+func (s *DBStorage) DeleteLayer(name string) error {
+	name = normalizeKindName(name)
+
+	var count int64
+	if err := s.db.Model(&models.Vertex{}).Where("layer = ?", name).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d vertex(es) in layer %s", ErrLayerInUse, count, name)
+	}
+	return deleted(s.db.Delete(&models.Layer{}, "name = ?", name), "layer "+name)
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"microservice_overview/models"
+)
+
+func TestCreateEdge_LayerEnforcement(t *testing.T) {
+	s := newTestStorage(t)
+
+	s.CreateVertex(&models.Vertex{ID: "gateway", Name: "Gateway", Layer: "edge"})
+	s.CreateVertex(&models.Vertex{ID: "bff", Name: "BFF", Layer: "bff"})
+	s.CreateVertex(&models.Vertex{ID: "db", Name: "DB", Kind: "database", Layer: "data", Metadata: models.JSONMap{"engine": "postgres"}})
+
+	tests := []struct {
+		name    string
+		mode    string
+		edge    models.Edge
+		wantErr bool
+	}{
+		{name: "off reports only", mode: "", edge: models.Edge{From: "bff", To: "gateway", Type: "calls"}},
+		{name: "upward blocks upward", mode: "upward", edge: models.Edge{From: "bff", To: "gateway", Type: "calls"}, wantErr: true},
+		{name: "upward allows skip", mode: "upward", edge: models.Edge{From: "gateway", To: "db", Type: "calls"}},
+		{name: "all blocks skip", mode: "all", edge: models.Edge{From: "bff", To: "db", Type: "calls"}, wantErr: true},
+		{name: "all allows next layer", mode: "all", edge: models.Edge{From: "gateway", To: "bff", Type: "calls"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ENFORCE_LAYERS", tt.mode)
+
+			edge := tt.edge
+			err := s.CreateEdge(&edge)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateEdge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrLayerViolation) {
+				t.Errorf("Expected ErrLayerViolation, got %v", err)
+			}
+			if err == nil {
+				s.DeleteEdge(edge.ID, 0)
+			}
+		})
+	}
+}
+
+func TestLayers_Validation(t *testing.T) {
+	s := newTestStorage(t)
+
+	if err := s.CreateVertex(&models.Vertex{Name: "X", Layer: "presentation"}); !errors.Is(err, ErrInvalidLayer) {
+		t.Errorf("Expected ErrInvalidLayer, got %v", err)
+	}
+	if err := s.CreateLayer(&models.Layer{Name: "gateway", Rank: 10}); err == nil {
+		t.Error("Expected error for duplicate rank")
+	}
+	if err := s.CreateLayer(&models.Layer{Name: "Integration", Rank: 35}); err != nil {
+		t.Fatalf("CreateLayer() error = %v", err)
+	}
+
+	s.CreateVertex(&models.Vertex{ID: "v1", Name: "V1", Layer: "integration"})
+	if err := s.DeleteLayer("integration"); !errors.Is(err, ErrLayerInUse) {
+		t.Errorf("Expected ErrLayerInUse, got %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"microservice_overview/models"
+
+	"gorm.io/gorm"
+)
+
+// migration jednorazowa zmiana danych zapisanych przez wcześniejsze wersje.
+// Wykonane migracje są zapisywane w schema_migrations, więc późniejsze zmiany
+// użytkownika (np. katalogu typów relacji) nie są nadpisywane przy starcie
+type migration struct {
+	id  string
+	run func(s *DBStorage) error
+}
+
+// migrations migracje danych w kolejności wykonywania; nowe dopisuje się na końcu
+var migrations = []migration{
+	{id: "2026_10_legacy_edge_types", run: (*DBStorage).backfillLegacyEdgeTypes},
+	{id: "2026_10_edge_type_allowed_kinds", run: (*DBStorage).backfillEdgeTypeKinds},
+	{id: "2026_10_unique_vertex_slugs", run: (*DBStorage).uniqueVertexSlugs},
+}
+
+// migrate wykonuje brakujące migracje, każdą w osobnej transakcji
+func (s *DBStorage) migrate() error {
+	for _, m := range migrations {
+		var applied models.Migration
+		err := s.db.First(&applied, "id = ?", m.id).Error
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		err = s.db.Transaction(func(tx *gorm.DB) error {
+			if err := m.run(&DBStorage{db: tx}); err != nil {
+				return err
+			}
+			return tx.Create(&models.Migration{ID: m.id, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %s: %w", m.id, err)
+		}
+	}
+	return nil
+}
+
+// backfillLegacyEdgeTypes przenosi relacje z dowolnymi typami zapisanymi przed
+// wprowadzeniem katalogu: typ zapisany niekanonicznie (np. "Calls") albo
+// znanym wariantem typu wbudowanego (np. "call", "depends_on") dostaje nazwę
+// z katalogu, a nieznany typ (np. "authenticates") jest dodawany do katalogu,
+// żeby relacje dało się dalej edytować
+func (s *DBStorage) backfillLegacyEdgeTypes() error {
+	var legacy []string
+	err := s.db.Model(&models.Edge{}).
+		Where("type <> '' AND type NOT IN (?)", s.db.Model(&models.EdgeType{}).Select("name")).
+		Distinct().Pluck("type", &legacy).Error
+	if err != nil {
+		return err
+	}
+
+	for _, name := range legacy {
+		canonical, err := s.legacyEdgeTypeTarget(name)
+		if err != nil {
+			return err
+		}
+		var edgeType models.EdgeType
+		err = s.db.First(&edgeType, "name = ?", canonical).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			edgeType = models.EdgeType{Name: canonical, Description: fmt.Sprintf("Typ przeniesiony z relacji (%q)", name)}
+			if err := validateEdgeTypeDefinition(&edgeType); err != nil {
+				return err
+			}
+			if err := s.db.Create(&edgeType).Error; err != nil {
+				return err
+			}
+			log.Printf("migration: registered edge type %s for legacy type %q", canonical, name)
+		} else if err != nil {
+			return err
+		}
+
+		err = s.db.Model(&models.Edge{}).Where("type = ?", name).
+			Updates(map[string]interface{}{"type": canonical, "version": gorm.Expr("version + 1")}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// legacyEdgeTypeAliases warianty nazw typów wbudowanych spotykane w danych
+// sprzed katalogu (po legacyEdgeTypeName)
+var legacyEdgeTypeAliases = map[string]string{
+	"call":         "calls",
+	"sync_call":    "calls",
+	"calls_sync":   "calls",
+	"call_async":   "calls_async",
+	"async_call":   "calls_async",
+	"async_calls":  "calls_async",
+	"require":      "requires",
+	"depends_on":   "requires",
+	"dependency":   "requires",
+	"publish":      "publishes",
+	"publishes_to": "publishes",
+}
+
+// legacyEdgeTypeTarget zwraca typ z katalogu dla typu zapisanego przed jego
+// wprowadzeniem. Wariant typu wbudowanego trafia do tego typu, o ile użytkownik
+// go nie usunął - inaczej, jak każdy nieznany typ, zachowuje własną nazwę
+func (s *DBStorage) legacyEdgeTypeTarget(name string) (string, error) {
+	canonical := legacyEdgeTypeName(name)
+	alias, ok := legacyEdgeTypeAliases[canonical]
+	if !ok {
+		return canonical, nil
+	}
+	var count int64
+	if err := s.db.Model(&models.EdgeType{}).Where("name = ?", alias).Count(&count).Error; err != nil {
+		return "", err
+	}
+	if count == 0 {
+		return canonical, nil
+	}
+	return alias, nil
+}
+
+// legacyEdgeTypeName sprowadza dowolny zapisany typ do poprawnej nazwy z katalogu:
+// normalizuje ją, zastępuje niedozwolone znaki "_" i dodaje przedrostek "legacy_",
+// gdy nazwa nie zaczyna się od litery
+func legacyEdgeTypeName(name string) string {
+	name = strings.Trim(invalidEdgeTypeChars.ReplaceAllString(normalizeEdgeTypeName(name), "_"), "_")
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = "legacy_" + name
+	}
+	return strings.TrimSuffix(name, "_")
+}
+
+// invalidEdgeTypeChars znaki niedozwolone w nazwie typu relacji
+var invalidEdgeTypeChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// backfillEdgeTypeKinds nadaje wbudowanym typom relacji zapisanym przed
+// wprowadzeniem rejestru rodzajów ich domyślne ograniczenia rodzajów. Typ
+// zmieniony przez użytkownika albo z relacjami łamiącymi ograniczenia zostaje
+// bez zmian
+func (s *DBStorage) backfillEdgeTypeKinds() error {
+	kinds, err := s.vertexKindsByName()
+	if err != nil {
+		return err
+	}
+
+	for _, builtin := range defaultEdgeTypes {
+		if len(builtin.AllowedSourceKinds) == 0 && len(builtin.AllowedTargetKinds) == 0 {
+			continue
+		}
+		var current models.EdgeType
+		if err := s.db.First(&current, "name = ?", builtin.Name).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue // Typ usunięty przez użytkownika
+			}
+			return err
+		}
+		if len(current.AllowedSourceKinds) > 0 || len(current.AllowedTargetKinds) > 0 {
+			continue
+		}
+
+		candidate := current
+		candidate.AllowedSourceKinds = builtin.AllowedSourceKinds
+		candidate.AllowedTargetKinds = builtin.AllowedTargetKinds
+		if reason, err := s.edgeTypeKindsConflict(&candidate, kinds); err != nil {
+			return err
+		} else if reason != "" {
+			log.Printf("migration: keeping edge type %s without kind restrictions: %s", builtin.Name, reason)
+			continue
+		}
+
+		if err := s.db.Model(&current).Select("AllowedSourceKinds", "AllowedTargetKinds").Updates(&candidate).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// edgeTypeKindsConflict opisuje, dlaczego ograniczeń rodzajów typu nie da się
+// nałożyć na zapisane dane (brakujący rodzaj albo relacja łamiąca ograniczenie);
+// pusty napis - ograniczenia pasują
+func (s *DBStorage) edgeTypeKindsConflict(edgeType *models.EdgeType, kinds map[string]models.VertexKind) (string, error) {
+	for _, list := range []models.StringList{edgeType.AllowedSourceKinds, edgeType.AllowedTargetKinds} {
+		for _, name := range list {
+			if _, ok := kinds[name]; !ok {
+				return fmt.Sprintf("vertex kind %s is not registered", name), nil
+			}
+		}
+	}
+
+	var edges []models.Edge
+	if err := s.db.Where("type = ?", edgeType.Name).Find(&edges).Error; err != nil {
+		return "", err
+	}
+	for _, edge := range edges {
+		from, err := s.GetVertexByID(edge.From)
+		if err != nil {
+			continue // Relacje do brakujących wierzchołków wykrywa walidacja spójności
+		}
+		to, err := s.GetVertexByID(edge.To)
+		if err != nil {
+			continue
+		}
+		if len(edgeType.AllowedSourceKinds) > 0 && !edgeType.AllowedSourceKinds.Contains(from.Kind) ||
+			len(edgeType.AllowedTargetKinds) > 0 && !edgeType.AllowedTargetKinds.Contains(to.Kind) {
+			return fmt.Sprintf("edge %s connects %s (%s) and %s (%s)", edge.ID, from.ID, from.Kind, to.ID, to.Kind), nil
+		}
+	}
+	return "", nil
+}
+
+// vertexSlugIndex unikalny indeks slugów; obejmuje też usunięte wierzchołki,
+// tak jak uniqueSlug
+const vertexSlugIndex = "idx_vertices_slug_unique"
+
+// uniqueVertexSlugs nadaje nowe slugi wierzchołkom, które dostały zajęty slug
+// przy równoległych zapisach (zostaje najstarszy), i zakłada unikalny indeks
+func (s *DBStorage) uniqueVertexSlugs() error {
+	var duplicated []string
+	err := s.db.Unscoped().Model(&models.Vertex{}).Group("slug").Having("COUNT(*) > 1").Pluck("slug", &duplicated).Error
+	if err != nil {
+		return err
+	}
+	for _, slug := range duplicated {
+		var vertices []models.Vertex
+		if err := s.db.Unscoped().Where("slug = ?", slug).Order("created_at, id").Find(&vertices).Error; err != nil {
+			return err
+		}
+		for _, v := range vertices[1:] {
+			renamed, err := s.uniqueSlug(slug, v.ID)
+			if err != nil {
+				return err
+			}
+			if err := s.db.Unscoped().Model(&models.Vertex{}).Where("id = ?", v.ID).UpdateColumn("slug", renamed).Error; err != nil {
+				return err
+			}
+			log.Printf("migration: vertex %s slug %s renamed to %s", v.ID, slug, renamed)
+		}
+	}
+	return s.db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS " + vertexSlugIndex + " ON vertices (slug)").Error
+}
@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"microservice_overview/models"
+
+	"gorm.io/gorm"
+)
+
+// resetEdgeTypeKinds symuluje bazę sprzed rejestru rodzajów: typy bez ograniczeń i bez migracji
+func resetEdgeTypeKinds(t *testing.T, s *DBStorage) {
+	t.Helper()
+	empty := models.StringList{}
+	if err := s.db.Model(&models.EdgeType{}).Where("name IN ?", []string{"calls", "calls_async"}).
+		Updates(map[string]interface{}{"allowed_source_kinds": empty, "allowed_target_kinds": empty}).Error; err != nil {
+		t.Fatalf("failed to reset edge types: %v", err)
+	}
+	if err := s.db.Delete(&models.Migration{}, "id = ?", "2026_10_edge_type_allowed_kinds").Error; err != nil {
+		t.Fatalf("failed to reset migrations: %v", err)
+	}
+}
+
+func TestMigrate_BackfillsEdgeTypeKinds(t *testing.T) {
+	s := newTestStorage(t)
+	resetEdgeTypeKinds(t, s)
+
+	// Istniejąca relacja calls_async z bazy danych blokuje ograniczenie tego typu
+	s.CreateVertex(&models.Vertex{ID: "svc", Name: "Service"})
+	s.CreateVertex(&models.Vertex{ID: "db", Name: "Database", Kind: "database", Metadata: models.JSONMap{"engine": "postgres"}})
+	if err := s.CreateEdge(&models.Edge{ID: "e1", From: "db", To: "svc", Type: "calls_async"}); err != nil {
+		t.Fatalf("CreateEdge() error = %v", err)
+	}
+
+	if err := s.migrate(); err != nil {
+		t.Fatalf("migrate() error = %v", err)
+	}
+	calls, _ := s.GetEdgeType("calls")
+	if !calls.AllowedSourceKinds.Contains(models.KindService) || !calls.AllowedSourceKinds.Contains("external_api") {
+		t.Errorf("Expected calls to get the default source kinds, got %v", calls.AllowedSourceKinds)
+	}
+	async, _ := s.GetEdgeType("calls_async")
+	if len(async.AllowedSourceKinds) != 0 {
+		t.Errorf("Expected calls_async with conflicting edges to stay unrestricted, got %v", async.AllowedSourceKinds)
+	}
+
+	// Migracja wykonuje się raz - późniejsza zmiana katalogu zostaje
+	calls.AllowedSourceKinds = nil
+	if err := s.UpdateEdgeType(calls); err != nil {
+		t.Fatalf("UpdateEdgeType() error = %v", err)
+	}
+	if err := s.migrate(); err != nil {
+		t.Fatalf("migrate() error = %v", err)
+	}
+	if calls, _ := s.GetEdgeType("calls"); len(calls.AllowedSourceKinds) != 0 {
+		t.Errorf("Expected the migration not to run again, got %v", calls.AllowedSourceKinds)
+	}
+}
+
+func TestMigrate_BackfillsLegacyEdgeTypes(t *testing.T) {
+	s := newTestStorage(t)
+	s.CreateVertex(&models.Vertex{ID: "a", Name: "A"})
+	s.CreateVertex(&models.Vertex{ID: "b", Name: "B"})
+
+	// Relacje zapisane przed katalogiem typów - z pominięciem walidacji
+	legacy := map[string]string{"e1": "Calls", "e2": "authenticates", "e3": "HTTP/REST", "e4": "2fa", "e5": "",
+		"e6": "call", "e7": "Depends-On", "e8": "async call"}
+	for id, edgeType := range legacy {
+		if err := s.db.Create(&models.Edge{ID: id, From: "a", To: "b", Type: edgeType, Version: 1}).Error; err != nil {
+			t.Fatalf("failed to create legacy edge: %v", err)
+		}
+	}
+	if err := s.db.Delete(&models.Migration{}, "id = ?", "2026_10_legacy_edge_types").Error; err != nil {
+		t.Fatalf("failed to reset migrations: %v", err)
+	}
+
+	if err := s.migrate(); err != nil {
+		t.Fatalf("migrate() error = %v", err)
+	}
+	expected := map[string]string{"e1": "calls", "e2": "authenticates", "e3": "http_rest", "e4": "legacy_2fa", "e5": "",
+		"e6": "calls", "e7": "requires", "e8": "calls_async"}
+	for id, edgeType := range expected {
+		edge, err := s.GetEdgeByID(id)
+		if err != nil {
+			t.Fatalf("GetEdgeByID(%s) error = %v", id, err)
+		}
+		if edge.Type != edgeType {
+			t.Errorf("Expected edge %s to have type %q, got %q", id, edgeType, edge.Type)
+		}
+		if edgeType != "" && edge.Version != 2 {
+			t.Errorf("Expected migrated edge %s to get a new version, got %d", id, edge.Version)
+		}
+	}
+	if _, err := s.GetEdgeType("authenticates"); err != nil {
+		t.Errorf("Expected the legacy type to be registered, got %v", err)
+	}
+	for _, name := range []string{"call", "depends_on", "async_call"} {
+		if _, err := s.GetEdgeType(name); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected variant %s to be mapped onto a built-in type instead of registered, got %v", name, err)
+		}
+	}
+
+	// Przeniesione relacje można dalej aktualizować
+	edge, _ := s.GetEdgeByID("e2")
+	edge.LatencyMs = 5
+	if err := s.UpdateEdge(edge); err != nil {
+		t.Errorf("UpdateEdge() on a migrated edge error = %v", err)
+	}
+}
+
+func TestMigrate_UniqueVertexSlugs(t *testing.T) {
+	s := newTestStorage(t)
+
+	// Baza sprzed indeksu: równoległe zapisy nadały ten sam slug
+	if err := s.db.Exec("DROP INDEX " + vertexSlugIndex).Error; err != nil {
+		t.Fatalf("failed to drop index: %v", err)
+	}
+	s.db.Delete(&models.Migration{}, "id = ?", "2026_10_unique_vertex_slugs")
+	now := time.Now()
+	for i, id := range []string{"first", "second", "third"} {
+		vertex := models.Vertex{ID: id, Name: "Orders", Slug: "orders", CreatedAt: now.Add(time.Duration(i) * time.Second)}
+		if err := s.db.Create(&vertex).Error; err != nil {
+			t.Fatalf("failed to seed vertex: %v", err)
+		}
+	}
+	s.db.Delete(&models.Vertex{}, "id = ?", "second")
+
+	if err := s.migrate(); err != nil {
+		t.Fatalf("migrate() error = %v", err)
+	}
+	expected := map[string]string{"first": "orders", "second": "orders-2", "third": "orders-3"}
+	for id, slug := range expected {
+		var vertex models.Vertex
+		s.db.Unscoped().First(&vertex, "id = ?", id)
+		if vertex.Slug != slug {
+			t.Errorf("Expected %s to get slug %s, got %s", id, slug, vertex.Slug)
+		}
+	}
+
+	// Indeks odrzuca zajęty slug
+	err := s.db.Create(&models.Vertex{ID: "fourth", Name: "Orders", Slug: "orders"}).Error
+	if !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Errorf("Expected ErrDuplicatedKey, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"microservice_overview/models"
+	"microservice_overview/rules"
+)
+
+// ErrRuleViolation zwracany gdy zapis relacji łamie regułę architektury o ważności error
+var ErrRuleViolation = errors.New("architecture rule violation")
+
+// RuleViolationError błąd zapisu relacji z listą naruszonych reguł
+type RuleViolationError struct {
+	Violations []models.RuleViolation
+}
+
+func (e *RuleViolationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return fmt.Sprintf("%s: %s", ErrRuleViolation, strings.Join(messages, "; "))
+}
+
+// Is pozwala sprawdzać błąd przez errors.Is(err, ErrRuleViolation)
+func (e *RuleViolationError) Is(target error) bool {
+	return target == ErrRuleViolation
+}
+
+// rulesGraph buduje indeks grafu dla silnika reguł
+func (s *DBStorage) rulesGraph() (*rules.Graph, error) {
+	vertices, err := s.GetAllVertices()
+	if err != nil {
+		return nil, err
+	}
+	edgeTypes, err := s.GetAllEdgeTypes()
+	if err != nil {
+		return nil, err
+	}
+	return rules.NewGraph(vertices, edgeTypes), nil
+}
+
+// checkEdgeRules sprawdza zapisywaną relację względem włączonych reguł.
+// Blokują tylko naruszenia o ważności error.
+func (s *DBStorage) checkEdgeRules(edge *models.Edge) error {
+	var enabled []models.ArchitectureRule
+	if err := s.db.Where("disabled = ?", false).Order("name").Find(&enabled).Error; err != nil {
+		return err
+	}
+	if len(enabled) == 0 {
+		return nil
+	}
+
+	g, err := s.rulesGraph()
+	if err != nil {
+		return err
+	}
+	var blocking []models.RuleViolation
+	for _, v := range rules.CheckEdge(enabled, g, *edge) {
+		if v.Severity == models.RuleSeverityError {
+			blocking = append(blocking, v)
+		}
+	}
+	if len(blocking) > 0 {
+		return &RuleViolationError{Violations: blocking}
+	}
+	return nil
+}
+
+// Reguły architektury
+
+func (s *DBStorage) GetAllRules() ([]models.ArchitectureRule, error) {
+	var all []models.ArchitectureRule
+	err := s.db.Order("name, id").Find(&all).Error
+	return all, err
+}
+
+func (s *DBStorage) GetRuleByID(id string) (*models.ArchitectureRule, error) {
+	var rule models.ArchitectureRule
+	if err := s.db.First(&rule, "id = ?", id).Error; err != nil {
+		return nil, notFound(err, "rule "+id)
+	}
+	return &rule, nil
+}
+
+func (s *DBStorage) CreateRule(rule *models.ArchitectureRule) error {
+	if rule.ID == "" {
+		rule.ID = newID()
+	} else if err := s.checkIDFree(&models.ArchitectureRule{}, "id", rule.ID, "rule "+rule.ID); err != nil {
+		return err
+	}
+	g, err := s.rulesGraph()
+	if err != nil {
+		return err
+	}
+	if err := rules.ValidateRule(rule, g); err != nil {
+		return err
+	}
+	return s.db.Create(rule).Error
+}
+
+func (s *DBStorage) UpdateRule(rule *models.ArchitectureRule) error {
+	var current models.ArchitectureRule
+	if err := s.db.First(&current, "id = ?", rule.ID).Error; err != nil {
+		return notFound(err, "rule "+rule.ID)
+	}
+	g, err := s.rulesGraph()
+	if err != nil {
+		return err
+	}
+	if err := rules.ValidateRule(rule, g); err != nil {
+		return err
+	}
+	rule.CreatedAt = current.CreatedAt
+	return s.db.Save(rule).Error
+}
+
+func (s *DBStorage) DeleteRule(id string) error {
+	return deleted(s.db.Delete(&models.ArchitectureRule{}, "id = ?", id), "rule "+id)
+}
+
+// GetRuleViolations sprawdza wszystkie relacje grafu względem włączonych reguł
+func (s *DBStorage) GetRuleViolations() (*models.RuleReport, error) {
+	all, err := s.GetAllRules()
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.rulesGraph()
+	if err != nil {
+		return nil, err
+	}
+	edges, err := s.GetAllEdges()
+	if err != nil {
+		return nil, err
+	}
+	return rules.Evaluate(all, g, edges), nil
+}
@@ -1,8 +1,10 @@
 package storage
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"microservice_overview/models"
 
@@ -16,21 +18,142 @@ type Storage interface {
 	// Wierzchołki
 	GetAllVertices() ([]models.Vertex, error)
 	GetVertexByID(id string) (*models.Vertex, error)
-	CreateVertex(vertex *models.Vertex) error
-	UpdateVertex(vertex *models.Vertex) error
-	DeleteVertex(id string) error
-	HasChildren(vertexID string) (bool, error)  // Sprawdza czy wierzchołek ma dzieci
-	IsLeafVertex(vertexID string) (bool, error) // Sprawdza czy wierzchołek jest na najniższym poziomie
+	GetVertexByIDOrSlug(ref string) (*models.Vertex, error)                        // Szuka wierzchołka po ID, a gdy go nie ma - po slugu
+	CreateVertex(vertex *models.Vertex) error                                      // Puste ID jest generowane (UUIDv7), slug wyprowadzany z nazwy
+	UpdateVertex(vertex *models.Vertex) error                                      // Version > 0 wymaga zgodności wersji w bazie
+	DeleteVertex(id string, version int64) error                                   // version 0 = bez sprawdzania wersji; ErrVertexInUse, jeśli ma relacje lub dzieci
+	MoveVertex(id string, parentID *string, version int64) (*models.Vertex, error) // Przenosi wierzchołek (z poddrzewem) pod nowego rodzica
+	HasChildren(vertexID string) (bool, error)                                     // Sprawdza czy wierzchołek ma dzieci
+	IsLeafVertex(vertexID string) (bool, error)                                    // Sprawdza czy wierzchołek jest na najniższym poziomie
+	HasEdges(vertexID string) (bool, error)                                        // Sprawdza czy wierzchołek ma jakiekolwiek relacje
 
 	// Relacje
 	GetAllEdges() ([]models.Edge, error)
 	GetEdgeByID(id string) (*models.Edge, error)
-	CreateEdge(edge *models.Edge) error
-	UpdateEdge(edge *models.Edge) error
-	DeleteEdge(id string) error
+	CreateEdge(edge *models.Edge) error        // Puste ID jest generowane (UUIDv7); ErrDuplicateEdge, jeśli relacja już istnieje
+	UpdateEdge(edge *models.Edge) error        // Version > 0 wymaga zgodności wersji w bazie
+	DeleteEdge(id string, version int64) error // version 0 = bez sprawdzania wersji
+
+	// Katalog typów relacji
+	GetAllEdgeTypes() ([]models.EdgeType, error)
+	GetEdgeType(name string) (*models.EdgeType, error)
+	CreateEdgeType(edgeType *models.EdgeType) error
+	UpdateEdgeType(edgeType *models.EdgeType) error
+	DeleteEdgeType(name string) error // Zwraca ErrEdgeTypeInUse, jeśli typ jest używany
+
+	// Rejestr rodzajów wierzchołków
+	GetAllVertexKinds() ([]models.VertexKind, error)
+	GetVertexKind(name string) (*models.VertexKind, error)
+	CreateVertexKind(kind *models.VertexKind) error
+	UpdateVertexKind(kind *models.VertexKind) error
+	DeleteVertexKind(name string) error // Zwraca ErrVertexKindInUse, jeśli rodzaj jest używany
+
+	// Model warstwowy
+	GetAllLayers() ([]models.Layer, error) // Od najwyższej warstwy
+	GetLayer(name string) (*models.Layer, error)
+	CreateLayer(layer *models.Layer) error
+	UpdateLayer(layer *models.Layer) error
+	DeleteLayer(name string) error // Zwraca ErrLayerInUse, jeśli do warstwy należą wierzchołki
+
+	// Reguły architektury
+	GetAllRules() ([]models.ArchitectureRule, error)
+	GetRuleByID(id string) (*models.ArchitectureRule, error)
+	CreateRule(rule *models.ArchitectureRule) error // Puste ID jest generowane (UUIDv7)
+	UpdateRule(rule *models.ArchitectureRule) error
+	DeleteRule(id string) error
+	GetRuleViolations() (*models.RuleReport, error) // Sprawdza wszystkie relacje względem włączonych reguł
+
+	// Webhooki
+	GetAllWebhooks() ([]models.Webhook, error)
+	GetWebhookByID(id string) (*models.Webhook, error)
+	CreateWebhook(webhook *models.Webhook) error                                        // Puste ID jest generowane (UUIDv7)
+	UpdateWebhook(webhook *models.Webhook) error                                        // Pusty sekret zachowuje dotychczasowy
+	DeleteWebhook(id string) error                                                      // Usuwa też log dostarczeń
+	RecordWebhookDelivery(delivery *models.WebhookDelivery) error                       // Zapisuje próbę dostarczenia; ErrNotFound, jeśli webhook usunięto
+	GetWebhookDeliveries(webhookID string, limit int) ([]models.WebhookDelivery, error) // Od najnowszych
+	DeleteWebhookDeliveriesBefore(t time.Time) (int64, error)                           // Usuwa próby starsze niż t; zwraca ich liczbę
+
+	// Klucze idempotencji
+	CreateIdempotencyRecord(record *models.IdempotencyRecord) error // Rezerwuje klucz; ErrDuplicateID, jeśli jest zajęty
+	GetIdempotencyRecord(key string) (*models.IdempotencyRecord, error)
+	CompleteIdempotencyRecord(record *models.IdempotencyRecord) error // Zapisuje odpowiedź, o ile rezerwacja z record.Token nadal trwa
+	DeleteIdempotencyRecord(key, token string) error                  // Zwalnia klucz zarezerwowany z tokenem
+	DeleteIdempotencyRecordsBefore(t time.Time) (int64, error)        // Usuwa rekordy starsze niż t; zwraca ich liczbę
+
+	// Tabela zdarzeń (zob. events.Relay)
+	AppendEvents(records []models.EventRecord) error                  // Nadaje rekordom kolejne numery
+	GetEventsAfter(id int64, limit int) ([]models.EventRecord, error) // Od najstarszych
+	GetLastEventID() (int64, error)                                   // 0, jeśli tabela jest pusta
+	DeleteEventsBefore(t time.Time) (int64, error)                    // Usuwa zdarzenia starsze niż t; zwraca ich liczbę
 
 	// Graf
-	GetGraph() (*models.Graph, error)
+	GetGraph() (*models.Graph, error)                                       // Zawiera naruszenia modelu warstwowego
+	ValidateGraph() (*models.IntegrityReport, error)                        // Sprawdza spójność zapisanych danych
+	RepairGraph(strategy string, dryRun bool) (*models.RepairReport, error) // Naprawia naruszenia spójności
+
+	// Transakcje
+	Transaction(fn func(tx Storage) error) error // Wykonuje fn w jednej transakcji; błąd fn wycofuje wszystkie zmiany
+}
+
+// ErrVersionConflict zwracany gdy rekord został w międzyczasie zmieniony (wersja się nie zgadza)
+var ErrVersionConflict = errors.New("version conflict: resource was modified by another request")
+
+// Błędy zapisu rozpoznawane przez handlery (errors.Is); opis konkretnego
+// przypadku jest doklejany do komunikatu
+var (
+	ErrNotFound         = errors.New("not found")                                  // Wskazany rekord nie istnieje
+	ErrDuplicateID      = errors.New("already exists")                             // ID (lub nazwa) jest już zajęte
+	ErrParentNotFound   = errors.New("parent vertex not found")                    // Rodzic wierzchołka nie istnieje
+	ErrEndpointNotFound = errors.New("edge endpoint not found")                    // Wierzchołek relacji nie istnieje
+	ErrHierarchyCycle   = errors.New("cannot create cycle in vertex hierarchy")    // Wierzchołek stałby się własnym przodkiem
+	ErrEdgeOnNonLeaf    = errors.New("edges can only exist between leaf vertices") // Relacja wierzchołka z dziećmi
+	ErrVertexInUse      = errors.New("vertex has edges or children")               // Usuwany wierzchołek ma relacje lub dzieci
+	ErrDuplicateEdge    = errors.New("edge already exists")                        // Relacja o tych samych końcach i typie już istnieje
+)
+
+// notFound opisuje brak rekordu jako ErrNotFound; inne błędy bazy zwraca bez zmian
+func notFound(err error, what string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%s %w", what, ErrNotFound)
+	}
+	return err
+}
+
+// deleted zwraca błąd usuwania; brak usuniętego rekordu to ErrNotFound
+func deleted(result *gorm.DB, what string) error {
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", what, ErrNotFound)
+	}
+	return nil
+}
+
+// checkIDFree zwraca ErrDuplicateID, jeśli rekord o podanym kluczu już istnieje
+// (także usunięty - klucz główny nadal jest zajęty)
+func (s *DBStorage) checkIDFree(model interface{}, column, id, what string) error {
+	var count int64
+	if err := s.db.Unscoped().Model(model).Where(column+" = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%s %w", what, ErrDuplicateID)
+	}
+	return nil
+}
+
+// findVertex wczytuje wierzchołek wskazany przez inny rekord; brak wierzchołka
+// zwraca jako missing (ErrParentNotFound lub ErrEndpointNotFound)
+func (s *DBStorage) findVertex(id string, missing error, role string) (*models.Vertex, error) {
+	var vertex models.Vertex
+	if err := s.db.First(&vertex, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s %s does not exist", missing, role, id)
+		}
+		return nil, err
+	}
+	return &vertex, nil
 }
 
 // DBStorage implementacja Storage używająca GORM
@@ -47,26 +170,76 @@ func NewStorage() (Storage, error) {
 
 	if devMode {
 		// Tryb developerski - SQLite w pamięci
-		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
 		if err != nil {
 			return nil, fmt.Errorf("failed to connect to in-memory database: %w", err)
 		}
+		// Każde połączenie do ":memory:" to osobna baza - transakcje i zapytania
+		// muszą współdzielić jedno połączenie
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to access in-memory database: %w", err)
+		}
+		sqlDB.SetMaxOpenConns(1)
 	} else {
 		// Tryb produkcyjny - PostgreSQL
 		dsn := buildPostgresDSN()
-		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 		if err != nil {
 			return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
 		}
 	}
 
 	// Automatyczna migracja schematu
-	err = db.AutoMigrate(&models.Vertex{}, &models.Edge{})
+	err = db.AutoMigrate(&models.Vertex{}, &models.Edge{}, &models.EdgeType{}, &models.VertexKind{}, &models.ArchitectureRule{}, &models.Layer{}, &models.Webhook{}, &models.WebhookDelivery{}, &models.IdempotencyRecord{}, &models.EventRecord{}, &models.Migration{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
-	return &DBStorage{db: db}, nil
+	s := &DBStorage{db: db}
+	if err := s.backfillSlugs(); err != nil {
+		return nil, fmt.Errorf("failed to backfill vertex slugs: %w", err)
+	}
+	if err := s.seedVertexKinds(); err != nil {
+		return nil, fmt.Errorf("failed to seed vertex kinds: %w", err)
+	}
+	if err := s.seedEdgeTypes(); err != nil {
+		return nil, fmt.Errorf("failed to seed edge types: %w", err)
+	}
+	if err := s.seedLayers(); err != nil {
+		return nil, fmt.Errorf("failed to seed layers: %w", err)
+	}
+	if err := s.migrate(); err != nil {
+		return nil, fmt.Errorf("failed to migrate data: %w", err)
+	}
+
+	return s, nil
+}
+
+// Transaction wykonuje fn na storage związanym z transakcją bazy. Wszystkie
+// odczyty i zapisy w fn muszą przechodzić przez tx
+func (s *DBStorage) Transaction(fn func(tx Storage) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&DBStorage{db: tx})
+	})
+}
+
+// backfillSlugs nadaje slugi wierzchołkom zapisanym przed wprowadzeniem slugów
+func (s *DBStorage) backfillSlugs() error {
+	var vertices []models.Vertex
+	if err := s.db.Unscoped().Where("slug = ? OR slug IS NULL", "").Order("created_at, id").Find(&vertices).Error; err != nil {
+		return err
+	}
+	for _, v := range vertices {
+		slug, err := s.uniqueSlug(slugify(v.Name), v.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.db.Unscoped().Model(&models.Vertex{}).Where("id = ?", v.ID).UpdateColumn("slug", slug).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // buildPostgresDSN buduje connection string dla PostgreSQL
@@ -101,24 +274,118 @@ func (s *DBStorage) GetVertexByID(id string) (*models.Vertex, error) {
 	var vertex models.Vertex
 	err := s.db.First(&vertex, "id = ?", id).Error
 	if err != nil {
-		return nil, err
+		return nil, notFound(err, "vertex "+id)
 	}
 	return &vertex, nil
 }
 
+func (s *DBStorage) GetVertexByIDOrSlug(ref string) (*models.Vertex, error) {
+	vertex, err := s.GetVertexByID(ref)
+	if !errors.Is(err, ErrNotFound) {
+		return vertex, err
+	}
+
+	var bySlug models.Vertex
+	if err := s.db.First(&bySlug, "slug = ?", ref).Error; err != nil {
+		return nil, notFound(err, "vertex "+ref)
+	}
+	return &bySlug, nil
+}
+
+// uniqueSlug zwraca base lub base-N, jeśli base jest już zajęty przez inny wierzchołek.
+// Sprawdzane są także usunięte wierzchołki, żeby stare linki nie wskazywały na nowy wierzchołek.
+func (s *DBStorage) uniqueSlug(base, vertexID string) (string, error) {
+	candidate := base
+	for n := 2; ; n++ {
+		taken, err := s.slugTaken(candidate, vertexID)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = withSuffix(base, n)
+	}
+}
+
+// slugTaken sprawdza czy slug ma inny wierzchołek (także usunięty)
+func (s *DBStorage) slugTaken(slug, vertexID string) (bool, error) {
+	var count int64
+	err := s.db.Unscoped().Model(&models.Vertex{}).Where("slug = ? AND id <> ?", slug, vertexID).Count(&count).Error
+	return count > 0, err
+}
+
+// slugAttempts ile razy zapis wierzchołka jest ponawiany, gdy równoległy zapis zajmie ten sam slug
+const slugAttempts = 5
+
+// saveWithSlug zapisuje wierzchołek (write) z pierwszym wolnym slugiem
+// wyprowadzonym z base. O unikalności decyduje indeks w bazie - gdy równoległy
+// zapis zajmie wybrany slug, zapis jest ponawiany z kolejnym sufiksem
+func (s *DBStorage) saveWithSlug(vertex *models.Vertex, base string, write func(tx *gorm.DB) error) error {
+	for attempt := 1; ; attempt++ {
+		slug, err := s.uniqueSlug(base, vertex.ID)
+		if err != nil {
+			return err
+		}
+		vertex.Slug = slug
+		// Punkt zapisu pozwala ponowić zapis także w trwającej transakcji
+		err = s.db.Transaction(write)
+		if !errors.Is(err, gorm.ErrDuplicatedKey) || attempt == slugAttempts {
+			return err
+		}
+		taken, err := s.slugTaken(slug, vertex.ID)
+		if err != nil {
+			return err
+		}
+		if !taken {
+			// Konflikt dotyczy ID - równoległy zapis utworzył ten sam wierzchołek
+			return fmt.Errorf("vertex %s %w", vertex.ID, ErrDuplicateID)
+		}
+	}
+}
+
 func (s *DBStorage) CreateVertex(vertex *models.Vertex) error {
+	if vertex.ID == "" {
+		vertex.ID = newID()
+	} else if err := s.checkIDFree(&models.Vertex{}, "id", vertex.ID, "vertex "+vertex.ID); err != nil {
+		return err
+	}
+	// Walidacja: rodzaj musi istnieć w rejestrze, a metadane pasować do jego schematu
+	if _, err := s.validateVertexKind(vertex); err != nil {
+		return err
+	}
+	if err := s.validateVertexLayer(vertex); err != nil {
+		return err
+	}
+	if err := validateVertexLatency(vertex); err != nil {
+		return err
+	}
+	if err := validateVertexSLO(vertex); err != nil {
+		return err
+	}
 	// Walidacja: jeśli ParentID jest ustawione, sprawdź czy rodzic istnieje
 	if vertex.ParentID != nil && *vertex.ParentID != "" {
-		var parent models.Vertex
-		if err := s.db.First(&parent, "id = ?", *vertex.ParentID).Error; err != nil {
-			return fmt.Errorf("parent vertex not found: %w", err)
+		if _, err := s.findVertex(*vertex.ParentID, ErrParentNotFound, "vertex"); err != nil {
+			return err
 		}
 		// Walidacja: sprawdź czy nie tworzymy cyklu (rodzic nie może być potomkiem tego wierzchołka)
 		if err := s.validateNoCycle(*vertex.ParentID, vertex.ID); err != nil {
 			return err
 		}
+		// Walidacja: rodzic przestanie być liściem, więc nie może mieć relacji
+		if err := s.validateParentAcceptsChildren(*vertex.ParentID); err != nil {
+			return err
+		}
 	}
-	return s.db.Create(vertex).Error
+	source := vertex.Slug
+	if source == "" {
+		source = vertex.Name
+	}
+
+	vertex.Version = 1
+	return s.saveWithSlug(vertex, slugify(source), func(tx *gorm.DB) error {
+		return tx.Create(vertex).Error
+	})
 }
 
 // validateNoCycle sprawdza czy dodanie parentID nie tworzy cyklu
@@ -131,7 +398,7 @@ func (s *DBStorage) validateNoCycle(parentID, currentID string) error {
 // checkCycle rekurencyjnie sprawdza czy istnieje ścieżka od start do target
 func (s *DBStorage) checkCycle(start, target string, visited map[string]bool) error {
 	if start == target {
-		return fmt.Errorf("cannot create cycle: vertex %s would be ancestor of itself", target)
+		return fmt.Errorf("%w: vertex %s would be ancestor of itself", ErrHierarchyCycle, target)
 	}
 	if visited[start] {
 		return nil // Już sprawdziliśmy tę gałąź
@@ -151,22 +418,185 @@ func (s *DBStorage) checkCycle(start, target string, visited map[string]bool) er
 }
 
 func (s *DBStorage) UpdateVertex(vertex *models.Vertex) error {
+	var current models.Vertex
+	if err := s.db.First(&current, "id = ?", vertex.ID).Error; err != nil {
+		return notFound(err, "vertex "+vertex.ID)
+	}
+	if vertex.Version != 0 && vertex.Version != current.Version {
+		return ErrVersionConflict
+	}
+
+	// Rodzaj jest zachowywany, gdy klient go nie poda; zmiana rodzaju musi
+	// być zgodna z istniejącymi relacjami wierzchołka
+	if vertex.Kind == "" {
+		vertex.Kind = current.Kind
+	}
+	if _, err := s.validateVertexKind(vertex); err != nil {
+		return err
+	}
+	if vertex.Kind != current.Kind {
+		if err := s.validateKindChange(vertex); err != nil {
+			return err
+		}
+	}
+	if err := s.validateVertexLayer(vertex); err != nil {
+		return err
+	}
+	if err := validateVertexLatency(vertex); err != nil {
+		return err
+	}
+	if err := validateVertexSLO(vertex); err != nil {
+		return err
+	}
+
 	// Walidacja: jeśli ParentID jest ustawione, sprawdź czy rodzic istnieje
 	if vertex.ParentID != nil && *vertex.ParentID != "" {
-		var parent models.Vertex
-		if err := s.db.First(&parent, "id = ?", *vertex.ParentID).Error; err != nil {
-			return fmt.Errorf("parent vertex not found: %w", err)
+		if _, err := s.findVertex(*vertex.ParentID, ErrParentNotFound, "vertex"); err != nil {
+			return err
 		}
 		// Walidacja: sprawdź czy nie tworzymy cyklu
 		if err := s.validateNoCycle(*vertex.ParentID, vertex.ID); err != nil {
 			return err
 		}
+		// Walidacja: rodzic przestanie być liściem, więc nie może mieć relacji
+		if err := s.validateParentAcceptsChildren(*vertex.ParentID); err != nil {
+			return err
+		}
+	}
+
+	vertex.CreatedAt = current.CreatedAt
+	vertex.Version = current.Version + 1
+
+	// Slug jest stabilny - zmienia się tylko gdy klient poda nowy
+	if vertex.Slug == "" {
+		vertex.Slug = current.Slug
+		return s.updateVersioned(vertex, current.Version)
+	}
+	return s.saveWithSlug(vertex, slugify(vertex.Slug), func(tx *gorm.DB) error {
+		return (&DBStorage{db: tx}).updateVersioned(vertex, current.Version)
+	})
+}
+
+// updateVersioned zapisuje wszystkie pola rekordu pod warunkiem, że wersja
+// w bazie nadal jest równa expected (rekord musi mieć już ustawioną nową wersję)
+func (s *DBStorage) updateVersioned(record interface{}, expected int64) error {
+	result := s.db.Model(record).Where("version = ?", expected).Select("*").Omit("created_at", "deleted_at").Updates(record)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// validateParentAcceptsChildren sprawdza czy wierzchołek może zostać rodzicem.
+// Relacje mogą istnieć tylko między liśćmi, więc wierzchołek z relacjami musi pozostać liściem.
+func (s *DBStorage) validateParentAcceptsChildren(parentID string) error {
+	hasEdges, err := s.HasEdges(parentID)
+	if err != nil {
+		return fmt.Errorf("failed to check edges of parent vertex: %w", err)
+	}
+	if hasEdges {
+		return fmt.Errorf("%w: parent vertex %s has edges, so it cannot get children", ErrEdgeOnNonLeaf, parentID)
+	}
+	return nil
+}
+
+// MoveVertex przenosi wierzchołek (razem z poddrzewem) pod nowego rodzica.
+// Pusty parentID przenosi wierzchołek na najwyższy poziom. Cała operacja
+// wykonywana jest w jednej transakcji.
+func (s *DBStorage) MoveVertex(id string, parentID *string, version int64) (*models.Vertex, error) {
+	if parentID != nil && *parentID == "" {
+		parentID = nil
+	}
+
+	var moved models.Vertex
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txs := &DBStorage{db: tx}
+
+		if err := tx.First(&moved, "id = ?", id).Error; err != nil {
+			return notFound(err, "vertex "+id)
+		}
+		if version != 0 && version != moved.Version {
+			return ErrVersionConflict
+		}
+
+		if parentID != nil {
+			if _, err := txs.findVertex(*parentID, ErrParentNotFound, "vertex"); err != nil {
+				return err
+			}
+			// Walidacja: nowy rodzic nie może być potomkiem przenoszonego wierzchołka
+			if err := txs.validateNoCycle(*parentID, id); err != nil {
+				return err
+			}
+			// Walidacja: nowy rodzic przestanie być liściem, więc nie może mieć relacji
+			if err := txs.validateParentAcceptsChildren(*parentID); err != nil {
+				return err
+			}
+		}
+
+		// Poprzedni rodzic może stać się liściem - to nie narusza żadnej reguły,
+		// a poddrzewo przenoszonego wierzchołka zachowuje swoją strukturę
+		result := tx.Model(&moved).Where("version = ?", moved.Version).Updates(map[string]interface{}{
+			"parent_id": parentID,
+			"version":   gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return tx.First(&moved, "id = ?", id).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &moved, nil
+}
+
+func (s *DBStorage) DeleteVertex(id string, version int64) error {
+	// Usunięcie nie może zostawić relacji bez końca ani osieroconych dzieci
+	hasEdges, err := s.HasEdges(id)
+	if err != nil {
+		return err
 	}
-	return s.db.Save(vertex).Error
+	if hasEdges {
+		return fmt.Errorf("%w: vertex %s has edges - delete them first", ErrVertexInUse, id)
+	}
+	hasChildren, err := s.HasChildren(id)
+	if err != nil {
+		return err
+	}
+	if hasChildren {
+		return fmt.Errorf("%w: vertex %s has children - move or delete them first", ErrVertexInUse, id)
+	}
+	return s.deleteVersioned(&models.Vertex{}, id, version, "vertex "+id)
 }
 
-func (s *DBStorage) DeleteVertex(id string) error {
-	return s.db.Delete(&models.Vertex{}, "id = ?", id).Error
+// deleteVersioned usuwa rekord; przy version > 0 tylko gdy wersja w bazie się zgadza.
+// Brak rekordu zwraca jako ErrNotFound
+func (s *DBStorage) deleteVersioned(model interface{}, id string, version int64, what string) error {
+	query := s.db
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+	result := query.Delete(model, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		var count int64
+		if err := s.db.Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrVersionConflict
+		}
+		return fmt.Errorf("%s %w", what, ErrNotFound)
+	}
+	return nil
 }
 
 // HasChildren sprawdza czy wierzchołek ma dzieci
@@ -188,6 +618,16 @@ func (s *DBStorage) IsLeafVertex(vertexID string) (bool, error) {
 	return !hasChildren, nil
 }
 
+// HasEdges sprawdza czy wierzchołek jest źródłem lub celem jakiejkolwiek relacji
+func (s *DBStorage) HasEdges(vertexID string) (bool, error) {
+	var count int64
+	err := s.db.Model(&models.Edge{}).Where("\"from\" = ? OR \"to\" = ?", vertexID, vertexID).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // Relacje
 
 func (s *DBStorage) GetAllEdges() ([]models.Edge, error) {
@@ -200,19 +640,44 @@ func (s *DBStorage) GetEdgeByID(id string) (*models.Edge, error) {
 	var edge models.Edge
 	err := s.db.First(&edge, "id = ?", id).Error
 	if err != nil {
-		return nil, err
+		return nil, notFound(err, "edge "+id)
 	}
 	return &edge, nil
 }
 
 func (s *DBStorage) CreateEdge(edge *models.Edge) error {
+	if edge.ID == "" {
+		edge.ID = newID()
+	} else if err := s.checkIDFree(&models.Edge{}, "id", edge.ID, "edge "+edge.ID); err != nil {
+		return err
+	}
+
+	// Typ relacji musi pochodzić z katalogu
+	edgeType, err := s.validateEdgeType(edge)
+	if err != nil {
+		return err
+	}
+	if err := validateEdgeLatency(edge); err != nil {
+		return err
+	}
+
+	if err := s.checkEdgeUnique(edge); err != nil {
+		return err
+	}
+
 	// Sprawdź czy wierzchołki istnieją
-	var fromVertex, toVertex models.Vertex
-	if err := s.db.First(&fromVertex, "id = ?", edge.From).Error; err != nil {
-		return fmt.Errorf("source vertex not found: %w", err)
+	fromVertex, err := s.findVertex(edge.From, ErrEndpointNotFound, "source vertex")
+	if err != nil {
+		return err
+	}
+	toVertex, err := s.findVertex(edge.To, ErrEndpointNotFound, "target vertex")
+	if err != nil {
+		return err
 	}
-	if err := s.db.First(&toVertex, "id = ?", edge.To).Error; err != nil {
-		return fmt.Errorf("target vertex not found: %w", err)
+
+	// Rodzaje wierzchołków muszą pasować do typu relacji
+	if err := s.checkEdgeKinds(edgeType, fromVertex, toVertex); err != nil {
+		return err
 	}
 
 	// Sprawdź czy oba wierzchołki są na najniższym poziomie (nie mają dzieci)
@@ -221,7 +686,7 @@ func (s *DBStorage) CreateEdge(edge *models.Edge) error {
 		return fmt.Errorf("failed to check if source vertex is leaf: %w", err)
 	}
 	if !fromIsLeaf {
-		return fmt.Errorf("source vertex %s has children - edges can only be created between leaf vertices", edge.From)
+		return fmt.Errorf("%w: source vertex %s has children", ErrEdgeOnNonLeaf, edge.From)
 	}
 
 	toIsLeaf, err := s.IsLeafVertex(edge.To)
@@ -229,20 +694,56 @@ func (s *DBStorage) CreateEdge(edge *models.Edge) error {
 		return fmt.Errorf("failed to check if target vertex is leaf: %w", err)
 	}
 	if !toIsLeaf {
-		return fmt.Errorf("target vertex %s has children - edges can only be created between leaf vertices", edge.To)
+		return fmt.Errorf("%w: target vertex %s has children", ErrEdgeOnNonLeaf, edge.To)
+	}
+
+	// Zależność nie może łamać reguł architektury
+	if err := s.checkEdgeRules(edge); err != nil {
+		return err
+	}
+	if err := s.checkEdgeLayers(edge); err != nil {
+		return err
 	}
 
+	edge.Version = 1
 	return s.db.Create(edge).Error
 }
 
 func (s *DBStorage) UpdateEdge(edge *models.Edge) error {
+	var current models.Edge
+	if err := s.db.First(&current, "id = ?", edge.ID).Error; err != nil {
+		return notFound(err, "edge "+edge.ID)
+	}
+	if edge.Version != 0 && edge.Version != current.Version {
+		return ErrVersionConflict
+	}
+
+	// Typ relacji musi pochodzić z katalogu
+	edgeType, err := s.validateEdgeType(edge)
+	if err != nil {
+		return err
+	}
+	if err := validateEdgeLatency(edge); err != nil {
+		return err
+	}
+
+	if err := s.checkEdgeUnique(edge); err != nil {
+		return err
+	}
+
 	// Sprawdź czy wierzchołki istnieją
-	var fromVertex, toVertex models.Vertex
-	if err := s.db.First(&fromVertex, "id = ?", edge.From).Error; err != nil {
-		return fmt.Errorf("source vertex not found: %w", err)
+	fromVertex, err := s.findVertex(edge.From, ErrEndpointNotFound, "source vertex")
+	if err != nil {
+		return err
+	}
+	toVertex, err := s.findVertex(edge.To, ErrEndpointNotFound, "target vertex")
+	if err != nil {
+		return err
 	}
-	if err := s.db.First(&toVertex, "id = ?", edge.To).Error; err != nil {
-		return fmt.Errorf("target vertex not found: %w", err)
+
+	// Rodzaje wierzchołków muszą pasować do typu relacji
+	if err := s.checkEdgeKinds(edgeType, fromVertex, toVertex); err != nil {
+		return err
 	}
 
 	// Sprawdź czy oba wierzchołki są na najniższym poziomie (nie mają dzieci)
@@ -251,7 +752,7 @@ func (s *DBStorage) UpdateEdge(edge *models.Edge) error {
 		return fmt.Errorf("failed to check if source vertex is leaf: %w", err)
 	}
 	if !fromIsLeaf {
-		return fmt.Errorf("source vertex %s has children - edges can only be created between leaf vertices", edge.From)
+		return fmt.Errorf("%w: source vertex %s has children", ErrEdgeOnNonLeaf, edge.From)
 	}
 
 	toIsLeaf, err := s.IsLeafVertex(edge.To)
@@ -259,14 +760,38 @@ func (s *DBStorage) UpdateEdge(edge *models.Edge) error {
 		return fmt.Errorf("failed to check if target vertex is leaf: %w", err)
 	}
 	if !toIsLeaf {
-		return fmt.Errorf("target vertex %s has children - edges can only be created between leaf vertices", edge.To)
+		return fmt.Errorf("%w: target vertex %s has children", ErrEdgeOnNonLeaf, edge.To)
 	}
 
-	return s.db.Save(edge).Error
+	// Zależność nie może łamać reguł architektury
+	if err := s.checkEdgeRules(edge); err != nil {
+		return err
+	}
+	if err := s.checkEdgeLayers(edge); err != nil {
+		return err
+	}
+
+	edge.CreatedAt = current.CreatedAt
+	edge.Version = current.Version + 1
+	return s.updateVersioned(edge, current.Version)
 }
 
-func (s *DBStorage) DeleteEdge(id string) error {
-	return s.db.Delete(&models.Edge{}, "id = ?", id).Error
+// checkEdgeUnique zwraca ErrDuplicateEdge, jeśli inna relacja łączy już te same
+// wierzchołki relacją tego samego typu
+func (s *DBStorage) checkEdgeUnique(edge *models.Edge) error {
+	var existing models.Edge
+	err := s.db.Where("\"from\" = ? AND \"to\" = ? AND type = ? AND id <> ?", edge.From, edge.To, edge.Type, edge.ID).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s -[%s]-> %s is edge %s", ErrDuplicateEdge, edge.From, edge.Type, edge.To, existing.ID)
+}
+
+func (s *DBStorage) DeleteEdge(id string, version int64) error {
+	return s.deleteVersioned(&models.Edge{}, id, version, "edge "+id)
 }
 
 // Graf
@@ -282,8 +807,14 @@ func (s *DBStorage) GetGraph() (*models.Graph, error) {
 		return nil, err
 	}
 
+	layerViolations, err := s.layerViolations(vertices, edges)
+	if err != nil {
+		return nil, err
+	}
+
 	return &models.Graph{
-		Vertices: vertices,
-		Edges:    edges,
+		Vertices:        vertices,
+		Edges:           edges,
+		LayerViolations: layerViolations,
 	}, nil
 }
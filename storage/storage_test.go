@@ -1,8 +1,14 @@
 package storage
 
 import (
+	"errors"
 	"os"
+	"strings"
 	"testing"
+
+	"microservice_overview/models"
+
+	"gorm.io/gorm"
 )
 
 func TestBuildPostgresDSN(t *testing.T) {
@@ -142,3 +148,131 @@ func TestGetEnv(t *testing.T) {
 	}
 }
 
+func TestWriteErrors(t *testing.T) {
+	s := newTestStorage(t)
+	s.CreateVertex(&models.Vertex{ID: "domain", Name: "Domain"})
+	s.CreateVertex(&models.Vertex{ID: "service", Name: "Service", ParentID: stringPtr("domain")})
+	s.CreateVertex(&models.Vertex{ID: "other", Name: "Other"})
+	if err := s.CreateEdge(&models.Edge{ID: "e1", From: "service", To: "other", Type: "calls"}); err != nil {
+		t.Fatalf("failed to create edge: %v", err)
+	}
+	if err := s.CreateEdge(&models.Edge{ID: "e2", From: "other", To: "service", Type: "calls"}); err != nil {
+		t.Fatalf("failed to create edge: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		run      func() error
+		expected error
+	}{
+		{"get unknown vertex", func() error { _, err := s.GetVertexByIDOrSlug("missing"); return err }, ErrNotFound},
+		{"update unknown edge", func() error {
+			return s.UpdateEdge(&models.Edge{ID: "missing", From: "service", To: "other", Type: "calls"})
+		}, ErrNotFound},
+		{"delete unknown vertex", func() error { return s.DeleteVertex("missing", 0) }, ErrNotFound},
+		{"delete unknown rule", func() error { return s.DeleteRule("missing") }, ErrNotFound},
+		{"delete unknown layer", func() error { return s.DeleteLayer("missing") }, ErrNotFound},
+		{"stale delete", func() error { return s.DeleteEdge("e1", 7) }, ErrVersionConflict},
+		{"duplicate vertex ID", func() error { return s.CreateVertex(&models.Vertex{ID: "other", Name: "Again"}) }, ErrDuplicateID},
+		{"duplicate edge type", func() error { return s.CreateEdgeType(&models.EdgeType{Name: "calls"}) }, ErrDuplicateID},
+		{"unknown parent", func() error { return s.CreateVertex(&models.Vertex{Name: "Orphan", ParentID: stringPtr("missing")}) }, ErrParentNotFound},
+		{"unknown edge endpoint", func() error { return s.CreateEdge(&models.Edge{From: "other", To: "missing", Type: "calls"}) }, ErrEndpointNotFound},
+		{"cycle", func() error { _, err := s.MoveVertex("domain", stringPtr("service"), 0); return err }, ErrHierarchyCycle},
+		{"edge on non-leaf", func() error { return s.CreateEdge(&models.Edge{From: "domain", To: "other", Type: "calls"}) }, ErrEdgeOnNonLeaf},
+		{"parent with edges", func() error { _, err := s.MoveVertex("domain", stringPtr("other"), 0); return err }, ErrEdgeOnNonLeaf},
+		{"duplicate edge", func() error { return s.CreateEdge(&models.Edge{From: "service", To: "other", Type: "calls"}) }, ErrDuplicateEdge},
+		{"update into duplicate edge", func() error {
+			return s.UpdateEdge(&models.Edge{ID: "e2", From: "service", To: "other", Type: "calls"})
+		}, ErrDuplicateEdge},
+		{"delete vertex with edges", func() error { return s.DeleteVertex("other", 0) }, ErrVertexInUse},
+		{"delete vertex with children", func() error { return s.DeleteVertex("domain", 0) }, ErrVertexInUse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	s := newTestStorage(t)
+
+	// Błąd wycofuje wszystkie zapisy, także zagnieżdżoną transakcję MoveVertex
+	err := s.Transaction(func(tx Storage) error {
+		if err := tx.CreateVertex(&models.Vertex{ID: "a", Name: "A"}); err != nil {
+			return err
+		}
+		if err := tx.CreateVertex(&models.Vertex{ID: "b", Name: "B"}); err != nil {
+			return err
+		}
+		if _, err := tx.MoveVertex("b", stringPtr("a"), 0); err != nil {
+			return err
+		}
+		return tx.CreateEdge(&models.Edge{From: "b", To: "missing", Type: "calls"})
+	})
+	if !errors.Is(err, ErrEndpointNotFound) {
+		t.Fatalf("Expected %v, got %v", ErrEndpointNotFound, err)
+	}
+	if vertices, _ := s.GetAllVertices(); len(vertices) != 0 {
+		t.Errorf("Expected the transaction to be rolled back, got %d vertices", len(vertices))
+	}
+
+	err = s.Transaction(func(tx Storage) error {
+		if err := tx.CreateVertex(&models.Vertex{ID: "a", Name: "A"}); err != nil {
+			return err
+		}
+		if err := tx.CreateVertex(&models.Vertex{ID: "b", Name: "B", ParentID: stringPtr("a")}); err != nil {
+			return err
+		}
+		// Zapis w transakcji jest widoczny dla kolejnych operacji
+		_, err := tx.GetVertexByIDOrSlug("b")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if b, err := s.GetVertexByID("b"); err != nil || b.ParentID == nil || *b.ParentID != "a" {
+		t.Errorf("Expected the committed child vertex, got %+v %v", b, err)
+	}
+}
+
+func TestCreateVertex_RetriesTakenSlug(t *testing.T) {
+	s := newTestStorage(t)
+	s.CreateVertex(&models.Vertex{ID: "other", Name: "Other"})
+
+	// Równoległy zapis zajmuje slug między sprawdzeniem a zapisem
+	raced := false
+	s.db.Callback().Query().After("gorm:query").Register("test:race", func(db *gorm.DB) {
+		if raced || !strings.Contains(db.Statement.SQL.String(), "slug = ") {
+			return
+		}
+		raced = true
+		db.Session(&gorm.Session{NewDB: true}).Exec("UPDATE vertices SET slug = ? WHERE id = ?", "orders", "other")
+	})
+	defer s.db.Callback().Query().Remove("test:race")
+
+	vertex := &models.Vertex{ID: "orders", Name: "Orders"}
+	if err := s.CreateVertex(vertex); err != nil {
+		t.Fatalf("CreateVertex() error = %v", err)
+	}
+	if !raced || vertex.Slug != "orders-2" {
+		t.Errorf("Expected the retry to pick orders-2, got %q (raced=%v)", vertex.Slug, raced)
+	}
+	if other, _ := s.GetVertexByID("other"); other == nil || other.Slug != "orders" {
+		t.Errorf("Expected the racing vertex to keep its slug, got %+v", other)
+	}
+}
+
+func TestGetVertexByIDOrSlug_DatabaseError(t *testing.T) {
+	s := newTestStorage(t)
+	sqlDB, _ := s.db.DB()
+	sqlDB.Close()
+
+	// Błąd bazy nie jest brakiem wierzchołka
+	if _, err := s.GetVertexByIDOrSlug("orders"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the database error, got %v", err)
+	}
+}
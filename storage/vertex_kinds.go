@@ -0,0 +1,394 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"microservice_overview/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrVertexKindInUse zwracany przy próbie usunięcia rodzaju, którego używają wierzchołki
+var ErrVertexKindInUse = errors.New("vertex kind is used by existing vertices")
+
+// ErrInvalidVertexKind zwracany gdy rodzaj wierzchołka lub jego metadane łamią reguły rejestru
+var ErrInvalidVertexKind = errors.New("invalid vertex kind")
+
+// ErrEdgeKindMismatch zwracany gdy rodzaje końców relacji nie pasują do jej typu
+// albo jeden z końców jest kontenerem
+var ErrEdgeKindMismatch = errors.New("edge does not match vertex kinds")
+
+// defaultVertexKinds rejestr rodzajów wierzchołków tworzony przy pierwszym uruchomieniu
+var defaultVertexKinds = []models.VertexKind{
+	{Name: models.KindService, Description: "Mikroserwis", Shape: "box", Color: "#97C2FC", MetadataSchema: models.MetadataSchema{
+		{Name: "owner", Type: models.FieldTypeString, Description: "Zespół odpowiedzialny za serwis"},
+		{Name: "repository", Type: models.FieldTypeString, Description: "Adres repozytorium"},
+		{Name: "language", Type: models.FieldTypeString},
+		{Name: "tier", Type: models.FieldTypeNumber, Description: "Poziom krytyczności (1 - najwyższy)"},
+		{Name: "deprecated", Type: models.FieldTypeBoolean},
+	}},
+	{Name: "database", Description: "Baza danych", Shape: "database", Color: "#F5B041", MetadataSchema: models.MetadataSchema{
+		{Name: "engine", Type: models.FieldTypeString, Required: true, Description: "Silnik bazy, np. postgres"},
+		{Name: "version", Type: models.FieldTypeString},
+		{Name: "deprecated", Type: models.FieldTypeBoolean},
+	}},
+	{Name: "queue", Description: "Kolejka lub temat (Kafka, RabbitMQ)", Shape: "hexagon", Color: "#58D68D", MetadataSchema: models.MetadataSchema{
+		{Name: "broker", Type: models.FieldTypeString, Description: "Broker, np. kafka"},
+		{Name: "partitions", Type: models.FieldTypeNumber},
+		{Name: "deprecated", Type: models.FieldTypeBoolean},
+	}},
+	{Name: "external_api", Description: "Zewnętrzne API (dostawca zewnętrzny)", Shape: "diamond", Color: "#EC7063", MetadataSchema: models.MetadataSchema{
+		{Name: "provider", Type: models.FieldTypeString},
+		{Name: "url", Type: models.FieldTypeString},
+		{Name: "deprecated", Type: models.FieldTypeBoolean},
+	}},
+	{Name: "team", Description: "Zespół lub domena - logiczne grupowanie wierzchołków", Shape: "ellipse", Color: "#D5D8DC", Container: true, MetadataSchema: models.MetadataSchema{
+		{Name: "lead", Type: models.FieldTypeString},
+		{Name: "channel", Type: models.FieldTypeString, Description: "Kanał kontaktowy zespołu"},
+	}},
+}
+
+// normalizeKindName sprowadza nazwę rodzaju do postaci kanonicznej (te same zasady co dla typów relacji)
+func normalizeKindName(name string) string {
+	return normalizeEdgeTypeName(name)
+}
+
+// seedVertexKinds dodaje brakujące rodzaje z domyślnego rejestru
+func (s *DBStorage) seedVertexKinds() error {
+	for _, kind := range defaultVertexKinds {
+		kind := kind
+		if err := s.db.Where("name = ?", kind.Name).FirstOrCreate(&kind).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateVertexKindDefinition sprawdza i normalizuje definicję rodzaju wierzchołka
+func validateVertexKindDefinition(kind *models.VertexKind) error {
+	kind.Name = normalizeKindName(kind.Name)
+	if !edgeTypeNamePattern.MatchString(kind.Name) {
+		return fmt.Errorf("%w name %q - use lowercase letters, digits and underscores", ErrInvalidVertexKind, kind.Name)
+	}
+
+	seen := make(map[string]bool)
+	for _, field := range kind.MetadataSchema {
+		if field.Name == "" {
+			return fmt.Errorf("%w: metadata field name is required", ErrInvalidVertexKind)
+		}
+		if seen[field.Name] {
+			return fmt.Errorf("%w: duplicate metadata field %q", ErrInvalidVertexKind, field.Name)
+		}
+		seen[field.Name] = true
+		switch field.Type {
+		case models.FieldTypeString, models.FieldTypeNumber, models.FieldTypeBoolean:
+		default:
+			return fmt.Errorf("%w: invalid type %q of metadata field %s - must be one of: string, number, boolean", ErrInvalidVertexKind, field.Type, field.Name)
+		}
+	}
+	return nil
+}
+
+// resolveVertexKind zwraca rodzaj z rejestru dla nazwy podanej w wierzchołku
+func (s *DBStorage) resolveVertexKind(name string) (*models.VertexKind, error) {
+	var kind models.VertexKind
+	err := s.db.First(&kind, "name = ?", normalizeKindName(name)).Error
+	if err == nil {
+		return &kind, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	var known []string
+	if err := s.db.Model(&models.VertexKind{}).Order("name").Pluck("name", &known).Error; err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w: unknown vertex kind %q - known kinds: %s", ErrInvalidVertexKind, name, strings.Join(known, ", "))
+}
+
+// validateKindNames sprawdza czy wszystkie rodzaje z listy istnieją w rejestrze i normalizuje je
+func (s *DBStorage) validateKindNames(kinds models.StringList) error {
+	for i, name := range kinds {
+		kind, err := s.resolveVertexKind(name)
+		if err != nil {
+			return err
+		}
+		kinds[i] = kind.Name
+	}
+	return nil
+}
+
+// validateVertexKind sprawdza rodzaj wierzchołka i jego metadane. Pusty rodzaj
+// oznacza serwis.
+func (s *DBStorage) validateVertexKind(vertex *models.Vertex) (*models.VertexKind, error) {
+	if strings.TrimSpace(vertex.Kind) == "" {
+		vertex.Kind = models.KindService
+	}
+	kind, err := s.resolveVertexKind(vertex.Kind)
+	if err != nil {
+		return nil, err
+	}
+	vertex.Kind = kind.Name
+	if err := validateMetadata(kind, vertex.Metadata); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidVertexKind, err)
+	}
+	return kind, nil
+}
+
+// validateMetadata sprawdza metadane względem schematu rodzaju. Rodzaj bez
+// schematu przyjmuje dowolne metadane.
+func validateMetadata(kind *models.VertexKind, metadata models.JSONMap) error {
+	if len(kind.MetadataSchema) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]models.MetadataField, len(kind.MetadataSchema))
+	for _, field := range kind.MetadataSchema {
+		fields[field.Name] = field
+		value, ok := metadata[field.Name]
+		if !ok || value == nil {
+			if field.Required {
+				return fmt.Errorf("metadata field %s is required for vertex kind %s", field.Name, kind.Name)
+			}
+			continue
+		}
+		if !metadataValueMatches(field.Type, value) {
+			return fmt.Errorf("metadata field %s of vertex kind %s must be a %s", field.Name, kind.Name, field.Type)
+		}
+	}
+
+	var unknown []string
+	for name := range metadata {
+		if _, ok := fields[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown metadata fields for vertex kind %s: %s", kind.Name, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// metadataValueMatches sprawdza typ wartości (liczby z JSON to float64, z kodu Go - int)
+func metadataValueMatches(fieldType string, value interface{}) bool {
+	switch fieldType {
+	case models.FieldTypeString:
+		_, ok := value.(string)
+		return ok
+	case models.FieldTypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case models.FieldTypeNumber:
+		switch value.(type) {
+		case float64, float32, int, int32, int64:
+			return true
+		}
+	}
+	return false
+}
+
+// validateEdgeKinds sprawdza reguły rodzajów dla relacji: wierzchołki grupujące
+// nie mają relacji, a typ relacji może ograniczać rodzaje źródła i celu
+func validateEdgeKinds(edgeType *models.EdgeType, from, to *models.Vertex, kinds map[string]models.VertexKind) error {
+	for _, v := range []*models.Vertex{from, to} {
+		if kinds[v.Kind].Container {
+			return fmt.Errorf("%w: vertex %s is of container kind %s - it groups other vertices and cannot have edges", ErrEdgeKindMismatch, v.ID, v.Kind)
+		}
+	}
+	if edgeType == nil {
+		return nil
+	}
+	if len(edgeType.AllowedSourceKinds) > 0 && !edgeType.AllowedSourceKinds.Contains(from.Kind) {
+		return fmt.Errorf("%w: edge type %s cannot start at vertex of kind %s - allowed source kinds: %s",
+			ErrEdgeKindMismatch, edgeType.Name, from.Kind, strings.Join(edgeType.AllowedSourceKinds, ", "))
+	}
+	if len(edgeType.AllowedTargetKinds) > 0 && !edgeType.AllowedTargetKinds.Contains(to.Kind) {
+		return fmt.Errorf("%w: edge type %s cannot end at vertex of kind %s - allowed target kinds: %s",
+			ErrEdgeKindMismatch, edgeType.Name, to.Kind, strings.Join(edgeType.AllowedTargetKinds, ", "))
+	}
+	return nil
+}
+
+// checkEdgeKinds wczytuje rodzaje obu końców relacji i sprawdza reguły rodzajów
+func (s *DBStorage) checkEdgeKinds(edgeType *models.EdgeType, from, to *models.Vertex) error {
+	kinds, err := s.vertexKindsByName()
+	if err != nil {
+		return err
+	}
+	return validateEdgeKinds(edgeType, from, to, kinds)
+}
+
+// validateKindChange sprawdza czy istniejące relacje wierzchołka dopuszczają jego nowy rodzaj
+func (s *DBStorage) validateKindChange(vertex *models.Vertex) error {
+	var edges []models.Edge
+	if err := s.db.Where(`"from" = ? OR "to" = ?`, vertex.ID, vertex.ID).Find(&edges).Error; err != nil {
+		return err
+	}
+	if len(edges) == 0 {
+		return nil
+	}
+
+	kinds, err := s.vertexKindsByName()
+	if err != nil {
+		return err
+	}
+	for _, edge := range edges {
+		from, to, err := s.edgeEndpoints(&edge, vertex)
+		if err != nil {
+			return err
+		}
+		var edgeType *models.EdgeType
+		if edge.Type != "" {
+			if edgeType, err = s.GetEdgeType(edge.Type); err != nil {
+				continue // Typ spoza katalogu wykrywa walidacja spójności
+			}
+		}
+		if err := validateEdgeKinds(edgeType, from, to, kinds); err != nil {
+			return fmt.Errorf("%w: cannot change kind of vertex %s to %s because of edge %s: %v", ErrInvalidVertexKind, vertex.ID, vertex.Kind, edge.ID, err)
+		}
+	}
+	return nil
+}
+
+// edgeEndpoints zwraca końce relacji, podstawiając zmieniany wierzchołek zamiast wersji z bazy
+func (s *DBStorage) edgeEndpoints(edge *models.Edge, changed *models.Vertex) (*models.Vertex, *models.Vertex, error) {
+	endpoint := func(id string) (*models.Vertex, error) {
+		if id == changed.ID {
+			return changed, nil
+		}
+		return s.GetVertexByID(id)
+	}
+	from, err := endpoint(edge.From)
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := endpoint(edge.To)
+	if err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+// vertexKindsByName zwraca rejestr rodzajów jako mapę
+func (s *DBStorage) vertexKindsByName() (map[string]models.VertexKind, error) {
+	kinds, err := s.GetAllVertexKinds()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]models.VertexKind, len(kinds))
+	for _, kind := range kinds {
+		byName[kind.Name] = kind
+	}
+	return byName, nil
+}
+
+// Rodzaje wierzchołków
+
+func (s *DBStorage) GetAllVertexKinds() ([]models.VertexKind, error) {
+	var kinds []models.VertexKind
+	err := s.db.Order("name").Find(&kinds).Error
+	return kinds, err
+}
+
+func (s *DBStorage) GetVertexKind(name string) (*models.VertexKind, error) {
+	var kind models.VertexKind
+	if err := s.db.First(&kind, "name = ?", normalizeKindName(name)).Error; err != nil {
+		return nil, notFound(err, "vertex kind "+name)
+	}
+	return &kind, nil
+}
+
+func (s *DBStorage) CreateVertexKind(kind *models.VertexKind) error {
+	if err := validateVertexKindDefinition(kind); err != nil {
+		return err
+	}
+	if err := s.checkIDFree(&models.VertexKind{}, "name", kind.Name, "vertex kind "+kind.Name); err != nil {
+		return err
+	}
+	return s.db.Create(kind).Error
+}
+
+func (s *DBStorage) UpdateVertexKind(kind *models.VertexKind) error {
+	if err := validateVertexKindDefinition(kind); err != nil {
+		return err
+	}
+	var current models.VertexKind
+	if err := s.db.First(&current, "name = ?", kind.Name).Error; err != nil {
+		return notFound(err, "vertex kind "+kind.Name)
+	}
+	if kind.Container && !current.Container {
+		var count int64
+		if err := s.db.Model(&models.Edge{}).
+			Where(`"from" IN (?) OR "to" IN (?)`,
+				s.db.Model(&models.Vertex{}).Select("id").Where("kind = ?", kind.Name),
+				s.db.Model(&models.Vertex{}).Select("id").Where("kind = ?", kind.Name)).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: vertex kind %s cannot become a container - its vertices have %d edge(s)", ErrInvalidVertexKind, kind.Name, count)
+		}
+	}
+	if err := s.validateKindVertices(kind); err != nil {
+		return err
+	}
+	kind.CreatedAt = current.CreatedAt
+	return s.db.Save(kind).Error
+}
+
+// maxReportedVertices ile niezgodnych wierzchołków wymienia błąd zmiany schematu
+const maxReportedVertices = 5
+
+// validateKindVertices sprawdza metadane istniejących wierzchołków rodzaju
+// względem nowego schematu i wymienia niezgodne wierzchołki
+func (s *DBStorage) validateKindVertices(kind *models.VertexKind) error {
+	var vertices []models.Vertex
+	if err := s.db.Where("kind = ?", kind.Name).Order("id").Find(&vertices).Error; err != nil {
+		return err
+	}
+	var invalid []string
+	for _, v := range vertices {
+		if err := validateMetadata(kind, v.Metadata); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s (%v)", v.ID, err))
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	reported := invalid
+	if len(reported) > maxReportedVertices {
+		reported = reported[:maxReportedVertices]
+	}
+	return fmt.Errorf("%w: metadata of %d vertex(es) of kind %s does not match the new schema: %s",
+		ErrInvalidVertexKind, len(invalid), kind.Name, strings.Join(reported, "; "))
+}
+
+func (s *DBStorage) DeleteVertexKind(name string) error {
+	name = normalizeKindName(name)
+
+	var count int64
+	if err := s.db.Model(&models.Vertex{}).Where("kind = ?", name).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d vertex(es) of kind %s", ErrVertexKindInUse, count, name)
+	}
+	var edgeTypes []models.EdgeType
+	if err := s.db.Find(&edgeTypes).Error; err != nil {
+		return err
+	}
+	var restricting []string
+	for _, edgeType := range edgeTypes {
+		if edgeType.AllowedSourceKinds.Contains(name) || edgeType.AllowedTargetKinds.Contains(name) {
+			restricting = append(restricting, edgeType.Name)
+		}
+	}
+	if len(restricting) > 0 {
+		return fmt.Errorf("%w: edge type(s) %s restrict endpoints to kind %s", ErrVertexKindInUse, strings.Join(restricting, ", "), name)
+	}
+	return deleted(s.db.Delete(&models.VertexKind{}, "name = ?", name), "vertex kind "+name)
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"microservice_overview/models"
+)
+
+func TestValidateMetadata(t *testing.T) {
+	kind := &models.VertexKind{Name: "database", MetadataSchema: models.MetadataSchema{
+		{Name: "engine", Type: models.FieldTypeString, Required: true},
+		{Name: "replicas", Type: models.FieldTypeNumber},
+		{Name: "managed", Type: models.FieldTypeBoolean},
+	}}
+
+	tests := []struct {
+		name     string
+		metadata models.JSONMap
+		wantErr  bool
+	}{
+		{name: "valid", metadata: models.JSONMap{"engine": "postgres", "replicas": float64(2), "managed": true}},
+		{name: "int number", metadata: models.JSONMap{"engine": "postgres", "replicas": 2}},
+		{name: "missing required", metadata: models.JSONMap{"replicas": float64(2)}, wantErr: true},
+		{name: "null required", metadata: models.JSONMap{"engine": nil}, wantErr: true},
+		{name: "wrong type", metadata: models.JSONMap{"engine": "postgres", "managed": "yes"}, wantErr: true},
+		{name: "unknown field", metadata: models.JSONMap{"engine": "postgres", "size": "xl"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMetadata(kind, tt.metadata)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Rodzaj bez schematu przyjmuje dowolne metadane
+	if err := validateMetadata(&models.VertexKind{Name: "custom"}, models.JSONMap{"anything": 1}); err != nil {
+		t.Errorf("validateMetadata() without schema error = %v", err)
+	}
+}
+
+func TestCreateEdge_KindRules(t *testing.T) {
+	s := newTestStorage(t)
+
+	s.CreateVertex(&models.Vertex{ID: "svc", Name: "Service"})
+	s.CreateVertex(&models.Vertex{ID: "db", Name: "Database", Kind: "database", Metadata: models.JSONMap{"engine": "postgres"}})
+	s.CreateVertex(&models.Vertex{ID: "team", Name: "Team", Kind: "team"})
+
+	tests := []struct {
+		name    string
+		edge    models.Edge
+		wantErr bool
+	}{
+		{name: "service calls database", edge: models.Edge{From: "svc", To: "db", Type: "calls"}},
+		{name: "database cannot call", edge: models.Edge{From: "db", To: "svc", Type: "calls"}, wantErr: true},
+		{name: "database cannot call async", edge: models.Edge{From: "db", To: "svc", Type: "calls_async"}, wantErr: true},
+		{name: "untyped edge from database", edge: models.Edge{From: "db", To: "svc"}},
+		{name: "container has no edges", edge: models.Edge{From: "svc", To: "team", Type: "requires"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edge := tt.edge
+			err := s.CreateEdge(&edge)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateEdge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateVertex_KindChangeBreaksEdges(t *testing.T) {
+	s := newTestStorage(t)
+
+	s.CreateVertex(&models.Vertex{ID: "a", Name: "A"})
+	s.CreateVertex(&models.Vertex{ID: "b", Name: "B"})
+	if err := s.CreateEdge(&models.Edge{ID: "e1", From: "a", To: "b", Type: "calls"}); err != nil {
+		t.Fatalf("CreateEdge() error = %v", err)
+	}
+
+	// Źródło relacji calls nie może stać się bazą danych
+	err := s.UpdateVertex(&models.Vertex{ID: "a", Name: "A", Kind: "database", Metadata: models.JSONMap{"engine": "postgres"}})
+	if err == nil {
+		t.Error("Expected error when changing kind of calls source to database")
+	}
+
+	// Cel relacji calls może
+	if err := s.UpdateVertex(&models.Vertex{ID: "b", Name: "B", Kind: "database", Metadata: models.JSONMap{"engine": "postgres"}}); err != nil {
+		t.Errorf("UpdateVertex() error = %v", err)
+	}
+}
+
+func TestDeleteVertexKind_InUse(t *testing.T) {
+	s := newTestStorage(t)
+
+	s.CreateVertex(&models.Vertex{ID: "q", Name: "Orders topic", Kind: "queue"})
+
+	if err := s.DeleteVertexKind("queue"); !errors.Is(err, ErrVertexKindInUse) {
+		t.Errorf("Expected ErrVertexKindInUse for kind with vertices, got %v", err)
+	}
+	// external_api występuje w dozwolonych rodzajach typu calls
+	if err := s.DeleteVertexKind("external_api"); !errors.Is(err, ErrVertexKindInUse) || !strings.Contains(err.Error(), "calls") {
+		t.Errorf("Expected ErrVertexKindInUse naming the edge type, got %v", err)
+	}
+
+	// Nazwy porównywane dokładnie - "_" nie jest symbolem wieloznacznym
+	for _, name := range []string{"read_db", "readxdb"} {
+		if err := s.CreateVertexKind(&models.VertexKind{Name: name}); err != nil {
+			t.Fatalf("CreateVertexKind(%s) error = %v", name, err)
+		}
+	}
+	if err := s.CreateEdgeType(&models.EdgeType{Name: "replicates", AllowedTargetKinds: models.StringList{"readxdb"}}); err != nil {
+		t.Fatalf("CreateEdgeType() error = %v", err)
+	}
+	if err := s.DeleteVertexKind("read_db"); err != nil {
+		t.Errorf("Expected kind not referenced by any edge type to be deleted, got %v", err)
+	}
+
+	if err := s.CreateVertexKind(&models.VertexKind{Name: "Cache"}); err != nil {
+		t.Fatalf("CreateVertexKind() error = %v", err)
+	}
+	if err := s.DeleteVertexKind("cache"); err != nil {
+		t.Errorf("DeleteVertexKind() error = %v", err)
+	}
+}
+
+func TestUpdateVertexKind_RevalidatesVertices(t *testing.T) {
+	s := newTestStorage(t)
+
+	s.CreateVertex(&models.Vertex{ID: "orders", Name: "Orders", Kind: "queue", Metadata: models.JSONMap{"broker": "kafka"}})
+	s.CreateVertex(&models.Vertex{ID: "events", Name: "Events", Kind: "queue"})
+
+	kind, _ := s.GetVertexKind("queue")
+	kind.MetadataSchema = append(kind.MetadataSchema, models.MetadataField{Name: "owner", Type: models.FieldTypeString, Required: true})
+	err := s.UpdateVertexKind(kind)
+	if !errors.Is(err, ErrInvalidVertexKind) || !strings.Contains(err.Error(), "events") || !strings.Contains(err.Error(), "orders") {
+		t.Errorf("Expected ErrInvalidVertexKind listing the vertices without owner, got %v", err)
+	}
+
+	// Schemat zgodny z istniejącymi metadanymi przechodzi
+	kind, _ = s.GetVertexKind("queue")
+	kind.MetadataSchema = append(kind.MetadataSchema, models.MetadataField{Name: "retention_days", Type: models.FieldTypeNumber})
+	if err := s.UpdateVertexKind(kind); err != nil {
+		t.Errorf("UpdateVertexKind() error = %v", err)
+	}
+}
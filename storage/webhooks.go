@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"time"
+
+	"microservice_overview/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Webhooki
+
+func (s *DBStorage) GetAllWebhooks() ([]models.Webhook, error) {
+	var all []models.Webhook
+	err := s.db.Order("created_at, id").Find(&all).Error
+	return all, err
+}
+
+func (s *DBStorage) GetWebhookByID(id string) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := s.db.First(&webhook, "id = ?", id).Error; err != nil {
+		return nil, notFound(err, "webhook "+id)
+	}
+	return &webhook, nil
+}
+
+func (s *DBStorage) CreateWebhook(webhook *models.Webhook) error {
+	if webhook.ID == "" {
+		webhook.ID = newID()
+	} else if err := s.checkIDFree(&models.Webhook{}, "id", webhook.ID, "webhook "+webhook.ID); err != nil {
+		return err
+	}
+	return s.db.Create(webhook).Error
+}
+
+func (s *DBStorage) UpdateWebhook(webhook *models.Webhook) error {
+	var current models.Webhook
+	if err := s.db.First(&current, "id = ?", webhook.ID).Error; err != nil {
+		return notFound(err, "webhook "+webhook.ID)
+	}
+	if webhook.Secret == "" {
+		webhook.Secret = current.Secret
+	}
+	webhook.CreatedAt = current.CreatedAt
+	return s.db.Save(webhook).Error
+}
+
+func (s *DBStorage) DeleteWebhook(id string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.WebhookDelivery{}, "webhook_id = ?", id).Error; err != nil {
+			return err
+		}
+		return deleted(tx.Delete(&models.Webhook{}, "id = ?", id), "webhook "+id)
+	})
+}
+
+func (s *DBStorage) RecordWebhookDelivery(delivery *models.WebhookDelivery) error {
+	if delivery.ID == "" {
+		delivery.ID = newID()
+	}
+	// Blokada wiersza webhooka wstrzymuje równoległe DeleteWebhook - próba
+	// zakończona w trakcie usuwania nie zostawi wpisu bez webhooka
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var webhook models.Webhook
+		if err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Select("id").First(&webhook, "id = ?", delivery.WebhookID).Error; err != nil {
+			return notFound(err, "webhook "+delivery.WebhookID)
+		}
+		return tx.Create(delivery).Error
+	})
+}
+
+func (s *DBStorage) GetWebhookDeliveries(webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := s.db.Where("webhook_id = ?", webhookID).Order("created_at DESC, id DESC").Limit(limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (s *DBStorage) DeleteWebhookDeliveriesBefore(t time.Time) (int64, error) {
+	result := s.db.Delete(&models.WebhookDelivery{}, "created_at < ?", t)
+	return result.RowsAffected, result.Error
+}
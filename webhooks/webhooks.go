@@ -0,0 +1,461 @@
+// Package webhooks wysyła zmiany grafu do zarejestrowanych webhooków:
+// podpisane żądania POST z ponawianiem i zapisem każdej próby w logu dostarczeń.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"microservice_overview/events"
+	"microservice_overview/models"
+	"microservice_overview/storage"
+)
+
+// EventPing zdarzenie testowe wysyłane na żądanie (POST /api/webhooks/:id/ping)
+const EventPing = "ping"
+
+// Nagłówki żądań webhooka
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Domyślna polityka dostarczania
+const (
+	defaultMaxAttempts = 5
+	defaultBackoff     = time.Second // Opóźnienie pierwszej ponownej próby, podwajane przy kolejnych
+	defaultTimeout     = 10 * time.Second
+	defaultWorkers     = 8                  // Równoległe próby dostarczenia
+	defaultRetention   = 7 * 24 * time.Hour // Jak długo log dostarczeń przechowuje próby
+	dispatchBuffer     = events.SubscriberBuffer
+	deliveryQueue      = 1024             // Dostarczenia czekające na wolnego workera
+	subscriptionsTTL   = 30 * time.Second // Jak długo lista webhooków jest pamiętana (zmiany z innych replik)
+	purgeInterval      = time.Hour        // Co ile Run usuwa stare wpisy logu dostarczeń
+)
+
+// ErrInvalidWebhook zwracany gdy definicja webhooka jest niepoprawna
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+// errLocalTarget połączenie z adresem lokalnym zablokowane przez politykę dostarczania
+var errLocalTarget = errors.New("webhook target resolves to a loopback or link-local address")
+
+// Validate sprawdza adres i filtr zdarzeń webhooka. Filtr przyjmuje pełne
+// nazwy zdarzeń (edge.created), wzorce vertex.* i edge.* oraz *
+func Validate(webhook *models.Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidWebhook)
+	}
+	for _, pattern := range webhook.Events {
+		if pattern != "*" && pattern != "vertex.*" && pattern != "edge.*" && !events.IsType(pattern) {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, pattern)
+		}
+	}
+	return nil
+}
+
+// localAddress mówi czy adres wskazuje na sam serwer lub jego sieć lokalną
+// (loopback, link-local - w tym 169.254.169.254 usług metadanych chmury)
+func localAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified()
+}
+
+// Matches sprawdza czy webhook subskrybuje zdarzenie danego typu
+func Matches(webhook *models.Webhook, eventType string) bool {
+	if len(webhook.Events) == 0 {
+		return true
+	}
+	for _, pattern := range webhook.Events {
+		if pattern == "*" || pattern == eventType ||
+			(strings.HasSuffix(pattern, ".*") && strings.HasPrefix(eventType, strings.TrimSuffix(pattern, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign zwraca podpis treści: "sha256=" + HMAC-SHA256(secret, timestamp + "." + body)
+// w zapisie szesnastkowym. Odbiorca liczy go tak samo i porównuje z nagłówkiem
+// X-Webhook-Signature; znacznik czasu chroni przed powtórzeniem starego żądania
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Config polityka dostarczania webhooków
+type Config struct {
+	MaxAttempts       int           // Łączna liczba prób dostarczenia
+	Backoff           time.Duration // Opóźnienie pierwszej ponownej próby, podwajane przy kolejnych
+	Workers           int           // Równoległe próby dostarczenia
+	Retention         time.Duration // Jak długo log dostarczeń przechowuje próby
+	AllowLocalTargets bool          // Czy webhooki mogą wskazywać adresy loopback i link-local
+}
+
+// DefaultConfig zwraca domyślną politykę dostarczania
+func DefaultConfig() Config {
+	return Config{
+		MaxAttempts: defaultMaxAttempts,
+		Backoff:     defaultBackoff,
+		Workers:     defaultWorkers,
+		Retention:   defaultRetention,
+	}
+}
+
+// LoadConfig wczytuje politykę dostarczania ze zmiennych WEBHOOK_WORKERS,
+// WEBHOOK_DELIVERY_RETENTION (czas w formacie Go, np. 168h) i
+// WEBHOOK_ALLOW_LOCAL_TARGETS (true/false); pozostałe ustawienia są domyślne
+func LoadConfig() (Config, error) {
+	config := DefaultConfig()
+	if value := os.Getenv("WEBHOOK_WORKERS"); value != "" {
+		workers, err := strconv.Atoi(value)
+		if err != nil || workers < 1 {
+			return config, fmt.Errorf("WEBHOOK_WORKERS must be a positive number, got %q", value)
+		}
+		config.Workers = workers
+	}
+	if value := os.Getenv("WEBHOOK_DELIVERY_RETENTION"); value != "" {
+		retention, err := time.ParseDuration(value)
+		if err != nil || retention <= 0 {
+			return config, fmt.Errorf("WEBHOOK_DELIVERY_RETENTION must be a positive duration (e.g. 168h), got %q", value)
+		}
+		config.Retention = retention
+	}
+	if value := os.Getenv("WEBHOOK_ALLOW_LOCAL_TARGETS"); value != "" {
+		allow, err := strconv.ParseBool(value)
+		if err != nil {
+			return config, fmt.Errorf("WEBHOOK_ALLOW_LOCAL_TARGETS must be true or false, got %q", value)
+		}
+		config.AllowLocalTargets = allow
+	}
+	return config, nil
+}
+
+// Dispatcher odbiera zdarzenia z szyny i dostarcza je do pasujących webhooków.
+// Próby wykonuje stała pula Workers, a ponowienia czekają na swoją kolej bez
+// zajmowania workera, więc kolejność odbioru nie jest gwarantowana - odbiorca
+// może porządkować zdarzenia po id
+type Dispatcher struct {
+	Config
+
+	storage storage.Storage
+	bus     *events.Bus
+	client  *http.Client
+
+	jobs    chan *delivery
+	workers sync.Once
+	wg      sync.WaitGroup
+
+	mu            sync.Mutex
+	subscriptions []models.Webhook // nil - do wczytania przy następnym zdarzeniu
+	loadedAt      time.Time
+}
+
+// delivery dostarczenie jednego zdarzenia do jednego webhooka
+type delivery struct {
+	ctx     context.Context
+	webhook models.Webhook
+	event   events.Event
+	id      string
+	body    []byte
+	attempt int // Numer następnej próby, od 1
+}
+
+// NewDispatcher tworzy dyspozytora z domyślną polityką (zob. DefaultConfig)
+func NewDispatcher(s storage.Storage, bus *events.Bus) *Dispatcher {
+	d := &Dispatcher{
+		Config:  DefaultConfig(),
+		storage: s,
+		bus:     bus,
+		jobs:    make(chan *delivery, deliveryQueue),
+	}
+	// Adres sprawdzany jest przy każdym połączeniu, już po rozwiązaniu nazwy -
+	// także po przekierowaniu i zmianie DNS po rejestracji webhooka
+	dialer := &net.Dialer{Timeout: defaultTimeout, Control: d.checkTarget}
+	d.client = &http.Client{
+		Timeout:   defaultTimeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: defaultTimeout},
+	}
+	return d
+}
+
+// Validate sprawdza webhook (zob. Validate) oraz, o ile polityka nie
+// dopuszcza adresów lokalnych, odrzuca adresy loopback i link-local
+func (d *Dispatcher) Validate(webhook *models.Webhook) error {
+	if err := Validate(webhook); err != nil {
+		return err
+	}
+	if d.AllowLocalTargets {
+		return nil
+	}
+	u, _ := url.Parse(webhook.URL)
+	host := strings.ToLower(u.Hostname())
+	ip := net.ParseIP(host)
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || (ip != nil && localAddress(ip)) {
+		return fmt.Errorf("%w: url must not point to a loopback or link-local address", ErrInvalidWebhook)
+	}
+	return nil
+}
+
+// checkTarget blokuje połączenia z adresami lokalnymi (net.Dialer.Control)
+func (d *Dispatcher) checkTarget(network, address string, _ syscall.RawConn) error {
+	if d.AllowLocalTargets {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip != nil && localAddress(ip) {
+		return fmt.Errorf("%w: %s", errLocalTarget, host)
+	}
+	return nil
+}
+
+// Run dostarcza zdarzenia i co godzinę usuwa stare wpisy logu dostarczeń, do
+// zamknięcia ctx, które przerywa też ponawianie
+func (d *Dispatcher) Run(ctx context.Context) {
+	go d.purge(ctx)
+	for {
+		sub := d.bus.Subscribe(dispatchBuffer)
+		d.consume(ctx, sub)
+		sub.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		// Szyna rozłączyła zbyt wolnego odbiorcę - zdarzenia z przerwy przepadają
+		log.Printf("webhooks: %v, resubscribing", sub.Err())
+	}
+}
+
+func (d *Dispatcher) consume(ctx context.Context, sub *events.Subscription) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			if event.Remote {
+				// Zapis innej repliki dostarcza ta replika, która go wykonała
+				continue
+			}
+			webhooks, err := d.loadSubscriptions()
+			if err != nil {
+				log.Printf("webhooks: failed to load subscriptions for event %d: %v", event.ID, err)
+				continue
+			}
+			for i := range webhooks {
+				if !webhooks[i].Disabled && Matches(&webhooks[i], event.Type) {
+					d.Deliver(ctx, webhooks[i], event)
+				}
+			}
+		}
+	}
+}
+
+// loadSubscriptions zwraca zapamiętaną listę webhooków, wczytując ją ponownie
+// po Invalidate albo po subscriptionsTTL
+func (d *Dispatcher) loadSubscriptions() ([]models.Webhook, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.subscriptions != nil && time.Since(d.loadedAt) < subscriptionsTTL {
+		return d.subscriptions, nil
+	}
+	webhooks, err := d.storage.GetAllWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	if webhooks == nil {
+		webhooks = []models.Webhook{}
+	}
+	d.subscriptions, d.loadedAt = webhooks, time.Now()
+	return webhooks, nil
+}
+
+// Invalidate unieważnia zapamiętaną listę webhooków; wywoływane po każdej
+// zmianie webhooków przez API
+func (d *Dispatcher) Invalidate() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscriptions = nil
+}
+
+// Purge usuwa wpisy logu dostarczeń starsze niż Retention
+func (d *Dispatcher) Purge() (int64, error) {
+	return d.storage.DeleteWebhookDeliveriesBefore(time.Now().Add(-d.Retention))
+}
+
+func (d *Dispatcher) purge(ctx context.Context) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.Purge(); err != nil {
+				log.Printf("webhooks: failed to purge delivery log: %v", err)
+			}
+		}
+	}
+}
+
+// Ping wysyła do webhooka zdarzenie testowe i zwraca identyfikator
+// dostarczenia (wynik trafia do logu dostarczeń). Dostarczenie nie zależy
+// od żądania, które je zleciło
+func (d *Dispatcher) Ping(webhook models.Webhook) string {
+	return d.Deliver(context.Background(), webhook, events.Event{Type: EventPing, Time: time.Now().UTC()})
+}
+
+// Deliver kolejkuje dostarczenie zdarzenia; nieudane próby są ponawiane
+func (d *Dispatcher) Deliver(ctx context.Context, webhook models.Webhook, event events.Event) string {
+	d.workers.Do(func() {
+		for i := 0; i < d.Workers; i++ {
+			go d.work()
+		}
+	})
+
+	job := &delivery{ctx: ctx, webhook: webhook, event: event, id: newDeliveryID(), attempt: 1}
+	job.body, _ = json.Marshal(event)
+	d.wg.Add(1)
+	d.enqueue(job)
+	return job.id
+}
+
+// enqueue przekazuje próbę workerom. Gdy kolejka jest pełna, dostarczenie
+// jest porzucane z wpisem w logu - dyspozytor nie może blokować szyny
+func (d *Dispatcher) enqueue(job *delivery) {
+	select {
+	case d.jobs <- job:
+	default:
+		d.record(job, &models.WebhookDelivery{Error: "delivery queue is full"})
+		d.wg.Done()
+	}
+}
+
+func (d *Dispatcher) work() {
+	for job := range d.jobs {
+		d.process(job)
+	}
+}
+
+// process wykonuje kolejną próbę i planuje ponowienie z wykładniczym opóźnieniem
+func (d *Dispatcher) process(job *delivery) {
+	if job.ctx.Err() != nil {
+		d.wg.Done()
+		return
+	}
+	if !d.attempt(job) || job.attempt == d.MaxAttempts {
+		d.wg.Done()
+		return
+	}
+
+	// Ponowienie czeka na timerze, nie na workerze; zamknięcie ctx je anuluje.
+	// Callbacki odwołują się do siebie nawzajem - ready gwarantuje, że oba
+	// istnieją, zanim któryś się wykona
+	delay := d.Backoff << (job.attempt - 1)
+	job.attempt++
+	ready := make(chan struct{})
+	var timer *time.Timer
+	var stop func() bool
+	timer = time.AfterFunc(delay, func() {
+		<-ready
+		stop()
+		d.enqueue(job)
+	})
+	stop = context.AfterFunc(job.ctx, func() {
+		<-ready
+		if timer.Stop() {
+			d.wg.Done()
+		}
+	})
+	close(ready)
+}
+
+// attempt wykonuje jedną próbę i zapisuje ją w logu; zwraca true, jeśli
+// warto spróbować ponownie
+func (d *Dispatcher) attempt(job *delivery) bool {
+	record := &models.WebhookDelivery{}
+	retry := false
+
+	start := time.Now()
+	req, err := http.NewRequestWithContext(job.ctx, http.MethodPost, job.webhook.URL, bytes.NewReader(job.body))
+	if err == nil {
+		timestamp := time.Now().Unix()
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "microservice-overview-webhooks")
+		req.Header.Set(HeaderEvent, job.event.Type)
+		req.Header.Set(HeaderDelivery, job.id)
+		req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+		if job.webhook.Secret != "" {
+			req.Header.Set(HeaderSignature, Sign(job.webhook.Secret, timestamp, job.body))
+		}
+		var resp *http.Response
+		resp, err = d.client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			record.StatusCode = resp.StatusCode
+			record.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+			retry = resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		} else {
+			retry = job.ctx.Err() == nil && !errors.Is(err, errLocalTarget)
+		}
+	}
+	record.DurationMs = float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	// Webhook usunięty w trakcie dostarczania nie dostaje już ponowień
+	return d.record(job, record) && retry
+}
+
+// record zapisuje próbę w logu dostarczeń; zwraca false, jeśli webhook usunięto
+func (d *Dispatcher) record(job *delivery, record *models.WebhookDelivery) bool {
+	record.WebhookID = job.webhook.ID
+	record.DeliveryID = job.id
+	record.EventID = job.event.ID
+	record.EventType = job.event.Type
+	record.Attempt = job.attempt
+	err := d.storage.RecordWebhookDelivery(record)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return false
+	case err != nil:
+		log.Printf("webhooks: failed to record delivery %s: %v", job.id, err)
+	}
+	return true
+}
+
+// Wait czeka na zakończenie wszystkich rozpoczętych dostarczeń
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+func newDeliveryID() string {
+	var b [12]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,500 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"microservice_overview/events"
+	"microservice_overview/models"
+	"microservice_overview/storage"
+)
+
+// receiver lokalny odbiorca webhooków zapisujący otrzymane żądania
+type receiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	statuses []int // Kody kolejnych odpowiedzi; po wyczerpaniu 200
+	received chan struct{}
+}
+
+func newReceiver(statuses ...int) (*receiver, *httptest.Server) {
+	rec := &receiver{statuses: statuses, received: make(chan struct{}, 64)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rec.mu.Lock()
+		rec.requests = append(rec.requests, r)
+		rec.bodies = append(rec.bodies, body)
+		status := http.StatusOK
+		if len(rec.statuses) > 0 {
+			status, rec.statuses = rec.statuses[0], rec.statuses[1:]
+		}
+		rec.mu.Unlock()
+		w.WriteHeader(status)
+		rec.received <- struct{}{}
+	}))
+	return rec, server
+}
+
+func (r *receiver) wait(t *testing.T, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-r.received:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for request %d of %d", i+1, n)
+		}
+	}
+}
+
+func setupStorage(t *testing.T) storage.Storage {
+	t.Helper()
+	os.Setenv("DEV_MODE", "true")
+	t.Cleanup(func() { os.Unsetenv("DEV_MODE") })
+	s, err := storage.NewStorage()
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	return s
+}
+
+func newTestDispatcher(s storage.Storage, bus *events.Bus) *Dispatcher {
+	d := NewDispatcher(s, bus)
+	d.Backoff = time.Millisecond
+	d.AllowLocalTargets = true // Odbiorcy testowi działają na 127.0.0.1
+	return d
+}
+
+func TestValidate(t *testing.T) {
+	valid := []models.Webhook{
+		{URL: "http://localhost:9000/hook"},
+		{URL: "https://example.com/hook", Events: models.StringList{"edge.created", "vertex.*", "*"}},
+	}
+	for _, w := range valid {
+		if err := Validate(&w); err != nil {
+			t.Errorf("expected %+v to be valid, got %v", w, err)
+		}
+	}
+
+	invalid := []models.Webhook{
+		{URL: ""},
+		{URL: "/relative"},
+		{URL: "ftp://example.com"},
+		{URL: "http://example.com", Events: models.StringList{"edge.renamed"}},
+		{URL: "http://example.com", Events: models.StringList{"layer.*"}},
+	}
+	for _, w := range invalid {
+		if err := Validate(&w); !errors.Is(err, ErrInvalidWebhook) {
+			t.Errorf("expected ErrInvalidWebhook for %+v, got %v", w, err)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	cases := []struct {
+		filter    models.StringList
+		eventType string
+		expected  bool
+	}{
+		{nil, events.EdgeCreated, true},
+		{models.StringList{"*"}, events.VertexDeleted, true},
+		{models.StringList{"edge.*"}, events.EdgeDeleted, true},
+		{models.StringList{"edge.*"}, events.VertexCreated, false},
+		{models.StringList{"vertex.updated", "edge.created"}, events.EdgeCreated, true},
+		{models.StringList{"vertex.updated"}, events.VertexCreated, false},
+	}
+	for _, tc := range cases {
+		if got := Matches(&models.Webhook{Events: tc.filter}, tc.eventType); got != tc.expected {
+			t.Errorf("Matches(%v, %s) = %v, expected %v", tc.filter, tc.eventType, got, tc.expected)
+		}
+	}
+}
+
+func TestDispatcher_DeliversSignedEvents(t *testing.T) {
+	s := setupStorage(t)
+	bus := events.NewBus()
+	rec, server := newReceiver()
+	defer server.Close()
+
+	s.CreateWebhook(&models.Webhook{ID: "signed", URL: server.URL, Secret: "s3cret", Events: models.StringList{"vertex.*"}})
+
+	d, stop := startDispatcher(s, bus)
+	defer stop()
+
+	bus.Publish(events.Event{Type: events.EdgeCreated, Edge: &models.Edge{ID: "e1"}}) // poza filtrem
+	bus.Publish(events.Event{Type: events.VertexCreated, Vertex: &models.Vertex{ID: "a", Name: "A"}})
+	rec.wait(t, 1)
+
+	rec.mu.Lock()
+	req, body := rec.requests[0], rec.bodies[0]
+	rec.mu.Unlock()
+
+	if req.Header.Get(HeaderEvent) != events.VertexCreated {
+		t.Errorf("expected %s header %s, got %q", HeaderEvent, events.VertexCreated, req.Header.Get(HeaderEvent))
+	}
+	timestamp, err := strconv.ParseInt(req.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid timestamp header: %v", err)
+	}
+	if got := req.Header.Get(HeaderSignature); got != Sign("s3cret", timestamp, body) {
+		t.Errorf("signature %q does not match the body", got)
+	}
+
+	var event events.Event
+	if err := json.Unmarshal(body, &event); err != nil || event.Vertex == nil || event.Vertex.ID != "a" {
+		t.Errorf("expected the vertex event as body, got %s (%v)", body, err)
+	}
+
+	d.Wait()
+	deliveries, _ := s.GetWebhookDeliveries("signed", 10)
+	if len(deliveries) != 1 || !deliveries[0].Success || deliveries[0].StatusCode != http.StatusOK || deliveries[0].EventID != event.ID {
+		t.Errorf("expected one successful delivery in the log, got %+v", deliveries)
+	}
+}
+
+func TestDispatcher_RetriesFailedDeliveries(t *testing.T) {
+	s := setupStorage(t)
+	rec, server := newReceiver(http.StatusServiceUnavailable, http.StatusInternalServerError)
+	defer server.Close()
+
+	webhook := models.Webhook{ID: "flaky", URL: server.URL}
+	s.CreateWebhook(&webhook)
+
+	d := newTestDispatcher(s, events.NewBus())
+	deliveryID := d.Deliver(context.Background(), webhook, events.Event{ID: 7, Type: events.VertexDeleted})
+	rec.wait(t, 3)
+	d.Wait()
+
+	rec.mu.Lock()
+	for _, req := range rec.requests {
+		if req.Header.Get(HeaderDelivery) != deliveryID {
+			t.Errorf("expected every attempt to carry delivery id %s, got %s", deliveryID, req.Header.Get(HeaderDelivery))
+		}
+		if req.Header.Get(HeaderSignature) != "" {
+			t.Error("expected no signature without a secret")
+		}
+	}
+	rec.mu.Unlock()
+
+	deliveries, _ := s.GetWebhookDeliveries("flaky", 10)
+	if len(deliveries) != 3 {
+		t.Fatalf("expected 3 attempts in the log, got %d", len(deliveries))
+	}
+	successes := 0
+	for _, delivery := range deliveries {
+		if delivery.Success {
+			successes++
+			if delivery.Attempt != 3 {
+				t.Errorf("expected the third attempt to succeed, got %+v", delivery)
+			}
+		}
+	}
+	if successes != 1 {
+		t.Errorf("expected exactly one successful attempt, got %d", successes)
+	}
+}
+
+func TestDispatcher_StopsOnClientErrorAndAfterMaxAttempts(t *testing.T) {
+	s := setupStorage(t)
+
+	// 4xx (poza 408 i 429) jest ostateczne
+	rec, server := newReceiver(http.StatusGone)
+	defer server.Close()
+	gone := models.Webhook{ID: "gone", URL: server.URL}
+	s.CreateWebhook(&gone)
+
+	d := newTestDispatcher(s, events.NewBus())
+	d.Deliver(context.Background(), gone, events.Event{Type: events.EdgeCreated})
+	rec.wait(t, 1)
+	d.Wait()
+	if deliveries, _ := s.GetWebhookDeliveries("gone", 10); len(deliveries) != 1 || deliveries[0].Success {
+		t.Errorf("expected a single failed attempt, got %+v", deliveries)
+	}
+
+	// Nieosiągalny adres - wszystkie próby kończą się błędem sieci
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	unreachable := models.Webhook{ID: "unreachable", URL: closed.URL}
+	s.CreateWebhook(&unreachable)
+
+	d.MaxAttempts = 3
+	d.Deliver(context.Background(), unreachable, events.Event{Type: events.EdgeCreated})
+	d.Wait()
+	deliveries, _ := s.GetWebhookDeliveries("unreachable", 10)
+	if len(deliveries) != 3 {
+		t.Fatalf("expected MaxAttempts attempts, got %d", len(deliveries))
+	}
+	for _, delivery := range deliveries {
+		if delivery.Success || delivery.Error == "" {
+			t.Errorf("expected a network error to be recorded, got %+v", delivery)
+		}
+	}
+}
+
+func TestDispatcher_SkipsDisabledWebhooks(t *testing.T) {
+	s := setupStorage(t)
+	bus := events.NewBus()
+	rec, server := newReceiver()
+	defer server.Close()
+
+	s.CreateWebhook(&models.Webhook{ID: "off", URL: server.URL, Disabled: true})
+	s.CreateWebhook(&models.Webhook{ID: "on", URL: server.URL + "/on"})
+
+	d, stop := startDispatcher(s, bus)
+	defer stop()
+
+	bus.Publish(events.Event{Type: events.VertexCreated, Vertex: &models.Vertex{ID: "a"}})
+	rec.wait(t, 1)
+	d.Wait()
+
+	if deliveries, _ := s.GetWebhookDeliveries("off", 10); len(deliveries) != 0 {
+		t.Errorf("expected no deliveries for a disabled webhook, got %+v", deliveries)
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if len(rec.requests) != 1 || rec.requests[0].URL.Path != "/on" {
+		t.Errorf("expected a single request to the enabled webhook, got %d", len(rec.requests))
+	}
+}
+
+func TestDispatcher_SkipsRemoteEvents(t *testing.T) {
+	s := setupStorage(t)
+	bus := events.NewBus()
+	rec, server := newReceiver()
+	defer server.Close()
+	s.CreateWebhook(&models.Webhook{ID: "all", URL: server.URL})
+
+	d, stop := startDispatcher(s, bus)
+	defer stop()
+
+	// Zapis innej repliki dostarcza tamta replika
+	bus.Publish(events.Event{Type: events.VertexCreated, Vertex: &models.Vertex{ID: "remote"}, Remote: true})
+	bus.Publish(events.Event{Type: events.VertexCreated, Vertex: &models.Vertex{ID: "local"}})
+	rec.wait(t, 1)
+	d.Wait()
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if len(rec.bodies) != 1 || !strings.Contains(string(rec.bodies[0]), `"local"`) {
+		t.Errorf("expected only the local event to be delivered, got %d deliveries", len(rec.bodies))
+	}
+}
+
+// startDispatcher uruchamia pętlę dyspozytora na subskrypcji utworzonej
+// od razu, żeby żadne opublikowane później zdarzenie nie zostało pominięte
+func startDispatcher(s storage.Storage, bus *events.Bus) (*Dispatcher, func()) {
+	d := newTestDispatcher(s, bus)
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := bus.Subscribe(dispatchBuffer)
+	done := make(chan struct{})
+	go func() {
+		d.consume(ctx, sub)
+		close(done)
+	}()
+	return d, func() {
+		cancel()
+		<-done
+		sub.Close()
+		d.Wait()
+	}
+}
+
+func TestDispatcher_ValidateRejectsLocalTargets(t *testing.T) {
+	d := NewDispatcher(setupStorage(t), events.NewBus())
+
+	local := []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://api.localhost/hook",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://[::1]:9000/hook",
+		"http://[fe80::1]/hook",
+		"http://0.0.0.0/hook",
+	}
+	for _, target := range local {
+		if err := d.Validate(&models.Webhook{URL: target}); !errors.Is(err, ErrInvalidWebhook) {
+			t.Errorf("expected %s to be rejected, got %v", target, err)
+		}
+	}
+	if err := d.Validate(&models.Webhook{URL: "https://hooks.example.com/graph"}); err != nil {
+		t.Errorf("expected a public target to be valid, got %v", err)
+	}
+
+	d.AllowLocalTargets = true
+	for _, target := range local {
+		if err := d.Validate(&models.Webhook{URL: target}); err != nil {
+			t.Errorf("expected %s to be allowed by configuration, got %v", target, err)
+		}
+	}
+}
+
+func TestDispatcher_BlocksLocalTargetsOnConnect(t *testing.T) {
+	s := setupStorage(t)
+	rec, server := newReceiver()
+	defer server.Close()
+
+	// Webhook zapisany z pominięciem walidacji (np. nazwa, która później wskazała 127.0.0.1)
+	webhook := models.Webhook{ID: "rebound", URL: server.URL}
+	s.CreateWebhook(&webhook)
+
+	d := NewDispatcher(s, events.NewBus())
+	d.Backoff = time.Millisecond
+	d.Ping(webhook)
+	d.Wait()
+
+	deliveries, _ := s.GetWebhookDeliveries("rebound", 10)
+	if len(deliveries) != 1 || deliveries[0].Success || !strings.Contains(deliveries[0].Error, "loopback") {
+		t.Errorf("expected a single blocked attempt without retries, got %+v", deliveries)
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if len(rec.requests) != 0 {
+		t.Errorf("expected no request to reach the local receiver, got %d", len(rec.requests))
+	}
+}
+
+func TestDispatcher_BoundsConcurrentDeliveries(t *testing.T) {
+	s := setupStorage(t)
+	var mu sync.Mutex
+	active, peak := 0, 0
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		active++
+		if active > peak {
+			peak = active
+		}
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}))
+	defer server.Close()
+	defer close(release)
+
+	webhook := models.Webhook{ID: "busy", URL: server.URL}
+	s.CreateWebhook(&webhook)
+
+	d := newTestDispatcher(s, events.NewBus())
+	d.Workers = 2
+	for i := 0; i < 10; i++ {
+		d.Deliver(context.Background(), webhook, events.Event{ID: int64(i), Type: events.VertexCreated})
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if peak != 2 {
+		t.Errorf("expected at most %d concurrent deliveries, got %d", d.Workers, peak)
+	}
+}
+
+func TestDispatcher_StopsForDeletedWebhooks(t *testing.T) {
+	s := setupStorage(t)
+	started, release := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	webhook := models.Webhook{ID: "removed", URL: server.URL}
+	s.CreateWebhook(&webhook)
+
+	d := newTestDispatcher(s, events.NewBus())
+	d.Deliver(context.Background(), webhook, events.Event{Type: events.EdgeCreated})
+	<-started
+
+	// Webhook usunięty w trakcie próby - bez wpisu w logu i bez ponowień
+	if err := s.DeleteWebhook("removed"); err != nil {
+		t.Fatalf("DeleteWebhook() error = %v", err)
+	}
+	close(release)
+	d.Wait()
+
+	if deliveries, _ := s.GetWebhookDeliveries("removed", 10); len(deliveries) != 0 {
+		t.Errorf("expected no deliveries recorded for a deleted webhook, got %+v", deliveries)
+	}
+}
+
+func TestDispatcher_CachesSubscriptions(t *testing.T) {
+	s := setupStorage(t)
+	bus := events.NewBus()
+	rec, server := newReceiver()
+	defer server.Close()
+
+	s.CreateWebhook(&models.Webhook{ID: "first", URL: server.URL + "/first"})
+	d, stop := startDispatcher(s, bus)
+	defer stop()
+
+	bus.Publish(events.Event{Type: events.VertexCreated})
+	rec.wait(t, 1)
+
+	// Nowy webhook jest widoczny dopiero po unieważnieniu listy
+	s.CreateWebhook(&models.Webhook{ID: "second", URL: server.URL + "/second"})
+	bus.Publish(events.Event{Type: events.VertexUpdated})
+	rec.wait(t, 1)
+	d.Invalidate()
+	bus.Publish(events.Event{Type: events.VertexDeleted})
+	rec.wait(t, 2)
+	d.Wait()
+
+	if deliveries, _ := s.GetWebhookDeliveries("second", 10); len(deliveries) != 1 || deliveries[0].EventType != events.VertexDeleted {
+		t.Errorf("expected the second webhook to receive only the event after Invalidate, got %+v", deliveries)
+	}
+}
+
+func TestDispatcher_PurgesDeliveryLog(t *testing.T) {
+	s := setupStorage(t)
+	s.CreateWebhook(&models.Webhook{ID: "old", URL: "https://hooks.example.com"})
+	s.RecordWebhookDelivery(&models.WebhookDelivery{WebhookID: "old", DeliveryID: "d1", Attempt: 1})
+
+	d := NewDispatcher(s, events.NewBus())
+	if purged, err := d.Purge(); err != nil || purged != 0 {
+		t.Errorf("expected recent deliveries to be kept, got %d %v", purged, err)
+	}
+
+	d.Retention = time.Millisecond
+	time.Sleep(5 * time.Millisecond)
+	if purged, err := d.Purge(); err != nil || purged != 1 {
+		t.Errorf("expected one purged delivery, got %d %v", purged, err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("WEBHOOK_WORKERS", "")
+	t.Setenv("WEBHOOK_DELIVERY_RETENTION", "")
+	t.Setenv("WEBHOOK_ALLOW_LOCAL_TARGETS", "")
+	if config, err := LoadConfig(); err != nil || config != DefaultConfig() {
+		t.Errorf("expected the default config, got %+v %v", config, err)
+	}
+
+	t.Setenv("WEBHOOK_WORKERS", "4")
+	t.Setenv("WEBHOOK_DELIVERY_RETENTION", "72h")
+	t.Setenv("WEBHOOK_ALLOW_LOCAL_TARGETS", "true")
+	config, err := LoadConfig()
+	if err != nil || config.Workers != 4 || config.Retention != 72*time.Hour || !config.AllowLocalTargets {
+		t.Errorf("unexpected config %+v %v", config, err)
+	}
+
+	for key, value := range map[string]string{"WEBHOOK_WORKERS": "0", "WEBHOOK_DELIVERY_RETENTION": "week", "WEBHOOK_ALLOW_LOCAL_TARGETS": "maybe"} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, value)
+			if _, err := LoadConfig(); err == nil {
+				t.Errorf("expected an error for %s=%s", key, value)
+			}
+		})
+	}
+}